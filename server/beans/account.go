@@ -1,20 +1,91 @@
 package beans
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // models
 
 type Account struct {
 	ID        ID
 	Name      Name
+	Type      AccountType
 	OffBudget bool
 
+	// Category holding the money set aside to pay off a credit account. Empty
+	// for other accounts and for credit accounts that are off budget.
+	PaymentCategoryID ID
+
+	// Closed accounts are left out of the accounts to pick for a transaction,
+	// and transactions on them cannot be made or changed.
+	Closed bool
+
 	BudgetID ID
 }
 
+type AccountType string
+
+const (
+	AccountChecking     AccountType = "checking"
+	AccountSavings      AccountType = "savings"
+	AccountCash         AccountType = "cash"
+	AccountCreditCard   AccountType = "credit_card"
+	AccountLineOfCredit AccountType = "line_of_credit"
+	AccountLoan         AccountType = "loan"
+	AccountInvestment   AccountType = "investment"
+)
+
+func (t AccountType) Validate() error {
+	switch t {
+	case "", AccountChecking, AccountSavings, AccountCash, AccountCreditCard,
+		AccountLineOfCredit, AccountLoan, AccountInvestment:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", t)
+}
+
+// Whether spending on the account is paid off later. On-budget credit
+// accounts move their spending into a payment category.
+func (t AccountType) IsCredit() bool {
+	return t == AccountCreditCard || t == AccountLineOfCredit
+}
+
 type AccountWithBalance struct {
 	Account
 	Balance Amount
+
+	// Sum of cleared and reconciled transactions.
+	ClearedBalance Amount
+	// Sum of uncleared transactions.
+	UnclearedBalance Amount
+}
+
+// Running balance of an account at the end of a period.
+type AccountBalance struct {
+	AccountID ID
+
+	// First day of the period. Weeks start on Monday.
+	Date    Date
+	Balance Amount
+}
+
+type BalanceInterval string
+
+const (
+	BalanceDaily   BalanceInterval = "day"
+	BalanceWeekly  BalanceInterval = "week"
+	BalanceMonthly BalanceInterval = "month"
+)
+
+func (i BalanceInterval) Validate() error {
+	switch i {
+	case "", BalanceDaily, BalanceWeekly, BalanceMonthly:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", i)
 }
 
 type RelatedAccount struct {
@@ -26,33 +97,198 @@ type RelatedAccount struct {
 // repository
 
 type AccountRepository interface {
-	Create(ctx context.Context, account Account) error
+	Create(ctx context.Context, tx Tx, account Account) error
+	Update(ctx context.Context, tx Tx, account Account) error
+
+	// Deletes an account, along with anything still on it.
+	Delete(ctx context.Context, tx Tx, budgetID ID, id ID) error
+
+	// Moves all transactions on an account, including split lines, to
+	// another account. Import IDs the other account already has are
+	// cleared from the moved transactions. Scheduled transactions on or
+	// transferring to the account are moved too, except scheduled transfers
+	// between the two accounts, which are deleted.
+	MoveTransactions(ctx context.Context, tx Tx, budgetID ID, fromID ID, toID ID) error
 	Get(ctx context.Context, budgetID ID, id ID) (Account, error)
+	GetForBudget(ctx context.Context, budgetID ID) ([]Account, error)
 	GetWithBalance(ctx context.Context, budgetID ID) ([]AccountWithBalance, error)
+
+	// Gets the balance of an account.
+	GetBalance(ctx context.Context, tx Tx, budgetID ID, id ID) (Amount, error)
+
+	// Gets the running balance at the end of each period between the dates,
+	// ordered by account and date.
+	GetBalances(ctx context.Context, budgetID ID, params AccountBalanceParams) ([]AccountBalance, error)
+
+	// Gets all accounts that are not closed.
 	GetTransactable(ctx context.Context, budgetID ID) ([]Account, error)
 }
 
 // contract
 
 type AccountContract interface {
-	// Creates an account.
+	// Creates an account. On-budget credit accounts also get a payment
+	// category.
 	Create(ctx context.Context, auth *BudgetAuthContext, params AccountCreate) (ID, error)
 
 	// Gets all accounts associated with the budget.
 	GetAll(ctx context.Context, auth *BudgetAuthContext) ([]AccountWithBalance, error)
 
-	// Gets all accounts that can be used in a transaction.
+	// Gets all accounts that can be used in a transaction. Excludes closed
+	// accounts.
 	GetTransactable(ctx context.Context, auth *BudgetAuthContext) ([]Account, error)
 
 	// Gets an account's details.
 	Get(ctx context.Context, auth *BudgetAuthContext, id ID) (Account, error)
+
+	// Renames an account or moves it on or off budget. Only accounts without
+	// transactions can move on or off budget.
+	Update(ctx context.Context, auth *BudgetAuthContext, params AccountUpdateParams) error
+
+	// Closes an account. The balance must be zero.
+	Close(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Reopens a closed account.
+	Reopen(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Deletes an account. An account with transactions or scheduled
+	// transactions must move them to another open account that is also on or
+	// off budget. Transfers between the two accounts are deleted, as they
+	// would become transfers with itself. A credit account with a payment
+	// category must move to another credit account, which takes over the
+	// payment category's transactions and assigned money.
+	Delete(ctx context.Context, auth *BudgetAuthContext, params AccountDeleteParams) error
+
+	// Gets the running balances of one account, or all accounts, over time.
+	// Defaults to monthly balances. The range can span at most
+	// MaxBalancePeriods balances.
+	GetBalances(ctx context.Context, auth *BudgetAuthContext, params AccountBalanceParams) ([]AccountBalance, error)
+
+	// Reconciles an account against a bank statement. Cleared
+	// transactions up to the statement date are marked as reconciled.
+	// Returns the ID of the adjustment transaction, if one was made.
+	Reconcile(ctx context.Context, auth *BudgetAuthContext, params AccountReconcileParams) (ID, error)
 }
 
 type AccountCreate struct {
+	Name      Name
+	Type      AccountType
+	OffBudget bool
+
+	// Makes a starting balance transaction on the date, if not zero.
+	OpeningBalance Amount
+	OpeningDate    Date
+}
+
+func (p AccountCreate) ValidateAll() error {
+	openingDate := []Validatable{}
+	if p.HasOpeningBalance() {
+		openingDate = append(openingDate, Required(p.OpeningDate))
+	}
+
+	return ValidateFields(
+		Field("Account name", p.Name),
+		Field("Account type", p.Type),
+		Field("Opening balance", MaxPrecision(p.OpeningBalance)),
+		Field("Opening date", openingDate...),
+	)
+}
+
+func (p AccountCreate) HasOpeningBalance() bool {
+	return p.OpeningBalance.OrZero().Compare(NewAmount(0, 0)) != 0
+}
+
+type AccountUpdateParams struct {
+	ID        ID
 	Name      Name
 	OffBudget bool
 }
 
+func (p AccountUpdateParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Account ID", Required(p.ID)),
+		Field("Account name", p.Name),
+	)
+}
+
+type AccountDeleteParams struct {
+	ID ID
+
+	// Account to move transactions to. Needed if the account has
+	// transactions, scheduled transactions, or a payment category.
+	MoveToAccountID ID
+}
+
+// A year of daily balances.
+const MaxBalancePeriods = 366
+
+type AccountBalanceParams struct {
+	// Gets balances of every account when empty.
+	AccountID ID
+
+	Interval BalanceInterval
+
+	// Inclusive date range. Transactions before From are part of the first
+	// balance.
+	From Date
+	To   Date
+}
+
+func (p AccountBalanceParams) ValidateAll() error {
+	if err := ValidateFields(
+		Field("Interval", p.Interval),
+		Field("From", Required(p.From)),
+		Field("To", Required(p.To)),
+	); err != nil {
+		return err
+	}
+
+	if p.To.Before(p.From.Time) {
+		return NewError(EINVALID, "From must not be after To.")
+	}
+
+	if p.periods() > MaxBalancePeriods {
+		return NewError(EINVALID, fmt.Sprintf("Range must be at most %d balances.", MaxBalancePeriods))
+	}
+
+	return nil
+}
+
+// Counts the periods the range touches, which is the number of balances
+// for each account.
+func (p AccountBalanceParams) periods() int {
+	switch p.Interval {
+	case BalanceDaily:
+		return int(p.To.Sub(p.From.Time).Hours()/24) + 1
+	case BalanceWeekly:
+		// weeks start on monday
+		start := p.From.AddDate(0, 0, -((int(p.From.Weekday()) + 6) % 7))
+		return int(p.To.Sub(start).Hours()/24)/7 + 1
+	default:
+		return monthsUntil(NewMonthDate(p.From), NewMonthDate(p.To))
+	}
+}
+
+type AccountReconcileParams struct {
+	AccountID ID
+
+	// Ending balance and date of the statement.
+	Balance Amount
+	Date    Date
+
+	// Makes a transaction for the difference when the cleared balance does
+	// not match the statement. Otherwise, a difference is an error.
+	CreateAdjustment bool
+}
+
+func (p AccountReconcileParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Account ID", Required(p.AccountID)),
+		Field("Balance", Required(&p.Balance), MaxPrecision(p.Balance)),
+		Field("Date", Required(p.Date)),
+	)
+}
+
 // helpers
 
 func (a Account) ToRelated() RelatedAccount {
@@ -62,3 +298,8 @@ func (a Account) ToRelated() RelatedAccount {
 		OffBudget: a.OffBudget,
 	}
 }
+
+// Whether the account still needs a payment category to be made.
+func (a Account) NeedsPaymentCategory() bool {
+	return a.Type.IsCredit() && !a.OffBudget && a.PaymentCategoryID.Empty()
+}
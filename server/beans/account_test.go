@@ -1,9 +1,12 @@
 package beans
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccountToRelated(t *testing.T) {
@@ -22,3 +25,65 @@ func TestAccountToRelated(t *testing.T) {
 		account.ToRelated(),
 	)
 }
+
+func TestAccountTypeValidate(t *testing.T) {
+	assert.Nil(t, AccountType("").Validate())
+	assert.Nil(t, AccountCreditCard.Validate())
+	assert.NotNil(t, AccountType("bank").Validate())
+}
+
+func TestAccountNeedsPaymentCategory(t *testing.T) {
+	assert.True(t, Account{Type: AccountCreditCard}.NeedsPaymentCategory())
+	assert.True(t, Account{Type: AccountLineOfCredit}.NeedsPaymentCategory())
+
+	assert.False(t, Account{Type: AccountChecking}.NeedsPaymentCategory())
+	assert.False(t, Account{Type: AccountCreditCard, OffBudget: true}.NeedsPaymentCategory())
+	assert.False(t, Account{Type: AccountCreditCard, PaymentCategoryID: NewID()}.NeedsPaymentCategory())
+}
+
+func TestAccountCreateHasOpeningBalance(t *testing.T) {
+	assert.False(t, AccountCreate{}.HasOpeningBalance())
+	assert.False(t, AccountCreate{OpeningBalance: NewAmount(0, 0)}.HasOpeningBalance())
+	assert.True(t, AccountCreate{OpeningBalance: NewAmount(-5, 0)}.HasOpeningBalance())
+}
+
+func TestAccountBalanceParamsPeriods(t *testing.T) {
+	date := func(s string) Date {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return NewDate(d)
+	}
+
+	var tests = []struct {
+		interval BalanceInterval
+		from     string
+		to       string
+		periods  int
+	}{
+		{BalanceDaily, "2022-01-01", "2022-01-01", 1},
+		{BalanceDaily, "2022-01-01", "2023-01-01", 366},
+		// 2022-01-02 is a sunday, so it is in the week before
+		{BalanceWeekly, "2022-01-02", "2022-01-03", 2},
+		{BalanceWeekly, "2022-01-03", "2022-01-09", 1},
+		{BalanceMonthly, "2022-01-31", "2022-02-01", 2},
+		{"", "2022-01-01", "2022-12-31", 12},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s %s", test.interval, test.from, test.to), func(t *testing.T) {
+			params := AccountBalanceParams{Interval: test.interval, From: date(test.from), To: date(test.to)}
+			assert.Equal(t, test.periods, params.periods())
+		})
+	}
+}
+
+func TestAccountBalanceParamsLimitsPeriods(t *testing.T) {
+	from := NewDate(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	params := AccountBalanceParams{Interval: BalanceDaily, From: from, To: NewDate(from.AddDate(0, 0, MaxBalancePeriods-1))}
+	assert.NoError(t, params.ValidateAll())
+
+	params.To = NewDate(from.AddDate(0, 0, MaxBalancePeriods))
+	_, msg := params.ValidateAll().(Error).BeansError()
+	assert.Equal(t, "Range must be at most 366 balances.", msg)
+}
@@ -34,3 +34,64 @@ func (a amountArithmetic) Negate(amount Amount) Amount {
 	newDecimal.Neg(&newDecimal)
 	return Amount{set: true, decimal: newDecimal}
 }
+
+// Multiplies an amount by a whole number.
+func (a amountArithmetic) Multiply(amount Amount, n int64) (Amount, error) {
+	amount = amount.OrZero()
+	res := apd.New(0, 0)
+	if _, err := apd.BaseContext.Mul(res, &amount.decimal, apd.New(n, 0)); err != nil {
+		return NewEmptyAmount(), err
+	}
+
+	return Amount{set: true, decimal: *res}.Normalize(), nil
+}
+
+// Divides an amount by a whole number, rounding up to the cent so the parts
+// add up to at least the whole.
+func (a amountArithmetic) DivideCeil(amount Amount, n int64) (Amount, error) {
+	ctx := apd.BaseContext.WithPrecision(34)
+	ctx.Rounding = apd.RoundCeiling
+
+	amount = amount.OrZero()
+	res := apd.New(0, 0)
+	if _, err := ctx.Quo(res, &amount.decimal, apd.New(n, 0)); err != nil {
+		return NewEmptyAmount(), err
+	}
+	if _, err := ctx.Quantize(res, res, -2); err != nil {
+		return NewEmptyAmount(), err
+	}
+
+	return Amount{set: true, decimal: *res}.Normalize(), nil
+}
+
+// Gets the whole percent that part is of whole, kept between 0 and 100. A
+// whole that is not positive is always 100 percent.
+func (a amountArithmetic) Percent(part Amount, whole Amount) (int, error) {
+	zero := NewAmount(0, 0)
+	if whole.OrZero().Compare(zero) <= 0 {
+		return 100, nil
+	}
+	if part.OrZero().Compare(zero) <= 0 {
+		return 0, nil
+	}
+
+	ctx := apd.BaseContext.WithPrecision(34)
+	ctx.Rounding = apd.RoundDown
+
+	res := apd.New(0, 0)
+	if _, err := ctx.Mul(res, &part.decimal, apd.New(100, 0)); err != nil {
+		return 0, err
+	}
+	if _, err := ctx.Quo(res, res, &whole.decimal); err != nil {
+		return 0, err
+	}
+	if _, err := ctx.Quantize(res, res, 0); err != nil {
+		return 0, err
+	}
+
+	percent, err := res.Int64()
+	if err != nil {
+		return 0, err
+	}
+	return int(min(percent, 100)), nil
+}
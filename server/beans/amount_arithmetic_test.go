@@ -61,3 +61,71 @@ func TestNegate(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiply(t *testing.T) {
+	var tests = []struct {
+		name     string
+		amount   beans.Amount
+		n        int64
+		expected string
+	}{
+		{"multiplies", beans.NewAmount(1025, -2), 4, "41"},
+		{"by zero", beans.NewAmount(1025, -2), 0, "0"},
+		{"negative", beans.NewAmount(-15, -1), 3, "-4.5"},
+		{"empty amount", beans.NewEmptyAmount(), 3, "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := beans.Arithmetic.Multiply(test.amount, test.n)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, amount.String())
+		})
+	}
+}
+
+func TestDivideCeil(t *testing.T) {
+	var tests = []struct {
+		name     string
+		amount   beans.Amount
+		n        int64
+		expected string
+	}{
+		{"divides evenly", beans.NewAmount(9, 0), 3, "3"},
+		{"rounds up to the cent", beans.NewAmount(10, 0), 3, "3.34"},
+		{"keeps cents", beans.NewAmount(1001, -2), 2, "5.01"},
+		{"empty amount", beans.NewEmptyAmount(), 3, "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := beans.Arithmetic.DivideCeil(test.amount, test.n)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, amount.String())
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	var tests = []struct {
+		name     string
+		part     beans.Amount
+		whole    beans.Amount
+		expected int
+	}{
+		{"rounds down", beans.NewAmount(2, 0), beans.NewAmount(3, 0), 66},
+		{"whole", beans.NewAmount(3, 0), beans.NewAmount(3, 0), 100},
+		{"at most 100", beans.NewAmount(5, 0), beans.NewAmount(3, 0), 100},
+		{"negative part", beans.NewAmount(-5, 0), beans.NewAmount(3, 0), 0},
+		{"zero whole", beans.NewAmount(5, 0), beans.NewAmount(0, 0), 100},
+		{"empty part", beans.NewEmptyAmount(), beans.NewAmount(3, 0), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			percent, err := beans.Arithmetic.Percent(test.part, test.whole)
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, percent)
+		})
+	}
+}
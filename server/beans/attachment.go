@@ -0,0 +1,105 @@
+package beans
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// A file, such as a receipt, attached to a transaction. The file itself is
+// kept in the BlobStore under the attachment ID.
+type Attachment struct {
+	ID            ID
+	TransactionID ID
+	FileName      Name
+	MimeType      string
+
+	// Size of the file in bytes.
+	Size int64
+}
+
+// Largest file that can be attached.
+const MaxAttachmentSize = 10 << 20
+
+// Types of files that can be attached. The type is detected from the file
+// contents, not trusted from the client.
+var AttachmentMimeTypes = []string{
+	"application/pdf",
+	"image/gif",
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+// storage
+
+// Stores the contents of files by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Gets the contents of a file. Errors with ErrorNotFound if there is no
+	// file for the key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Deletes a file. Deleting a key with no file does nothing.
+	Delete(ctx context.Context, key string) error
+}
+
+// repository
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment Attachment) error
+	Delete(ctx context.Context, budgetID ID, id ID) error
+	Get(ctx context.Context, budgetID ID, id ID) (Attachment, error)
+
+	// Gets all attachments on a transaction in the order they were added.
+	GetForTransaction(ctx context.Context, budgetID ID, transactionID ID) ([]Attachment, error)
+
+	// Gets all attachments on the transactions and their transfers.
+	GetForTransactions(ctx context.Context, budgetID ID, transactionIDs []ID) ([]Attachment, error)
+}
+
+// contract
+
+type AttachmentContract interface {
+	// Attaches a file to a transaction.
+	Create(ctx context.Context, auth *BudgetAuthContext, params AttachmentParams) (ID, error)
+
+	// Gets all attachments on a transaction.
+	GetAll(ctx context.Context, auth *BudgetAuthContext, transactionID ID) ([]Attachment, error)
+
+	// Gets an attachment and its file. The caller must close the file.
+	Get(ctx context.Context, auth *BudgetAuthContext, transactionID ID, id ID) (Attachment, io.ReadCloser, error)
+
+	// Deletes an attachment and its file.
+	Delete(ctx context.Context, auth *BudgetAuthContext, transactionID ID, id ID) error
+}
+
+type AttachmentParams struct {
+	TransactionID ID
+	FileName      Name
+	File          io.Reader
+}
+
+func (p AttachmentParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Transaction ID", Required(p.TransactionID)),
+		Field("File name", p.FileName),
+	)
+}
+
+// Checks the size and detected type of a file.
+func ValidateAttachmentFile(size int64, mimeType string) error {
+	if size == 0 {
+		return NewError(EINVALID, "File is required.")
+	}
+	if size > MaxAttachmentSize {
+		return NewError(EINVALID, fmt.Sprintf("File must be at most %d MB.", MaxAttachmentSize>>20))
+	}
+	if !slices.Contains(AttachmentMimeTypes, mimeType) {
+		return NewError(EINVALID, fmt.Sprintf("File type %s is not allowed.", mimeType))
+	}
+
+	return nil
+}
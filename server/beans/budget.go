@@ -2,11 +2,55 @@ package beans
 
 import (
 	"context"
+	"fmt"
 )
 
 type Budget struct {
 	ID   ID
 	Name Name
+
+	// How overspent categories carry into the next month.
+	OverspendingRollover OverspendingRollover
+}
+
+type OverspendingRollover string
+
+const (
+	// Overspending stays in the category and carries into every later month.
+	OverspendingCarryForward OverspendingRollover = "carry_forward"
+	// Overspent categories start the next month at zero, and the overspending
+	// is taken out of the next month's budgetable amount.
+	OverspendingReset OverspendingRollover = "reset"
+	// Like reset, except overspending paid with a credit account becomes debt
+	// on the account. It is taken out of the account's payment category
+	// instead of the next month's budgetable amount.
+	OverspendingResetCreditAsDebt OverspendingRollover = "reset_credit_as_debt"
+)
+
+func (o OverspendingRollover) Empty() bool {
+	return o == ""
+}
+
+func (o OverspendingRollover) Validate() error {
+	switch o {
+	case "", OverspendingCarryForward, OverspendingReset, OverspendingResetCreditAsDebt:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", o)
+}
+
+type BudgetUpdateParams struct {
+	ID                   ID
+	Name                 Name
+	OverspendingRollover OverspendingRollover
+}
+
+func (p BudgetUpdateParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Budget name", p.Name),
+		Field("Overspending rollover", Required(p.OverspendingRollover), p.OverspendingRollover),
+	)
 }
 
 type BudgetContract interface {
@@ -19,6 +63,10 @@ type BudgetContract interface {
 
 	// Gets all budgets accessible to the user.
 	GetAll(ctx context.Context, auth *AuthContext) ([]Budget, error)
+
+	// Updates a budget's name and settings.
+	// Ensures the user has access to the budget.
+	Update(ctx context.Context, auth *AuthContext, params BudgetUpdateParams) error
 }
 
 type BudgetRepository interface {
@@ -30,4 +78,6 @@ type BudgetRepository interface {
 	GetBudgetsForUser(ctx context.Context, userID ID) ([]Budget, error)
 	// Gets budget user IDs.
 	GetBudgetUserIDs(ctx context.Context, id ID) ([]ID, error)
+	// Updates the budget's name and settings.
+	Update(ctx context.Context, budget Budget) error
 }
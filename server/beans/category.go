@@ -7,6 +7,14 @@ type Category struct {
 	BudgetID ID
 	GroupID  ID
 	Name     Name
+
+	// Position of the category within its group.
+	SortOrder int
+
+	// Hidden categories keep their history but are left out of budgeting.
+	Hidden bool
+
+	Goal Optional[CategoryGoal]
 }
 
 type RelatedCategory struct {
@@ -19,11 +27,19 @@ type CategoryGroup struct {
 	BudgetID ID
 	Name     Name
 	IsIncome bool
+
+	// Holds the payment categories of credit accounts.
+	IsCreditCardPayments bool
+
+	// Position of the group within the budget.
+	SortOrder int
+
+	Hidden bool
 }
 
 type CategoryGroupWithCategories struct {
 	CategoryGroup
-	Categories []RelatedCategory
+	Categories []Category
 }
 
 type CategoryContract interface {
@@ -41,14 +57,84 @@ type CategoryContract interface {
 
 	// Gets category for a budget.
 	GetCategory(ctx context.Context, auth *BudgetAuthContext, id ID) (Category, error)
+
+	// Updates a category. Categories cannot be moved into or out of the
+	// income or credit card payments groups.
+	UpdateCategory(ctx context.Context, auth *BudgetAuthContext, params CategoryUpdateParams) error
+
+	// Updates a category group.
+	UpdateGroup(ctx context.Context, auth *BudgetAuthContext, params CategoryGroupUpdateParams) error
+
+	// Deletes a category. Its transactions, scheduled transactions and rules
+	// are moved to the replacement category, and its assigned amounts are
+	// added to the replacement's assigned amounts.
+	DeleteCategory(ctx context.Context, auth *BudgetAuthContext, params CategoryDeleteParams) error
+
+	// Deletes a category group. The group must not have any categories.
+	DeleteGroup(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Sets the funding goal of a category. An empty goal removes it.
+	SetGoal(ctx context.Context, auth *BudgetAuthContext, id ID, goal Optional[CategoryGoal]) error
+}
+
+type CategoryUpdateParams struct {
+	ID        ID
+	GroupID   ID
+	Name      Name
+	SortOrder int
+	Hidden    bool
+}
+
+func (p CategoryUpdateParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Category ID", Required(p.ID)),
+		Field("Group ID", Required(p.GroupID)),
+		Field("Name", p.Name),
+	)
+}
+
+type CategoryGroupUpdateParams struct {
+	ID        ID
+	Name      Name
+	SortOrder int
+	Hidden    bool
+}
+
+func (p CategoryGroupUpdateParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Group ID", Required(p.ID)),
+		Field("Name", p.Name),
+	)
+}
+
+type CategoryDeleteParams struct {
+	ID ID
+
+	// Category to move transactions and assigned amounts to.
+	ReplacementID ID
+}
+
+func (p CategoryDeleteParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Category ID", Required(p.ID)),
+		Field("Replacement Category ID", Required(p.ReplacementID)),
+	)
 }
 
 type CategoryRepository interface {
 	Create(ctx context.Context, tx Tx, category Category) error
 	GetSingleForBudget(ctx context.Context, id ID, budgetID ID) (Category, error)
+	Update(ctx context.Context, tx Tx, category Category) error
+	Delete(ctx context.Context, tx Tx, budgetID ID, id ID) error
+
+	// Moves transactions, scheduled transactions, rules and assigned amounts
+	// from one category to another.
+	Reassign(ctx context.Context, tx Tx, budgetID ID, fromID ID, toID ID) error
 
 	CreateGroup(ctx context.Context, tx Tx, categoryGroup CategoryGroup) error
 	GetCategoryGroup(ctx context.Context, id ID, budgetID ID) (CategoryGroup, error)
+	UpdateGroup(ctx context.Context, tx Tx, categoryGroup CategoryGroup) error
+	DeleteGroup(ctx context.Context, tx Tx, budgetID ID, id ID) error
 
 	GetCategoriesForGroup(ctx context.Context, id ID, budgetID ID) ([]Category, error)
 	GetForBudget(ctx context.Context, budgetID ID) ([]Category, error)
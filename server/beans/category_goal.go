@@ -0,0 +1,152 @@
+package beans
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type GoalType string
+
+const (
+	// Assign a fixed amount every month.
+	GoalMonthly GoalType = "monthly"
+	// Assign a fixed amount every week. Weeks start on Monday.
+	GoalWeekly GoalType = "weekly"
+	// Build up a balance, with no deadline.
+	GoalTargetBalance GoalType = "target_balance"
+	// Build up a balance by a given month, spread evenly over the months left.
+	GoalTargetBalanceByDate GoalType = "target_balance_by_date"
+)
+
+func (t GoalType) Validate() error {
+	switch t {
+	case GoalMonthly, GoalWeekly, GoalTargetBalance, GoalTargetBalanceByDate:
+		return nil
+	case "":
+		return errors.New(":field is required")
+	}
+
+	return fmt.Errorf(":field %s is not supported", t)
+}
+
+type CategoryGoal struct {
+	Type   GoalType
+	Amount Amount
+
+	// Month the balance should be reached by. Only used by target balance by
+	// date goals.
+	TargetMonth MonthDate
+}
+
+func (g CategoryGoal) ValidateAll() error {
+	targetMonth := []Validatable{}
+	if g.Type == GoalTargetBalanceByDate {
+		targetMonth = append(targetMonth, Required(g.TargetMonth))
+	}
+
+	return ValidateFields(
+		Field("Goal type", g.Type),
+		Field("Goal amount", Required(&g.Amount), Positive(g.Amount), NonZero(g.Amount), MaxPrecision(g.Amount)),
+		Field("Target month", targetMonth...),
+	)
+}
+
+type CategoryGoalProgress struct {
+	Goal CategoryGoal
+
+	// Amount to assign in the month to stay on track.
+	Target Amount
+
+	// How much of the goal is funded, from 0 to 100.
+	Percent int
+
+	// Amount still to assign in the month to meet the target.
+	Underfunded Amount
+}
+
+// Gets the progress of a goal in a month. The balance is what the category
+// had available before the month began.
+func (g CategoryGoal) Progress(month MonthDate, balance Amount, assigned Amount) (CategoryGoalProgress, error) {
+	funded, err := Arithmetic.Add(balance, assigned)
+	if err != nil {
+		return CategoryGoalProgress{}, err
+	}
+
+	var target Amount
+	var percent int
+
+	switch g.Type {
+	case GoalMonthly:
+		target = g.Amount
+		percent, err = Arithmetic.Percent(assigned, target)
+
+	case GoalWeekly:
+		target, err = Arithmetic.Multiply(g.Amount, int64(mondaysIn(month)))
+		if err != nil {
+			return CategoryGoalProgress{}, err
+		}
+		percent, err = Arithmetic.Percent(assigned, target)
+
+	case GoalTargetBalance:
+		target, err = Arithmetic.Add(g.Amount, Arithmetic.Negate(balance))
+		if err != nil {
+			return CategoryGoalProgress{}, err
+		}
+		percent, err = Arithmetic.Percent(funded, g.Amount)
+
+	case GoalTargetBalanceByDate:
+		remaining, err := Arithmetic.Add(g.Amount, Arithmetic.Negate(balance))
+		if err != nil {
+			return CategoryGoalProgress{}, err
+		}
+		target, err = Arithmetic.DivideCeil(atLeastZero(remaining), int64(monthsUntil(month, g.TargetMonth)))
+		if err != nil {
+			return CategoryGoalProgress{}, err
+		}
+		percent, err = Arithmetic.Percent(funded, g.Amount)
+
+	default:
+		return CategoryGoalProgress{}, fmt.Errorf("unknown goal type %s", g.Type)
+	}
+	if err != nil {
+		return CategoryGoalProgress{}, err
+	}
+
+	target = atLeastZero(target)
+	underfunded, err := Arithmetic.Add(target, Arithmetic.Negate(assigned))
+	if err != nil {
+		return CategoryGoalProgress{}, err
+	}
+
+	return CategoryGoalProgress{
+		Goal:        g,
+		Target:      target.Normalize(),
+		Percent:     percent,
+		Underfunded: atLeastZero(underfunded).Normalize(),
+	}, nil
+}
+
+func atLeastZero(amount Amount) Amount {
+	if amount.Compare(NewAmount(0, 0)) < 0 {
+		return NewAmount(0, 0)
+	}
+	return amount
+}
+
+func mondaysIn(month MonthDate) int {
+	count := 0
+	for day := month.FirstDay().Time; !day.After(month.LastDay().Time); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Monday {
+			count++
+		}
+	}
+	return count
+}
+
+// Counts the months from one month through another. A target in the past
+// counts as the current month.
+func monthsUntil(from MonthDate, to MonthDate) int {
+	months := (to.Time().Year()-from.Time().Year())*12 + int(to.Time().Month()) - int(from.Time().Month()) + 1
+	return max(months, 1)
+}
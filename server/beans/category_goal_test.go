@@ -0,0 +1,117 @@
+package beans_test
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryGoalValidate(t *testing.T) {
+	var tests = []struct {
+		name string
+		goal beans.CategoryGoal
+		err  string
+	}{
+		{"valid", beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(5, 0)}, ""},
+		{"no type", beans.CategoryGoal{Amount: beans.NewAmount(5, 0)}, "Goal type is required."},
+		{"bad type", beans.CategoryGoal{Type: "yearly", Amount: beans.NewAmount(5, 0)}, "Goal type yearly is not supported."},
+		{"no amount", beans.CategoryGoal{Type: beans.GoalMonthly}, "Goal amount is required."},
+		{"zero amount", beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(0, 0)}, "Goal amount must not be zero."},
+		{"negative amount", beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(-5, 0)}, "Goal amount must be positive."},
+		{"needs target month", beans.CategoryGoal{Type: beans.GoalTargetBalanceByDate, Amount: beans.NewAmount(5, 0)}, "Target month is required."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.goal.ValidateAll()
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				testutils.AssertErrorAndCode(t, err, beans.EINVALID, test.err)
+			}
+		})
+	}
+}
+
+func TestCategoryGoalProgress(t *testing.T) {
+	var tests = []struct {
+		name        string
+		goal        beans.CategoryGoal
+		month       string
+		balance     beans.Amount
+		assigned    beans.Amount
+		target      beans.Amount
+		percent     int
+		underfunded beans.Amount
+	}{
+		{
+			"monthly underfunded",
+			beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(50, 0)},
+			"2022-05-01", beans.NewAmount(100, 0), beans.NewAmount(20, 0),
+			beans.NewAmount(50, 0), 40, beans.NewAmount(30, 0),
+		},
+		{
+			"monthly overfunded",
+			beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(50, 0)},
+			"2022-05-01", beans.NewAmount(0, 0), beans.NewAmount(70, 0),
+			beans.NewAmount(50, 0), 100, beans.NewAmount(0, 0),
+		},
+		{
+			"weekly in a month with five mondays",
+			beans.CategoryGoal{Type: beans.GoalWeekly, Amount: beans.NewAmount(10, 0)},
+			"2022-05-01", beans.NewAmount(0, 0), beans.NewAmount(10, 0),
+			beans.NewAmount(50, 0), 20, beans.NewAmount(40, 0),
+		},
+		{
+			"weekly in a month with four mondays",
+			beans.CategoryGoal{Type: beans.GoalWeekly, Amount: beans.NewAmount(10, 0)},
+			"2022-02-01", beans.NewAmount(0, 0), beans.NewAmount(0, 0),
+			beans.NewAmount(40, 0), 0, beans.NewAmount(40, 0),
+		},
+		{
+			"target balance",
+			beans.CategoryGoal{Type: beans.GoalTargetBalance, Amount: beans.NewAmount(1000, 0)},
+			"2022-05-01", beans.NewAmount(600, 0), beans.NewAmount(150, 0),
+			beans.NewAmount(400, 0), 75, beans.NewAmount(250, 0),
+		},
+		{
+			"target balance reached",
+			beans.CategoryGoal{Type: beans.GoalTargetBalance, Amount: beans.NewAmount(1000, 0)},
+			"2022-05-01", beans.NewAmount(1200, 0), beans.NewAmount(0, 0),
+			beans.NewAmount(0, 0), 100, beans.NewAmount(0, 0),
+		},
+		{
+			"target balance by date",
+			beans.CategoryGoal{Type: beans.GoalTargetBalanceByDate, Amount: beans.NewAmount(1000, 0), TargetMonth: testutils.NewMonthDate(t, "2022-07-01")},
+			"2022-05-01", beans.NewAmount(400, 0), beans.NewAmount(100, 0),
+			beans.NewAmount(200, 0), 50, beans.NewAmount(100, 0),
+		},
+		{
+			"target balance by date rounds up",
+			beans.CategoryGoal{Type: beans.GoalTargetBalanceByDate, Amount: beans.NewAmount(100, 0), TargetMonth: testutils.NewMonthDate(t, "2022-07-01")},
+			"2022-05-01", beans.NewAmount(0, 0), beans.NewAmount(0, 0),
+			beans.NewAmount(3334, -2), 0, beans.NewAmount(3334, -2),
+		},
+		{
+			"target balance by date in the past",
+			beans.CategoryGoal{Type: beans.GoalTargetBalanceByDate, Amount: beans.NewAmount(1000, 0), TargetMonth: testutils.NewMonthDate(t, "2022-01-01")},
+			"2022-05-01", beans.NewAmount(400, 0), beans.NewAmount(0, 0),
+			beans.NewAmount(600, 0), 40, beans.NewAmount(600, 0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			progress, err := test.goal.Progress(testutils.NewMonthDate(t, test.month), test.balance, test.assigned)
+			require.Nil(t, err)
+
+			assert.Equal(t, test.goal, progress.Goal)
+			assert.Equal(t, test.target.String(), progress.Target.String())
+			assert.Equal(t, test.percent, progress.Percent)
+			assert.Equal(t, test.underfunded.String(), progress.Underfunded.String())
+		})
+	}
+}
@@ -28,6 +28,19 @@ func NewAmountWithBigInt(coefficient *big.Int, exponent int32) Amount {
 	return Amount{decimal: *apd.NewWithBigInt(bigInt, exponent), set: true}
 }
 
+// Parses an amount from a decimal string, such as "-12.50".
+func NewAmountFromString(s string) (Amount, error) {
+	dec, condition, err := apd.NewFromString(s)
+	if err != nil {
+		return NewEmptyAmount(), err
+	}
+	if condition != 0 || dec.Form != apd.Finite {
+		return NewEmptyAmount(), errors.New("invalid amount")
+	}
+
+	return Amount{decimal: *dec, set: true}, nil
+}
+
 func (a *Amount) AsInt64() (int64, error) {
 	return a.decimal.Int64()
 }
@@ -92,16 +105,12 @@ func (a *Amount) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
-	dec, condition, err := apd.NewFromString(amountString.String())
+	amount, err := NewAmountFromString(amountString.String())
 	if err != nil {
 		return err
 	}
-	if condition != 0 {
-		return errors.New("invalid amount")
-	}
 
-	a.decimal = *dec
-	a.set = true
+	*a = amount
 	return nil
 }
 
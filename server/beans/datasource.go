@@ -3,12 +3,18 @@ package beans
 // A collection of repositories that represents the primary datastore of beans.
 type DataSource interface {
 	AccountRepository() AccountRepository
+	AttachmentRepository() AttachmentRepository
 	BudgetRepository() BudgetRepository
 	CategoryRepository() CategoryRepository
 	MonthRepository() MonthRepository
 	MonthCategoryRepository() MonthCategoryRepository
+	MonthSnapshotRepository() MonthSnapshotRepository
 	PayeeRepository() PayeeRepository
+	RuleRepository() RuleRepository
+	ScheduledTransactionRepository() ScheduledTransactionRepository
+	TagRepository() TagRepository
 	TransactionRepository() TransactionRepository
+	TransactionHistoryRepository() TransactionHistoryRepository
 	UserRepository() UserRepository
 
 	TxManager() TxManager
@@ -0,0 +1,213 @@
+package beans
+
+import (
+	"context"
+	"fmt"
+)
+
+// A transaction read from a statement file, before it is saved.
+type ImportRow struct {
+	Date      Date
+	Amount    Amount
+	PayeeName Name
+	Notes     TransactionNotes
+
+	// Identifier the statement gives the transaction, if it has one.
+	ImportID NullString
+
+	// Names of the category and transfer account, for formats that track
+	// them. They are matched to existing categories and accounts on import.
+	CategoryName        Name
+	TransferAccountName Name
+	Splits              []ImportSplit
+}
+
+type ImportSplit struct {
+	Amount       Amount
+	CategoryName Name
+	Notes        TransactionNotes
+}
+
+func (r ImportRow) ValidateAll() error {
+	err := ValidateFields(
+		Field("Date", Required(r.Date)),
+		Field("Amount", Required(&r.Amount), MaxPrecision(r.Amount)),
+		Field("Payee", Max(ValidatableString(r.PayeeName), 255, "characters")),
+		Field("Notes", Max(r.Notes, 255, "characters")),
+		Field("Import ID", Max(r.ImportID, 255, "characters")),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range r.Splits {
+		err := ValidateFields(
+			Field("Split amount", Required(&s.Amount), MaxPrecision(s.Amount)),
+			Field("Split notes", Max(s.Notes, 255, "characters")),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type ImportPreviewRow struct {
+	ImportRow
+
+	// Existing payee with the same name as the row, if there is one.
+	// Rows without a matching payee will create a new payee on import.
+	Payee Optional[RelatedPayee]
+
+	// True if the account already has a transaction with the same import ID,
+	// or, for rows without an import ID, the same date, amount, and payee.
+	// Imported transactions are compared by the payee in their statement.
+	// Duplicates are skipped on import.
+	Duplicate bool
+}
+
+type ImportResult struct {
+	TransactionIDs []ID
+	Duplicates     int
+}
+
+// date formats
+
+type ImportDateFormat string
+
+const (
+	DateFormatYMD       ImportDateFormat = "YYYY-MM-DD"
+	DateFormatYMDSlash  ImportDateFormat = "YYYY/MM/DD"
+	DateFormatMDYSlash  ImportDateFormat = "MM/DD/YYYY"
+	DateFormatDMYSlash  ImportDateFormat = "DD/MM/YYYY"
+	DateFormatMDYDash   ImportDateFormat = "MM-DD-YYYY"
+	DateFormatDMYDash   ImportDateFormat = "DD-MM-YYYY"
+	DateFormatDMYPeriod ImportDateFormat = "DD.MM.YYYY"
+)
+
+var importDateLayouts = map[ImportDateFormat]string{
+	DateFormatYMD:       "2006-1-2",
+	DateFormatYMDSlash:  "2006/1/2",
+	DateFormatMDYSlash:  "1/2/2006",
+	DateFormatDMYSlash:  "2/1/2006",
+	DateFormatMDYDash:   "1-2-2006",
+	DateFormatDMYDash:   "2-1-2006",
+	DateFormatDMYPeriod: "2.1.2006",
+}
+
+// Gets the time layout for the format. Leading zeros are optional.
+func (f ImportDateFormat) Layout() (string, bool) {
+	layout, ok := importDateLayouts[f]
+	return layout, ok
+}
+
+func (f ImportDateFormat) Empty() bool {
+	return f == ""
+}
+
+func (f ImportDateFormat) Validate() error {
+	if _, ok := f.Layout(); !ok && !f.Empty() {
+		return fmt.Errorf(":field %s is not supported", f)
+	}
+
+	return nil
+}
+
+// csv
+
+type CSVColumns struct {
+	Date   Optional[int]
+	Payee  Optional[int]
+	Notes  Optional[int]
+	Amount Optional[int]
+	Debit  Optional[int]
+	Credit Optional[int]
+}
+
+type CSVFormat struct {
+	HasHeader  bool
+	DateFormat ImportDateFormat
+	Columns    CSVColumns
+}
+
+type ImportCSVParams struct {
+	AccountID ID
+	File      string
+	CSVFormat
+}
+
+func (p ImportCSVParams) ValidateAll() error {
+	err := ValidateFields(
+		Field("Account ID", Required(p.AccountID)),
+		Field("Date format", Required(p.DateFormat), p.DateFormat),
+		Field("Date column", Required(p.Columns.Date)),
+	)
+	if err != nil {
+		return err
+	}
+
+	hasAmount := !p.Columns.Amount.Empty()
+	hasDebitOrCredit := !p.Columns.Debit.Empty() || !p.Columns.Credit.Empty()
+	if hasAmount == hasDebitOrCredit {
+		return NewError(EINVALID, "Map either an amount column or debit and credit columns.")
+	}
+
+	return nil
+}
+
+// ofx
+
+type ImportOFXParams struct {
+	AccountID ID
+	File      string
+}
+
+func (p ImportOFXParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Account ID", Required(p.AccountID)),
+	)
+}
+
+// qif
+
+type ImportQIFParams struct {
+	AccountID  ID
+	File       string
+	DateFormat ImportDateFormat
+}
+
+func (p ImportQIFParams) ValidateAll() error {
+	return ValidateFields(
+		Field("Account ID", Required(p.AccountID)),
+		Field("Date format", Required(p.DateFormat), p.DateFormat),
+	)
+}
+
+type ExportedFile struct {
+	Account RelatedAccount
+	File    string
+}
+
+// contract
+
+type ImportContract interface {
+	// Reads a CSV file and returns the transactions that would be imported.
+	PreviewCSV(ctx context.Context, auth *BudgetAuthContext, params ImportCSVParams) ([]ImportPreviewRow, error)
+
+	// Imports transactions from a CSV file into an account.
+	ImportCSV(ctx context.Context, auth *BudgetAuthContext, params ImportCSVParams) (ImportResult, error)
+
+	// Reads an OFX or QFX file and returns the transactions that would be imported.
+	PreviewOFX(ctx context.Context, auth *BudgetAuthContext, params ImportOFXParams) ([]ImportPreviewRow, error)
+
+	// Imports transactions from an OFX or QFX file into an account.
+	ImportOFX(ctx context.Context, auth *BudgetAuthContext, params ImportOFXParams) (ImportResult, error)
+
+	// Imports transactions from a QIF file into an account. Categories and
+	// transfer accounts are matched by name and must already exist.
+	ImportQIF(ctx context.Context, auth *BudgetAuthContext, params ImportQIFParams) (ImportResult, error)
+
+	// Exports all transactions as one QIF file per account.
+	ExportQIF(ctx context.Context, auth *BudgetAuthContext) ([]ExportedFile, error)
+}
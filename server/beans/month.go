@@ -2,6 +2,7 @@ package beans
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"golang.org/x/net/context"
@@ -21,6 +22,14 @@ type MonthWithDetails struct {
 	Assigned    Amount
 
 	Budgetable Amount
+
+	// Total still to assign in the month to meet category goals.
+	Underfunded Amount
+
+	// Cash overspending of the previous month, already taken out of
+	// Budgetable. Only set when the budget resets overspending.
+	Overspent Amount
+
 	Categories []MonthCategoryWithDetails
 }
 
@@ -48,6 +57,10 @@ func (d MonthDate) Previous() MonthDate {
 	return NewMonthDate(NewDate(d.FirstDay().AddDate(0, -1, 0)))
 }
 
+func (d MonthDate) Next() MonthDate {
+	return NewMonthDate(NewDate(d.FirstDay().AddDate(0, 1, 0)))
+}
+
 func (d MonthDate) Empty() bool {
 	return d.date.Empty()
 }
@@ -81,16 +94,143 @@ func normalizeMonth(date time.Time) time.Time {
 	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 }
 
+type QuickBudgetType string
+
+const (
+	// Assign what was assigned in the previous month.
+	QuickBudgetAssignedLastMonth QuickBudgetType = "assigned_last_month"
+	// Assign what was spent in the previous month.
+	QuickBudgetSpentLastMonth QuickBudgetType = "spent_last_month"
+	// Assign the average spent over a number of previous months.
+	QuickBudgetAverageSpent QuickBudgetType = "average_spent"
+	// Assign zero.
+	QuickBudgetReset QuickBudgetType = "reset"
+)
+
+func (t QuickBudgetType) Empty() bool {
+	return t == ""
+}
+
+func (t QuickBudgetType) Validate() error {
+	switch t {
+	case "", QuickBudgetAssignedLastMonth, QuickBudgetSpentLastMonth, QuickBudgetAverageSpent, QuickBudgetReset:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", t)
+}
+
+const MaxQuickBudgetMonths = 12
+
+type QuickBudgetParams struct {
+	MonthID ID
+	Type    QuickBudgetType
+
+	// Number of months to average over. Only used by average spent.
+	Months int
+
+	// Categories to assign. When empty, every visible category outside of
+	// the income group is assigned.
+	CategoryIDs []ID
+}
+
+func (p QuickBudgetParams) ValidateAll() error {
+	if err := ValidateFields(
+		Field("Type", Required(p.Type), p.Type),
+	); err != nil {
+		return err
+	}
+
+	if p.Type == QuickBudgetAverageSpent && (p.Months < 1 || p.Months > MaxQuickBudgetMonths) {
+		return NewError(EINVALID, fmt.Sprintf("Months must be between 1 and %d.", MaxQuickBudgetMonths))
+	}
+
+	return nil
+}
+
+type MoveMoneyParams struct {
+	MonthID ID
+
+	// An empty category is ready to assign.
+	FromCategoryID ID
+	ToCategoryID   ID
+
+	Amount Amount
+}
+
+func (p MoveMoneyParams) ValidateAll() error {
+	if err := ValidateFields(
+		Field("Amount", Required(&p.Amount), Positive(p.Amount), NonZero(p.Amount), MaxPrecision(p.Amount)),
+	); err != nil {
+		return err
+	}
+
+	if p.FromCategoryID == p.ToCategoryID {
+		return NewError(EINVALID, "Cannot move money to the same category.")
+	}
+
+	return nil
+}
+
+const MaxMonthRange = 12
+
+type MonthRangeParams struct {
+	// Inclusive range of months.
+	From MonthDate
+	To   MonthDate
+
+	// Creates months after the current month that do not exist. Otherwise
+	// they are returned with nothing assigned, without being created.
+	CreateFuture bool
+}
+
+func (p MonthRangeParams) ValidateAll() error {
+	if err := ValidateFields(
+		Field("From", Required(p.From)),
+		Field("To", Required(p.To)),
+	); err != nil {
+		return err
+	}
+
+	if p.To.Time().Before(p.From.Time()) {
+		return NewError(EINVALID, "From must not be after To.")
+	}
+
+	if monthsUntil(p.From, p.To) > MaxMonthRange {
+		return NewError(EINVALID, fmt.Sprintf("Range must be at most %d months.", MaxMonthRange))
+	}
+
+	return nil
+}
+
 type MonthContract interface {
 	// Gets a month, its categories, and budgetable amount.
 	// If the month does not exist it is created.
 	GetOrCreate(ctx context.Context, auth *BudgetAuthContext, date MonthDate) (MonthWithDetails, error)
 
+	// Gets every month in the range, as GetOrCreate does, reading the
+	// months before the range once.
+	GetRange(ctx context.Context, auth *BudgetAuthContext, params MonthRangeParams) ([]MonthWithDetails, error)
+
 	// Updates the given month.
 	Update(ctx context.Context, auth *BudgetAuthContext, monthID ID, carryover Amount) error
 
 	// Sets the assigned amount on a category for a month.
 	SetCategoryAmount(ctx context.Context, auth *BudgetAuthContext, monthID ID, categoryID ID, amount Amount) error
+
+	// Sets the assigned amount on many categories of a month at once.
+	QuickBudget(ctx context.Context, auth *BudgetAuthContext, params QuickBudgetParams) (MonthWithDetails, error)
+
+	// Moves an assigned amount from one category to another, or to or from
+	// ready to assign. The move is recorded in the month's movements.
+	MoveMoney(ctx context.Context, auth *BudgetAuthContext, params MoveMoneyParams) error
+
+	// Gets the money movements of a month in the order they were made.
+	GetMovements(ctx context.Context, auth *BudgetAuthContext, monthID ID) ([]MoneyMovement, error)
+
+	// Compares the budget's stored month snapshots to a full recompute, for
+	// debugging. Returns nothing when the snapshots are correct.
+	CheckSnapshots(ctx context.Context, auth *BudgetAuthContext) ([]MonthSnapshotMismatch, error)
 }
 
 type MonthRepository interface {
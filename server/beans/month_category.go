@@ -1,6 +1,9 @@
 package beans
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type MonthCategory struct {
 	ID         ID
@@ -15,11 +18,25 @@ type MonthCategoryWithDetails struct {
 	Amount     Amount
 	Activity   Amount
 	Available  Amount
+
+	// Set when the category has a goal.
+	Goal Optional[CategoryGoalProgress]
+}
+
+// A record of money moved between the categories of a month. An empty
+// category is ready to assign.
+type MoneyMovement struct {
+	ID             ID
+	MonthID        ID
+	FromCategoryID ID
+	ToCategoryID   ID
+	Amount         Amount
+	CreatedAt      time.Time
 }
 
 type MonthCategoryRepository interface {
 	Create(ctx context.Context, tx Tx, monthCategory MonthCategory) error
-	UpdateAmount(ctx context.Context, monthCategory MonthCategory) error
+	UpdateAmount(ctx context.Context, tx Tx, monthCategory MonthCategory) error
 
 	GetForMonth(ctx context.Context, month Month) ([]MonthCategory, error)
 	GetAssignedByCategory(ctx context.Context, budgetID ID, before Date) (map[ID]Amount, error)
@@ -29,8 +46,40 @@ type MonthCategoryRepository interface {
 
 	// Gets the amount assigned in a month.
 	GetAssignedInMonth(ctx context.Context, month Month) (Amount, error)
+
+	CreateMovement(ctx context.Context, tx Tx, movement MoneyMovement) error
+
+	// Gets the movements of a month in the order they were made.
+	GetMovementsForMonth(ctx context.Context, month Month) ([]MoneyMovement, error)
+}
+
+// What a month starts with from the months before it.
+type CarriedIn struct {
+	// Balance of each category going into the month.
+	Balances map[ID]Amount
+
+	// Cash overspending of the previous month, taken out of the month's
+	// budgetable amount.
+	Overspent Amount
 }
 
 type MonthCategoryService interface {
 	GetForMonth(ctx context.Context, month Month) ([]MonthCategoryWithDetails, error)
+
+	// Gets what the month starts with, following the budget's overspending
+	// rollover.
+	GetCarriedIn(ctx context.Context, month Month) (CarriedIn, error)
+
+	// Gets the snapshot of the month, building and keeping the snapshots
+	// of the months before it that are missing. Months more than
+	// MaxMonthRange months from now are summed from the last month that is
+	// kept, and are not kept themselves.
+	GetSnapshot(ctx context.Context, budgetID ID, date MonthDate) (MonthSnapshot, error)
+
+	// Compares the budget's snapshots against a full recompute.
+	CheckSnapshots(ctx context.Context, budgetID ID) ([]MonthSnapshotMismatch, error)
+
+	// Gets activity by category, including the activity of credit account
+	// payment categories.
+	GetActivityByCategory(ctx context.Context, budgetID ID, from Date, to Date) (map[ID]Amount, error)
 }
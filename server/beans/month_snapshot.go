@@ -0,0 +1,50 @@
+package beans
+
+import "context"
+
+// The totals of a month, kept so a month can be read without summing every
+// month before it. Snapshots are removed whenever something they were
+// built from changes.
+type MonthSnapshot struct {
+	BudgetID ID
+	Date     MonthDate
+
+	// Cash overspending carried in from the previous month.
+	Overspent Amount
+
+	Categories map[ID]MonthCategorySnapshot
+}
+
+type MonthCategorySnapshot struct {
+	CarriedIn Amount
+	Assigned  Amount
+	Activity  Amount
+}
+
+// A difference between a stored snapshot and a full recompute. The category
+// is empty when the month's overspending differs.
+type MonthSnapshotMismatch struct {
+	Date       MonthDate
+	CategoryID ID
+	Field      string
+	Snapshot   Amount
+	Recomputed Amount
+}
+
+type MonthSnapshotRepository interface {
+	// Gets the version of the budget's snapshots. The version changes
+	// every time snapshots of the budget are removed.
+	GetVersion(ctx context.Context, budgetID ID) (int64, error)
+
+	// Creates the snapshots, unless the budget's snapshots have been
+	// removed since the version was read.
+	Create(ctx context.Context, tx Tx, budgetID ID, version int64, snapshots []MonthSnapshot) error
+
+	Get(ctx context.Context, budgetID ID, date MonthDate) (MonthSnapshot, error)
+
+	// Gets the latest snapshot before the month.
+	GetLatestBefore(ctx context.Context, budgetID ID, date MonthDate) (MonthSnapshot, error)
+
+	// Gets all snapshots of the budget, in month order.
+	GetForBudget(ctx context.Context, budgetID ID) ([]MonthSnapshot, error)
+}
@@ -45,6 +45,13 @@ func TestMonthDate(t *testing.T) {
 		previous := monthDate.Previous()
 		assert.Equal(t, previous.String(), "2022-04-01")
 	})
+
+	t.Run("get next", func(t *testing.T) {
+		monthDate := NewMonthDate(NewDate(time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+		next := monthDate.Next()
+		assert.Equal(t, next.String(), "2023-01-01")
+	})
 }
 
 func TestMonthDateJSON(t *testing.T) {
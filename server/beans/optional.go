@@ -1,5 +1,7 @@
 package beans
 
+import "encoding/json"
+
 type Optional[T any] struct {
 	set bool
 	val T
@@ -16,3 +18,25 @@ func (n Optional[T]) Value() (T, bool) {
 func OptionalWrap[T any](val T) Optional[T] {
 	return Optional[T]{set: true, val: val}
 }
+
+func (n *Optional[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*n = Optional[T]{}
+		return nil
+	}
+
+	var val T
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	*n = OptionalWrap(val)
+	return nil
+}
+
+func (n Optional[T]) MarshalJSON() ([]byte, error) {
+	if !n.set {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(n.val)
+}
@@ -0,0 +1,40 @@
+package beans_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalJSON(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		res, err := json.Marshal(beans.OptionalWrap(0))
+		require.NoError(t, err)
+		assert.Equal(t, `0`, string(res))
+
+		res, err = json.Marshal(beans.Optional[int]{})
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(res))
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var res struct {
+			A beans.Optional[int] `json:"a"`
+			B beans.Optional[int] `json:"b"`
+			C beans.Optional[int] `json:"c"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"a":0,"b":null}`), &res))
+
+		assert.Equal(t, beans.OptionalWrap(0), res.A)
+		assert.Equal(t, beans.Optional[int]{}, res.B)
+		assert.Equal(t, beans.Optional[int]{}, res.C)
+	})
+
+	t.Run("unmarshal invalid", func(t *testing.T) {
+		var res beans.Optional[int]
+		require.Error(t, json.Unmarshal([]byte(`"a"`), &res))
+	})
+}
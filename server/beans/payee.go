@@ -25,7 +25,7 @@ type PayeeContract interface {
 }
 
 type PayeeRepository interface {
-	Create(ctx context.Context, payee Payee) error
+	Create(ctx context.Context, tx Tx, payee Payee) error
 	Get(ctx context.Context, budgetID ID, id ID) (Payee, error)
 	GetForBudget(ctx context.Context, budgetID ID) ([]Payee, error)
 }
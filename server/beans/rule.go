@@ -0,0 +1,185 @@
+package beans
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// Changes new transactions that match its conditions. Rules run in the order
+// they were created, and later rules win when several set the same field.
+type Rule struct {
+	ID       ID
+	BudgetID ID
+	Name     Name
+
+	RuleConditions
+	RuleActions
+}
+
+type RuleConditions struct {
+	// Matches payees with a name containing the text, ignoring case.
+	PayeeContains NullString
+
+	// Inclusive amount range. Either end may be empty.
+	MinAmount Amount
+	MaxAmount Amount
+}
+
+type RuleActions struct {
+	PayeeID    ID
+	CategoryID ID
+
+	// Added to the end of the notes, unless the notes already contain it.
+	AppendNotes TransactionNotes
+}
+
+func (r Rule) Matches(payeeName Name, amount Amount) bool {
+	if !r.PayeeContains.Empty() &&
+		!strings.Contains(strings.ToLower(string(payeeName)), strings.ToLower(r.PayeeContains.String())) {
+		return false
+	}
+	if !r.MinAmount.Empty() && amount.Compare(r.MinAmount) < 0 {
+		return false
+	}
+	if !r.MaxAmount.Empty() && amount.Compare(r.MaxAmount) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// The parts of a transaction that rules match on and change.
+type RuleSubject struct {
+	PayeeName Name
+	Amount    Amount
+
+	// Transfers cannot have a payee, and only standard transactions can
+	// have a category.
+	AllowPayee    bool
+	AllowCategory bool
+
+	PayeeID    ID
+	CategoryID ID
+	Notes      TransactionNotes
+}
+
+// Runs every matching rule on the subject. Rules match on the subject as it
+// was given, not as changed by earlier rules. Appended notes are cut off at
+// the most notes a transaction can have.
+func ApplyRules(rules []Rule, subject RuleSubject) RuleSubject {
+	for _, rule := range rules {
+		if !rule.Matches(subject.PayeeName, subject.Amount) {
+			continue
+		}
+
+		if !rule.PayeeID.Empty() && subject.AllowPayee {
+			subject.PayeeID = rule.PayeeID
+		}
+		if !rule.CategoryID.Empty() && subject.AllowCategory {
+			subject.CategoryID = rule.CategoryID
+		}
+		if !rule.AppendNotes.Empty() && !strings.Contains(subject.Notes.String(), rule.AppendNotes.String()) {
+			subject.Notes = NewTransactionNotes(truncateNotes(subject.Notes.String() + " " + rule.AppendNotes.String()))
+		}
+	}
+
+	return subject
+}
+
+// The most characters transaction notes can have.
+const maxNotesLength = 255
+
+func truncateNotes(notes string) string {
+	if len(notes) <= maxNotesLength {
+		return notes
+	}
+
+	// do not cut a character in half
+	end := maxNotesLength
+	for end > 0 && !utf8.RuneStart(notes[end]) {
+		end--
+	}
+	return notes[:end]
+}
+
+// A change that running the rules makes to an existing transaction.
+type RuleChange struct {
+	// The transaction before the change.
+	Transaction TransactionWithRelations
+
+	PayeeID    ID
+	CategoryID ID
+	Notes      TransactionNotes
+}
+
+// repository
+
+type RuleRepository interface {
+	Create(ctx context.Context, rule Rule) error
+	Update(ctx context.Context, rule Rule) error
+	Delete(ctx context.Context, budgetID ID, id ID) error
+	Get(ctx context.Context, budgetID ID, id ID) (Rule, error)
+
+	// Gets all rules for the budget in the order they run.
+	GetForBudget(ctx context.Context, budgetID ID) ([]Rule, error)
+}
+
+// contract
+
+type RuleContract interface {
+	// Creates a rule.
+	Create(ctx context.Context, auth *BudgetAuthContext, params RuleParams) (ID, error)
+
+	// Edits a rule.
+	Update(ctx context.Context, auth *BudgetAuthContext, params RuleUpdateParams) error
+
+	// Deletes a rule.
+	Delete(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Gets a rule.
+	Get(ctx context.Context, auth *BudgetAuthContext, id ID) (Rule, error)
+
+	// Gets all rules for the budget in the order they run.
+	GetAll(ctx context.Context, auth *BudgetAuthContext) ([]Rule, error)
+
+	// Runs the rules over all existing transactions. A dry run returns the
+	// changes without saving them.
+	Run(ctx context.Context, auth *BudgetAuthContext, dryRun bool) ([]RuleChange, error)
+}
+
+type RuleParams struct {
+	Name Name
+	RuleConditions
+	RuleActions
+}
+
+func (p RuleParams) ValidateAll() error {
+	err := ValidateFields(
+		Field("Name", p.Name),
+		Field("Payee contains", Max(p.PayeeContains, 255, "characters")),
+		Field("Min amount", MaxPrecision(p.MinAmount)),
+		Field("Max amount", MaxPrecision(p.MaxAmount)),
+		Field("Append notes", Max(p.AppendNotes, 255, "characters")),
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.PayeeContains.Empty() && p.MinAmount.Empty() && p.MaxAmount.Empty() {
+		return NewError(EINVALID, "Rule must have a condition.")
+	}
+	if p.PayeeID.Empty() && p.CategoryID.Empty() && p.AppendNotes.Empty() {
+		return NewError(EINVALID, "Rule must have an action.")
+	}
+	if !p.MinAmount.Empty() && !p.MaxAmount.Empty() && p.MinAmount.Compare(p.MaxAmount) > 0 {
+		return NewError(EINVALID, "Min amount must not be more than max amount.")
+	}
+
+	return nil
+}
+
+type RuleUpdateParams struct {
+	ID ID
+	RuleParams
+}
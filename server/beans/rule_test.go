@@ -0,0 +1,127 @@
+package beans_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMatches(t *testing.T) {
+	var tests = []struct {
+		name      string
+		contains  string
+		min       beans.Amount
+		max       beans.Amount
+		payeeName beans.Name
+		amount    beans.Amount
+		expected  bool
+	}{
+		{"payee contains", "coffee", beans.NewEmptyAmount(), beans.NewEmptyAmount(), "Acme Coffee", beans.NewAmount(-5, 0), true},
+		{"payee ignores case", "COFFEE", beans.NewEmptyAmount(), beans.NewEmptyAmount(), "acme coffee", beans.NewAmount(-5, 0), true},
+		{"payee does not contain", "coffee", beans.NewEmptyAmount(), beans.NewEmptyAmount(), "Acme Tea", beans.NewAmount(-5, 0), false},
+		{"empty payee", "coffee", beans.NewEmptyAmount(), beans.NewEmptyAmount(), "", beans.NewAmount(-5, 0), false},
+		{"min is inclusive", "", beans.NewAmount(-5, 0), beans.NewEmptyAmount(), "", beans.NewAmount(-5, 0), true},
+		{"below min", "", beans.NewAmount(-5, 0), beans.NewEmptyAmount(), "", beans.NewAmount(-6, 0), false},
+		{"max is inclusive", "", beans.NewEmptyAmount(), beans.NewAmount(5, 0), "", beans.NewAmount(5, 0), true},
+		{"above max", "", beans.NewEmptyAmount(), beans.NewAmount(5, 0), "", beans.NewAmount(6, 0), false},
+		{"all conditions", "coffee", beans.NewAmount(-10, 0), beans.NewAmount(0, 0), "Coffee", beans.NewAmount(-5, 0), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := beans.Rule{RuleConditions: beans.RuleConditions{
+				PayeeContains: beans.NewNullString(test.contains),
+				MinAmount:     test.min,
+				MaxAmount:     test.max,
+			}}
+
+			assert.Equal(t, test.expected, rule.Matches(test.payeeName, test.amount))
+		})
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	payeeA, payeeB := beans.NewID(), beans.NewID()
+	categoryA, categoryB := beans.NewID(), beans.NewID()
+
+	rules := []beans.Rule{
+		{
+			RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("coffee")},
+			RuleActions: beans.RuleActions{
+				PayeeID:     payeeA,
+				CategoryID:  categoryA,
+				AppendNotes: beans.NewTransactionNotes("coffee"),
+			},
+		},
+		{
+			RuleConditions: beans.RuleConditions{MaxAmount: beans.NewAmount(-100, 0)},
+			RuleActions: beans.RuleActions{
+				CategoryID:  categoryB,
+				AppendNotes: beans.NewTransactionNotes("big"),
+			},
+		},
+		{
+			// matches on the original payee, not the payee set by the first rule
+			RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("payee a")},
+			RuleActions:    beans.RuleActions{PayeeID: payeeB},
+		},
+	}
+
+	t.Run("later rules win", func(t *testing.T) {
+		res := beans.ApplyRules(rules, beans.RuleSubject{
+			PayeeName:     "Coffee Shop",
+			Amount:        beans.NewAmount(-150, 0),
+			AllowPayee:    true,
+			AllowCategory: true,
+		})
+
+		assert.Equal(t, payeeA, res.PayeeID)
+		assert.Equal(t, categoryB, res.CategoryID)
+		assert.Equal(t, beans.NewTransactionNotes("coffee big"), res.Notes)
+	})
+
+	t.Run("does not change disallowed fields", func(t *testing.T) {
+		res := beans.ApplyRules(rules, beans.RuleSubject{
+			PayeeName: "Coffee Shop",
+			Amount:    beans.NewAmount(-5, 0),
+			PayeeID:   payeeB,
+		})
+
+		assert.Equal(t, payeeB, res.PayeeID)
+		assert.True(t, res.CategoryID.Empty())
+		assert.Equal(t, beans.NewTransactionNotes("coffee"), res.Notes)
+	})
+
+	t.Run("does not append notes twice", func(t *testing.T) {
+		res := beans.ApplyRules(rules, beans.RuleSubject{
+			PayeeName: "Coffee Shop",
+			Amount:    beans.NewAmount(-5, 0),
+			Notes:     beans.NewTransactionNotes("morning coffee"),
+		})
+
+		assert.Equal(t, beans.NewTransactionNotes("morning coffee"), res.Notes)
+	})
+
+	t.Run("cuts off notes at limit", func(t *testing.T) {
+		res := beans.ApplyRules(rules, beans.RuleSubject{
+			PayeeName: "Coffee Shop",
+			Amount:    beans.NewAmount(-5, 0),
+			Notes:     beans.NewTransactionNotes(strings.Repeat("a", 250)),
+		})
+
+		assert.Equal(t, beans.NewTransactionNotes(strings.Repeat("a", 250)+" coff"), res.Notes)
+		assert.Equal(t, 255, res.Notes.Length())
+	})
+
+	t.Run("does not cut off character in half", func(t *testing.T) {
+		res := beans.ApplyRules(rules, beans.RuleSubject{
+			PayeeName: "Coffee Shop",
+			Amount:    beans.NewAmount(-5, 0),
+			Notes:     beans.NewTransactionNotes(strings.Repeat("a", 254) + "é"),
+		})
+
+		assert.Equal(t, beans.NewTransactionNotes(strings.Repeat("a", 254)), res.Notes)
+	})
+}
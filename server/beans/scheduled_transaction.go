@@ -0,0 +1,203 @@
+package beans
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ScheduledTransaction struct {
+	ID       ID
+	BudgetID ID
+
+	// Transaction created on each occurrence. The date is not used.
+	Template TransactionCreateParams
+	Rule     ScheduleRule
+
+	// Date of the next occurrence to post.
+	NextDate Date
+}
+
+type ScheduleFrequency string
+
+const (
+	ScheduleDays            ScheduleFrequency = "days"
+	ScheduleWeeks           ScheduleFrequency = "weeks"
+	ScheduleMonths          ScheduleFrequency = "months"
+	ScheduleLastBusinessDay ScheduleFrequency = "last_business_day"
+)
+
+func (f ScheduleFrequency) Empty() bool {
+	return f == ""
+}
+
+func (f ScheduleFrequency) Validate() error {
+	switch f {
+	case "", ScheduleDays, ScheduleWeeks, ScheduleMonths, ScheduleLastBusinessDay:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", f)
+}
+
+// When a scheduled transaction occurs.
+type ScheduleRule struct {
+	Frequency ScheduleFrequency
+
+	// Number of days, weeks, or months between occurrences. Rules on the
+	// last business day are every Interval months.
+	Interval int
+
+	// Occurrences are counted from this date. Monthly rules keep its day of
+	// the month, using the last day of shorter months.
+	Start Date
+}
+
+const MaxScheduleInterval = 999
+
+// Most occurrences that can be posted by one call.
+const MaxPostedOccurrences = 1000
+
+func (r ScheduleRule) ValidateAll() error {
+	err := ValidateFields(
+		Field("Frequency", Required(r.Frequency), r.Frequency),
+		Field("Start date", Required(r.Start)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if r.Interval < 1 || r.Interval > MaxScheduleInterval {
+		return NewError(EINVALID, fmt.Sprintf("Interval must be between 1 and %d.", MaxScheduleInterval))
+	}
+
+	return nil
+}
+
+// Gets the first occurrence after the date.
+func (r ScheduleRule) After(date Date) Date {
+	// start from an estimate of how many occurrences have passed
+	n := 0
+	switch r.Frequency {
+	case ScheduleDays, ScheduleWeeks:
+		days := int(date.Sub(r.Start.Time).Hours() / 24)
+		n = days / r.stepDays()
+	case ScheduleMonths, ScheduleLastBusinessDay:
+		months := (date.Year()-r.Start.Year())*12 + int(date.Month()-r.Start.Month())
+		n = months/r.Interval - 1
+	}
+	n = max(n, 0)
+
+	for {
+		occurrence := r.occurrence(n)
+		if occurrence.After(date.Time) {
+			return occurrence
+		}
+		n++
+	}
+}
+
+// Gets the nth occurrence, counting from zero.
+func (r ScheduleRule) occurrence(n int) Date {
+	switch r.Frequency {
+	case ScheduleMonths:
+		return addMonthsClamped(r.Start, n*r.Interval, r.Start.Day())
+	case ScheduleLastBusinessDay:
+		last := addMonthsClamped(r.Start, n*r.Interval, 31)
+		for last.Weekday() == time.Saturday || last.Weekday() == time.Sunday {
+			last = last.Previous()
+		}
+		return last
+	default:
+		return NewDate(r.Start.AddDate(0, 0, n*r.stepDays()))
+	}
+}
+
+func (r ScheduleRule) stepDays() int {
+	if r.Frequency == ScheduleWeeks {
+		return r.Interval * 7
+	}
+	return r.Interval
+}
+
+// Moves the date by some months and sets the day, using the last day of the
+// month if the month is too short.
+func addMonthsClamped(date Date, months int, day int) Date {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+
+	return NewDate(first.AddDate(0, 0, min(day, last)-1))
+}
+
+// repository
+
+type ScheduledTransactionRepository interface {
+	Create(ctx context.Context, scheduled ScheduledTransaction) error
+	Update(ctx context.Context, tx Tx, scheduled ScheduledTransaction) error
+	Delete(ctx context.Context, budgetID ID, id ID) error
+	Get(ctx context.Context, budgetID ID, id ID) (ScheduledTransaction, error)
+	GetForBudget(ctx context.Context, budgetID ID) ([]ScheduledTransaction, error)
+
+	// Gets scheduled transactions with an occurrence on or before the date.
+	GetDue(ctx context.Context, budgetID ID, date Date) ([]ScheduledTransaction, error)
+
+	// Moves the next date of a scheduled transaction, only if it is still
+	// the previous date. Returns whether it was moved.
+	AdvanceNextDate(ctx context.Context, tx Tx, id ID, previous Date, next Date) (bool, error)
+}
+
+// contract
+
+type ScheduledTransactionContract interface {
+	// Creates a scheduled transaction.
+	Create(ctx context.Context, auth *BudgetAuthContext, params ScheduledTransactionParams) (ID, error)
+
+	// Edits a scheduled transaction. Occurrences already posted are not
+	// posted again. A new rule or start date counts occurrences from the
+	// later of the start date and the next unposted occurrence.
+	Update(ctx context.Context, auth *BudgetAuthContext, params ScheduledTransactionUpdateParams) error
+
+	// Deletes a scheduled transaction. Transactions already posted are kept.
+	Delete(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Gets a scheduled transaction.
+	Get(ctx context.Context, auth *BudgetAuthContext, id ID) (ScheduledTransaction, error)
+
+	// Gets all scheduled transactions for the budget.
+	GetAll(ctx context.Context, auth *BudgetAuthContext) ([]ScheduledTransaction, error)
+
+	// Creates the transactions for every occurrence on or before the date,
+	// which defaults to today and cannot be later than tomorrow. A schedule
+	// whose template is no longer valid is left unposted and reported.
+	PostDue(ctx context.Context, auth *BudgetAuthContext, date Date) (PostDueResult, error)
+}
+
+type PostDueResult struct {
+	TransactionIDs []ID
+	Failed         []PostDueFailure
+}
+
+type PostDueFailure struct {
+	ScheduledTransactionID ID
+	Error                  string
+}
+
+type ScheduledTransactionParams struct {
+	// The date of the template is the first occurrence.
+	Template  TransactionCreateParams
+	Frequency ScheduleFrequency
+	Interval  int
+}
+
+func (p ScheduledTransactionParams) Rule() ScheduleRule {
+	return ScheduleRule{
+		Frequency: p.Frequency,
+		Interval:  p.Interval,
+		Start:     p.Template.Date,
+	}
+}
+
+type ScheduledTransactionUpdateParams struct {
+	ID ID
+	ScheduledTransactionParams
+}
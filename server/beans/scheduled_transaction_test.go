@@ -0,0 +1,53 @@
+package beans_test
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleRuleAfter(t *testing.T) {
+	var tests = []struct {
+		name      string
+		frequency beans.ScheduleFrequency
+		interval  int
+		start     string
+		after     string
+		expected  string
+	}{
+		{"days before start", beans.ScheduleDays, 3, "2024-01-10", "2024-01-01", "2024-01-10"},
+		{"days on occurrence", beans.ScheduleDays, 3, "2024-01-10", "2024-01-13", "2024-01-16"},
+		{"days between occurrences", beans.ScheduleDays, 3, "2024-01-10", "2024-01-14", "2024-01-16"},
+		{"weeks", beans.ScheduleWeeks, 2, "2024-01-01", "2024-01-01", "2024-01-15"},
+		{"months", beans.ScheduleMonths, 1, "2024-01-15", "2024-01-15", "2024-02-15"},
+		{"months keeps day", beans.ScheduleMonths, 1, "2024-01-31", "2024-02-29", "2024-03-31"},
+		{"months uses last day of short month", beans.ScheduleMonths, 1, "2024-01-31", "2024-01-31", "2024-02-29"},
+		{"every three months", beans.ScheduleMonths, 3, "2024-01-05", "2024-02-01", "2024-04-05"},
+		{"months across years", beans.ScheduleMonths, 1, "2023-12-20", "2024-05-20", "2024-06-20"},
+		{"last business day", beans.ScheduleLastBusinessDay, 1, "2024-01-01", "2024-01-01", "2024-01-31"},
+		{"last business day skips weekend", beans.ScheduleLastBusinessDay, 1, "2024-01-01", "2024-01-31", "2024-02-29"},
+		{"last business day on friday", beans.ScheduleLastBusinessDay, 1, "2024-03-01", "2024-03-01", "2024-03-29"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := beans.ScheduleRule{
+				Frequency: test.frequency,
+				Interval:  test.interval,
+				Start:     testutils.NewDate(t, test.start),
+			}
+
+			assert.Equal(t, testutils.NewDate(t, test.expected), rule.After(testutils.NewDate(t, test.after)))
+		})
+	}
+}
+
+func TestScheduleRuleValidate(t *testing.T) {
+	rule := beans.ScheduleRule{Frequency: "years", Interval: 1, Start: testutils.NewDate(t, "2024-01-01")}
+	testutils.AssertErrorAndCode(t, rule.ValidateAll(), beans.EINVALID, "Frequency years is not supported.")
+
+	rule = beans.ScheduleRule{Frequency: beans.ScheduleDays, Interval: 0, Start: testutils.NewDate(t, "2024-01-01")}
+	testutils.AssertErrorAndCode(t, rule.ValidateAll(), beans.EINVALID, "Interval must be between 1 and 999.")
+}
@@ -0,0 +1,50 @@
+package beans
+
+import "context"
+
+// A label for tracking transactions across categories, such as a trip or a
+// project. Transactions and split lines can have many tags.
+type Tag struct {
+	ID       ID
+	BudgetID ID
+	Name     Name
+}
+
+type RelatedTag struct {
+	ID   ID
+	Name Name
+}
+
+// repository
+
+type TagRepository interface {
+	Create(ctx context.Context, tag Tag) error
+	Update(ctx context.Context, tag Tag) error
+	Delete(ctx context.Context, budgetID ID, id ID) error
+	Get(ctx context.Context, budgetID ID, id ID) (Tag, error)
+
+	// Gets all tags for the budget ordered by name.
+	GetForBudget(ctx context.Context, budgetID ID) ([]Tag, error)
+
+	// Replaces the tags of each transaction in the map.
+	SetForTransactions(ctx context.Context, tx Tx, tags map[ID][]ID) error
+}
+
+// contract
+
+type TagContract interface {
+	// Creates a tag.
+	Create(ctx context.Context, auth *BudgetAuthContext, name Name) (ID, error)
+
+	// Renames a tag.
+	Update(ctx context.Context, auth *BudgetAuthContext, id ID, name Name) error
+
+	// Deletes a tag and removes it from all transactions.
+	Delete(ctx context.Context, auth *BudgetAuthContext, id ID) error
+
+	// Gets a tag.
+	Get(ctx context.Context, auth *BudgetAuthContext, id ID) (Tag, error)
+
+	// Gets all tags for the budget ordered by name.
+	GetAll(ctx context.Context, auth *BudgetAuthContext) ([]Tag, error)
+}
@@ -2,6 +2,9 @@ package beans
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 )
 
 type Transaction struct {
@@ -14,10 +17,19 @@ type Transaction struct {
 	Amount Amount
 	Date   Date
 	Notes  TransactionNotes
+	Status TransactionStatus
 
 	TransferID ID
 	SplitID    ID
 	IsSplit    bool
+
+	// Identifier given to the transaction by the bank statement it was
+	// imported from, such as an OFX FITID. Unique per account.
+	ImportID NullString
+
+	// Payee name in the bank statement the transaction was imported from.
+	// Rules can change the payee, so imports find duplicates by this name.
+	ImportPayee NullString
 }
 
 type Split struct {
@@ -25,6 +37,7 @@ type Split struct {
 	Amount   Amount
 	Notes    TransactionNotes
 	Category RelatedCategory
+	Tags     []RelatedTag
 }
 
 type TransactionAsSplit struct {
@@ -37,6 +50,7 @@ type TransactionWithRelations struct {
 	Amount Amount
 	Date   Date
 	Notes  TransactionNotes
+	Status TransactionStatus
 
 	Variant TransactionVariant
 
@@ -44,6 +58,9 @@ type TransactionWithRelations struct {
 	Category        Optional[RelatedCategory]
 	Payee           Optional[RelatedPayee]
 	TransferAccount Optional[RelatedAccount]
+
+	// Ordered by name.
+	Tags []RelatedTag
 }
 
 type TransactionNotes struct{ NullString }
@@ -52,6 +69,26 @@ func NewTransactionNotes(string string) TransactionNotes {
 	return TransactionNotes{NullString: NewNullString(string)}
 }
 
+// Whether the transaction has cleared the bank. Reconciled transactions
+// have been checked against a statement, and their status, amount and
+// account are locked.
+type TransactionStatus string
+
+const (
+	TransactionUncleared  TransactionStatus = "uncleared"
+	TransactionCleared    TransactionStatus = "cleared"
+	TransactionReconciled TransactionStatus = "reconciled"
+)
+
+func (s TransactionStatus) Validate() error {
+	switch s {
+	case "", TransactionUncleared, TransactionCleared, TransactionReconciled:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", s)
+}
+
 type TransactionVariant string
 
 const (
@@ -65,8 +102,13 @@ type TransactionContract interface {
 	// Creates a transaction.
 	Create(ctx context.Context, auth *BudgetAuthContext, params TransactionCreateParams) (ID, error)
 
-	// Gets all transactions for budget. Excludes splits.
-	GetAll(ctx context.Context, auth *BudgetAuthContext) ([]TransactionWithRelations, error)
+	// Gets transactions for budget matching the params, one page at a time.
+	// Excludes splits.
+	GetAll(ctx context.Context, auth *BudgetAuthContext, params TransactionListParams) (TransactionPage, error)
+
+	// Finds transactions for budget matching the search, most relevant first.
+	// Excludes splits.
+	Search(ctx context.Context, auth *BudgetAuthContext, params TransactionSearchParams) ([]TransactionWithRelations, error)
 
 	// Get splits for a transaction.
 	GetSplits(ctx context.Context, auth *BudgetAuthContext, id ID) ([]Split, error)
@@ -74,22 +116,38 @@ type TransactionContract interface {
 	// Edits a transaction.
 	Update(ctx context.Context, auth *BudgetAuthContext, params TransactionUpdateParams) error
 
+	// Edits many transactions at once. Either all transactions are updated or
+	// none are.
+	BulkUpdate(ctx context.Context, auth *BudgetAuthContext, params TransactionBulkUpdateParams) error
+
 	// Deletes transactions.
 	Delete(ctx context.Context, auth *BudgetAuthContext, transactionIDs []ID) error
 
 	// Gets a transaction details.
 	Get(ctx context.Context, auth *BudgetAuthContext, id ID) (TransactionWithRelations, error)
+
+	// Gets the history of a transaction and its split lines, oldest first.
+	// History is kept after the transaction is deleted. Edits that change
+	// nothing are not recorded.
+	GetHistory(ctx context.Context, auth *BudgetAuthContext, id ID) ([]TransactionHistory, error)
 }
 
 type TransactionRepository interface {
-	Create(ctx context.Context, transactions []Transaction) error
+	Create(ctx context.Context, tx Tx, transactions []Transaction) error
+
+	Update(ctx context.Context, tx Tx, transactions []Transaction) error
+
+	Delete(ctx context.Context, tx Tx, budgetID ID, transactionIDs []ID) error
 
-	Update(ctx context.Context, transactions []Transaction) error
+	// Deletes split lines. Transactions that are not splits are ignored.
+	DeleteSplits(ctx context.Context, tx Tx, splitIDs []ID) error
 
-	Delete(ctx context.Context, budgetID ID, transactionIDs []ID) error
+	// Gets all transactions for budget matching the params. Excludes splits.
+	GetForBudget(ctx context.Context, budgetID ID, params TransactionListParams) ([]TransactionWithRelations, error)
 
-	// Gets all transactions for budget. Excludes splits.
-	GetForBudget(ctx context.Context, budgetID ID) ([]TransactionWithRelations, error)
+	// Finds transactions for budget matching the search, most relevant first.
+	// A match on a split line finds its transaction. Excludes splits.
+	Search(ctx context.Context, budgetID ID, params TransactionSearchParams) ([]TransactionWithRelations, error)
 
 	// Gets a single transaction for budget.
 	GetWithRelations(ctx context.Context, budgetID ID, id ID) (TransactionWithRelations, error)
@@ -97,14 +155,44 @@ type TransactionRepository interface {
 	// Gets all splits for a transaction.
 	GetSplits(ctx context.Context, budgetID ID, transactionID ID) ([]TransactionAsSplit, error)
 
+	// Gets the splits of every split transaction on an account.
+	GetSplitsForAccount(ctx context.Context, budgetID ID, accountID ID) ([]TransactionAsSplit, error)
+
 	// Get transaction.
 	Get(ctx context.Context, budgetID ID, id ID) (Transaction, error)
 
+	// Gets all transactions on an account between the dates. Excludes splits.
+	GetForAccountBetween(ctx context.Context, budgetID ID, accountID ID, begin Date, end Date) ([]Transaction, error)
+
+	// Gets the sum of cleared and reconciled transactions on an account
+	// on or before the date.
+	GetClearedBalance(ctx context.Context, tx Tx, accountID ID, date Date) (Amount, error)
+
+	// Gets the cleared transactions on an account on or before the date,
+	// including split lines. These are the transactions Reconcile marks.
+	GetToReconcile(ctx context.Context, tx Tx, accountID ID, date Date) ([]Transaction, error)
+
+	// Marks cleared transactions on an account on or before the date as
+	// reconciled.
+	Reconcile(ctx context.Context, tx Tx, accountID ID, date Date) error
+
+	// Gets the import IDs of all transactions on an account.
+	GetImportIDs(ctx context.Context, budgetID ID, accountID ID) ([]string, error)
+
 	// Gets sum of all income transactions between the dates.
 	GetIncomeBetween(ctx context.Context, budgetID ID, begin Date, end Date) (Amount, error)
 
 	// Gets sum of transactions grouped by category between the dates.
 	GetActivityByCategory(ctx context.Context, budgetID ID, from Date, to Date) (map[ID]Amount, error)
+
+	// Gets the activity of credit account payment categories between the
+	// dates, grouped by payment category. Spending on a credit account moves
+	// into its payment category and payments move out of it.
+	GetPaymentActivityByCategory(ctx context.Context, budgetID ID, from Date, to Date) (map[ID]Amount, error)
+
+	// Gets the activity of budgeted categories on credit accounts between the
+	// dates, grouped by category and then by the account's payment category.
+	GetCreditActivityByCategory(ctx context.Context, budgetID ID, from Date, to Date) (map[ID]map[ID]Amount, error)
 }
 
 type TransactionParams struct {
@@ -115,12 +203,18 @@ type TransactionParams struct {
 	Date       Date
 	Notes      TransactionNotes
 	Splits     []SplitParams
+	TagIDs     []ID
+
+	// Defaults to uncleared when creating and to the current status when
+	// updating.
+	Status TransactionStatus
 }
 
 type SplitParams struct {
 	Amount     Amount
 	CategoryID ID
 	Notes      TransactionNotes
+	TagIDs     []ID
 }
 
 type TransactionCreateParams struct {
@@ -141,12 +235,54 @@ func (t TransactionUpdateParams) ValidateAll() error {
 	return ValidateFields(Field("Transaction ID", Required(t.ID)))
 }
 
+type TransactionBulkUpdateParams struct {
+	IDs []ID
+
+	// Only the set fields are changed. An empty category or payee ID
+	// removes the category or payee.
+	AccountID  Optional[ID]
+	CategoryID Optional[ID]
+	PayeeID    Optional[ID]
+	Date       Optional[Date]
+	Notes      Optional[TransactionNotes]
+}
+
+func (t TransactionBulkUpdateParams) ValidateAll() error {
+	if len(t.IDs) == 0 {
+		return NewError(EINVALID, "Transaction IDs is required.")
+	}
+
+	return nil
+}
+
+// Applies the set fields to the params.
+func (t TransactionBulkUpdateParams) Apply(params TransactionParams) TransactionParams {
+	if id, ok := t.AccountID.Value(); ok {
+		params.AccountID = id
+	}
+	if id, ok := t.CategoryID.Value(); ok {
+		params.CategoryID = id
+	}
+	if id, ok := t.PayeeID.Value(); ok {
+		params.PayeeID = id
+	}
+	if date, ok := t.Date.Value(); ok {
+		params.Date = date
+	}
+	if notes, ok := t.Notes.Value(); ok {
+		params.Notes = notes
+	}
+
+	return params
+}
+
 func (t TransactionParams) ValidateAll() error {
 	err := ValidateFields(
 		Field("Account ID", Required(t.AccountID)),
 		Field("Amount", Required(&t.Amount), MaxPrecision(t.Amount)),
 		Field("Date", Required(t.Date)),
 		Field("Notes", Max(t.Notes, 255, "characters")),
+		Field("Status", t.Status),
 	)
 	if err != nil {
 		return err
@@ -175,6 +311,186 @@ func (t TransactionParams) ValidateAll() error {
 	return nil
 }
 
+// listing
+
+type TransactionFilter struct {
+	AccountID ID
+
+	// Matches split transactions with a split in the category.
+	CategoryID ID
+	PayeeID    ID
+
+	// Matches split transactions with a split that has the tag.
+	TagID ID
+
+	// Inclusive date range.
+	From Date
+	To   Date
+
+	// Inclusive amount range.
+	MinAmount Amount
+	MaxAmount Amount
+
+	Variant TransactionVariant
+}
+
+type TransactionSort string
+
+const (
+	TransactionSortDateDesc   TransactionSort = "date_desc"
+	TransactionSortDateAsc    TransactionSort = "date_asc"
+	TransactionSortAmountDesc TransactionSort = "amount_desc"
+	TransactionSortAmountAsc  TransactionSort = "amount_asc"
+)
+
+func (s TransactionSort) Validate() error {
+	switch s {
+	case "", TransactionSortDateDesc, TransactionSortDateAsc, TransactionSortAmountDesc, TransactionSortAmountAsc:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", s)
+}
+
+// Gets the sort, defaulting to newest first.
+func (s TransactionSort) OrDefault() TransactionSort {
+	if s == "" {
+		return TransactionSortDateDesc
+	}
+
+	return s
+}
+
+// Position in a sorted list of transactions. Listing with a cursor returns
+// the transactions that come after it.
+type TransactionCursor struct {
+	Sort   TransactionSort `json:"s"`
+	ID     ID              `json:"i"`
+	Date   Date            `json:"d"`
+	Amount Amount          `json:"a"`
+}
+
+func NewTransactionCursor(sort TransactionSort, transaction TransactionWithRelations) TransactionCursor {
+	return TransactionCursor{
+		Sort:   sort.OrDefault(),
+		ID:     transaction.ID,
+		Date:   transaction.Date,
+		Amount: transaction.Amount,
+	}
+}
+
+func ParseTransactionCursor(cursor string) (TransactionCursor, error) {
+	var c TransactionCursor
+	if cursor == "" {
+		return c, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil || c.ID.Empty() || c.Date.Empty() || c.Amount.Empty() {
+		return TransactionCursor{}, NewError(EINVALID, "Invalid cursor.")
+	}
+
+	return c, nil
+}
+
+func (c TransactionCursor) Empty() bool {
+	return c.ID.Empty()
+}
+
+func (c TransactionCursor) String() string {
+	if c.Empty() {
+		return ""
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+type TransactionListParams struct {
+	TransactionFilter
+
+	Sort   TransactionSort
+	Cursor TransactionCursor
+
+	// Maximum number of transactions to get. Zero gets all transactions.
+	Limit int
+}
+
+const MaxTransactionListLimit = 1000
+
+func (p TransactionListParams) ValidateAll() error {
+	err := ValidateFields(
+		Field("Sort", p.Sort),
+		Field("Variant", p.Variant),
+		Field("Min amount", MaxPrecision(p.MinAmount)),
+		Field("Max amount", MaxPrecision(p.MaxAmount)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.Limit < 0 || p.Limit > MaxTransactionListLimit {
+		return NewError(EINVALID, fmt.Sprintf("Limit must be between 0 and %d.", MaxTransactionListLimit))
+	}
+
+	if !p.Cursor.Empty() && p.Cursor.Sort != p.Sort.OrDefault() {
+		return NewError(EINVALID, "Cursor does not match sort.")
+	}
+
+	return nil
+}
+
+type TransactionPage struct {
+	Transactions []TransactionWithRelations
+
+	// Cursor for the next page. Empty if this is the last page.
+	NextCursor TransactionCursor
+}
+
+// search
+
+type TransactionSearchParams struct {
+	// Words to find in the notes, payee, category or account. Each word
+	// matches as a prefix.
+	Query NullString
+
+	AccountID ID
+
+	// Inclusive date range.
+	From Date
+	To   Date
+
+	// Maximum number of transactions to get. Zero gets all matches.
+	Limit int
+}
+
+func (p TransactionSearchParams) ValidateAll() error {
+	err := ValidateFields(
+		Field("Query", Required(p.Query), Max(p.Query, 255, "characters")),
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.Limit < 0 || p.Limit > MaxTransactionListLimit {
+		return NewError(EINVALID, fmt.Sprintf("Limit must be between 0 and %d.", MaxTransactionListLimit))
+	}
+
+	return nil
+}
+
+func (v TransactionVariant) Validate() error {
+	switch v {
+	case "", TransactionStandard, TransactionOffBudget, TransactionTransfer, TransactionSplit:
+		return nil
+	}
+
+	return fmt.Errorf(":field %s is not supported", v)
+}
+
 // helpers
 
 func GetTransactionVariant(
@@ -0,0 +1,79 @@
+package beans
+
+import (
+	"context"
+	"time"
+)
+
+type TransactionHistoryAction string
+
+const (
+	TransactionCreated TransactionHistoryAction = "create"
+	TransactionUpdated TransactionHistoryAction = "update"
+	TransactionDeleted TransactionHistoryAction = "delete"
+)
+
+// A record of a change to a transaction. History is never changed, and is
+// kept after the transaction is deleted.
+type TransactionHistory struct {
+	ID            ID
+	BudgetID      ID
+	TransactionID ID
+
+	// Set when the transaction is a split line.
+	SplitID ID
+
+	// The user that made the change. Only the ID is needed to create.
+	User UserPublic
+
+	Action    TransactionHistoryAction
+	CreatedAt time.Time
+
+	// Empty for a created transaction.
+	Before Optional[TransactionHistoryValues]
+
+	// Empty for a deleted transaction.
+	After Optional[TransactionHistoryValues]
+}
+
+// The fields of a transaction that are kept in its history.
+type TransactionHistoryValues struct {
+	AccountID  ID
+	CategoryID ID
+	PayeeID    ID
+	Amount     Amount
+	Date       Date
+	Notes      TransactionNotes
+	Status     TransactionStatus
+}
+
+func (t Transaction) HistoryValues() TransactionHistoryValues {
+	return TransactionHistoryValues{
+		AccountID:  t.AccountID,
+		CategoryID: t.CategoryID,
+		PayeeID:    t.PayeeID,
+		Amount:     t.Amount,
+		Date:       t.Date,
+		Notes:      t.Notes,
+		Status:     t.Status,
+	}
+}
+
+func (v TransactionHistoryValues) Equal(other TransactionHistoryValues) bool {
+	return v.AccountID == other.AccountID &&
+		v.CategoryID == other.CategoryID &&
+		v.PayeeID == other.PayeeID &&
+		v.Amount.Compare(other.Amount) == 0 &&
+		v.Date.String() == other.Date.String() &&
+		v.Notes == other.Notes &&
+		v.Status == other.Status
+}
+
+// repository
+
+type TransactionHistoryRepository interface {
+	Create(ctx context.Context, tx Tx, history []TransactionHistory) error
+
+	// Gets the history of a transaction and its split lines, oldest first.
+	GetForTransaction(ctx context.Context, budgetID ID, transactionID ID) ([]TransactionHistory, error)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bradenrayhorn/beans/server/contract"
 	"github.com/bradenrayhorn/beans/server/http"
 	"github.com/bradenrayhorn/beans/server/inmem"
+	"github.com/bradenrayhorn/beans/server/localfs"
 	"github.com/bradenrayhorn/beans/server/service"
 	"github.com/bradenrayhorn/beans/server/sqlite"
 )
@@ -42,8 +43,13 @@ func (a *Application) Start() error {
 	a.datasource = sqlite.NewDataSource(pool)
 	a.sessionRepository = inmem.NewSessionRepository()
 
+	blobStore, err := localfs.NewBlobStore(a.config.AttachmentsPath)
+	if err != nil {
+		return err
+	}
+
 	a.httpServer = http.NewServer(
-		contract.NewContracts(a.datasource, a.sessionRepository),
+		contract.NewContracts(a.datasource, a.sessionRepository, blobStore),
 		service.NewServices(a.datasource, a.sessionRepository),
 	)
 	if err := a.httpServer.Open(":" + a.config.Port); err != nil {
@@ -15,6 +15,9 @@ import (
 type Config struct {
 	DbFilePath string
 
+	// Directory where attachment files are kept.
+	AttachmentsPath string
+
 	Port string
 }
 
@@ -24,8 +27,9 @@ func LoadConfig() (Config, error) {
 
 	// load defaults
 	err := k.Load(confmap.Provider(map[string]interface{}{
-		"http.port": "8000",
-		"db.path":   "beans.db",
+		"http.port":        "8000",
+		"db.path":          "beans.db",
+		"attachments.path": "attachments",
 	}, "."), nil)
 	if err != nil {
 		return Config{}, err
@@ -51,7 +55,8 @@ func LoadConfig() (Config, error) {
 	}
 
 	return Config{
-		DbFilePath: k.String("db.path"),
-		Port:       k.String("http.port"),
+		DbFilePath:      k.String("db.path"),
+		AttachmentsPath: k.String("attachments.path"),
+		Port:            k.String("http.port"),
 	}, nil
 }
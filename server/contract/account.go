@@ -2,6 +2,9 @@ package contract
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 )
@@ -11,18 +14,66 @@ type accountContract struct{ contract }
 var _ beans.AccountContract = (*accountContract)(nil)
 
 func (c *accountContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AccountCreate) (beans.ID, error) {
-	if err := beans.ValidateFields(beans.Field("Account name", params.Name)); err != nil {
+	if err := params.ValidateAll(); err != nil {
 		return beans.ID{}, err
 	}
 
+	accountType := params.Type
+	if accountType == "" {
+		accountType = beans.AccountChecking
+	}
+
 	account := beans.Account{
 		ID:        beans.NewID(),
 		Name:      params.Name,
+		Type:      accountType,
 		BudgetID:  auth.BudgetID(),
 		OffBudget: params.OffBudget,
 	}
 
-	if err := c.ds().AccountRepository().Create(ctx, account); err != nil {
+	var openingBalance beans.Transaction
+	if params.HasOpeningBalance() {
+		// on-budget opening balances are income to be budgeted
+		categoryID := beans.EmptyID()
+		if !account.OffBudget {
+			income, err := c.getIncomeCategory(ctx, auth)
+			if err != nil {
+				return beans.ID{}, err
+			}
+			categoryID = income.ID
+		}
+
+		openingBalance = beans.Transaction{
+			ID:         beans.NewID(),
+			AccountID:  account.ID,
+			CategoryID: categoryID,
+			Amount:     params.OpeningBalance,
+			Date:       params.OpeningDate,
+			Notes:      beans.NewTransactionNotes("Starting Balance"),
+			Status:     beans.TransactionCleared,
+		}
+	}
+
+	err := beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if account.NeedsPaymentCategory() {
+			categoryID, err := c.createPaymentCategory(ctx, tx, account)
+			if err != nil {
+				return err
+			}
+			account.PaymentCategoryID = categoryID
+		}
+
+		if err := c.ds().AccountRepository().Create(ctx, tx, account); err != nil {
+			return err
+		}
+
+		if !openingBalance.ID.Empty() {
+			return (&transactionContract{c.contract}).saveCreate(ctx, tx, auth, []beans.Transaction{openingBalance})
+		}
+
+		return nil
+	})
+	if err != nil {
 		return beans.ID{}, err
 	}
 
@@ -40,3 +91,414 @@ func (c *accountContract) GetTransactable(ctx context.Context, auth *beans.Budge
 func (c *accountContract) Get(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) (beans.Account, error) {
 	return c.ds().AccountRepository().Get(ctx, auth.BudgetID(), id)
 }
+
+func (c *accountContract) Update(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AccountUpdateParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), params.ID)
+	if err != nil {
+		return err
+	}
+
+	// categories are only kept on on-budget transactions
+	if account.OffBudget != params.OffBudget {
+		transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{
+			TransactionFilter: beans.TransactionFilter{AccountID: account.ID},
+			Limit:             1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(transactions) > 0 {
+			return beans.NewError(beans.EINVALID, "Cannot move an account with transactions on or off budget.")
+		}
+	}
+
+	account.Name = params.Name
+	account.OffBudget = params.OffBudget
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if account.NeedsPaymentCategory() {
+			categoryID, err := c.createPaymentCategory(ctx, tx, account)
+			if err != nil {
+				return err
+			}
+			account.PaymentCategoryID = categoryID
+		}
+
+		return c.ds().AccountRepository().Update(ctx, tx, account)
+	})
+}
+
+func (c *accountContract) Close(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), id)
+	if err != nil {
+		return err
+	}
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		balance, err := c.ds().AccountRepository().GetBalance(ctx, tx, auth.BudgetID(), account.ID)
+		if err != nil {
+			return err
+		}
+
+		if balance.Compare(beans.NewAmount(0, 0)) != 0 {
+			return beans.NewError(beans.EINVALID, fmt.Sprintf("Account balance of %s must be zero to close.", balance.String()))
+		}
+
+		account.Closed = true
+		return c.ds().AccountRepository().Update(ctx, tx, account)
+	})
+}
+
+func (c *accountContract) Reopen(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), id)
+	if err != nil {
+		return err
+	}
+
+	account.Closed = false
+	return c.ds().AccountRepository().Update(ctx, nil, account)
+}
+
+func (c *accountContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AccountDeleteParams) error {
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), params.ID)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{
+		TransactionFilter: beans.TransactionFilter{AccountID: account.ID},
+	})
+	if err != nil {
+		return err
+	}
+
+	scheduled, err := c.ds().ScheduledTransactionRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	hasScheduled := slices.ContainsFunc(scheduled, func(s beans.ScheduledTransaction) bool {
+		return s.Template.AccountID == account.ID || s.Template.TransferAccountID == account.ID
+	})
+
+	if len(transactions) == 0 && !hasScheduled && account.PaymentCategoryID.Empty() {
+		return c.ds().AccountRepository().Delete(ctx, nil, auth.BudgetID(), account.ID)
+	}
+
+	if params.MoveToAccountID.Empty() {
+		switch {
+		case len(transactions) > 0:
+			return beans.NewError(beans.EINVALID, "Account has transactions. Choose an account to move them to.")
+		case hasScheduled:
+			return beans.NewError(beans.EINVALID, "Account has scheduled transactions. Choose an account to move them to.")
+		default:
+			return beans.NewError(beans.EINVALID, "Account has a payment category. Choose a credit account to move it to.")
+		}
+	}
+	if params.MoveToAccountID == account.ID {
+		return beans.NewError(beans.EINVALID, "Cannot move transactions to the account being deleted.")
+	}
+
+	moveTo, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), params.MoveToAccountID)
+	if err != nil {
+		if errors.Is(err, beans.ErrorNotFound) {
+			return beans.NewError(beans.EINVALID, "Invalid Move To Account ID.")
+		}
+		return err
+	}
+	if moveTo.Closed {
+		return beans.NewError(beans.EINVALID, "Cannot move transactions to a closed account.")
+	}
+	if moveTo.OffBudget != account.OffBudget {
+		return beans.NewError(beans.EINVALID, "Cannot move transactions between on-budget and off-budget accounts.")
+	}
+	if !account.PaymentCategoryID.Empty() && moveTo.PaymentCategoryID.Empty() {
+		return beans.NewError(beans.EINVALID, "Cannot move a payment category to an account that is not a credit account.")
+	}
+
+	// transfers with the other account would become transfers with itself
+	deleteIDs := []beans.ID{}
+	history := []beans.TransactionHistory{}
+	toMove := []beans.TransactionWithRelations{}
+	for _, withRelations := range transactions {
+		transferAccount, ok := withRelations.TransferAccount.Value()
+		if !ok || transferAccount.ID != moveTo.ID {
+			toMove = append(toMove, withRelations)
+			continue
+		}
+
+		transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), withRelations.ID)
+		if err != nil {
+			return err
+		}
+		transfer, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), transaction.TransferID)
+		if err != nil {
+			return fmt.Errorf("could not get transfer: %w", err)
+		}
+
+		deleteIDs = append(deleteIDs, transaction.ID, transfer.ID)
+		history = append(history, historyOfDelete(auth, transaction), historyOfDelete(auth, transfer))
+	}
+
+	// the payment category is folded into the other account's
+	if !account.PaymentCategoryID.Empty() {
+		inPaymentCategory, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{
+			TransactionFilter: beans.TransactionFilter{CategoryID: account.PaymentCategoryID},
+		})
+		if err != nil {
+			return err
+		}
+		toMove = append(toMove, inPaymentCategory...)
+	}
+
+	moveHistory, err := c.historyOfMove(ctx, auth, toMove, account, moveTo)
+	if err != nil {
+		return err
+	}
+	history = append(history, moveHistory...)
+
+	// attachments are deleted with their transactions, but their files are not
+	attachments, err := c.ds().AttachmentRepository().GetForTransactions(ctx, auth.BudgetID(), deleteIDs)
+	if err != nil {
+		return err
+	}
+
+	err = beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if len(deleteIDs) > 0 {
+			if err := c.ds().TransactionRepository().Delete(ctx, tx, auth.BudgetID(), deleteIDs); err != nil {
+				return err
+			}
+		}
+		if err := c.ds().AccountRepository().MoveTransactions(ctx, tx, auth.BudgetID(), account.ID, moveTo.ID); err != nil {
+			return err
+		}
+		if !account.PaymentCategoryID.Empty() {
+			if err := c.ds().CategoryRepository().Reassign(ctx, tx, auth.BudgetID(), account.PaymentCategoryID, moveTo.PaymentCategoryID); err != nil {
+				return err
+			}
+		}
+		if err := c.ds().TransactionHistoryRepository().Create(ctx, tx, history); err != nil {
+			return err
+		}
+
+		if err := c.ds().AccountRepository().Delete(ctx, tx, auth.BudgetID(), account.ID); err != nil {
+			return err
+		}
+		if !account.PaymentCategoryID.Empty() {
+			return c.ds().CategoryRepository().Delete(ctx, tx, auth.BudgetID(), account.PaymentCategoryID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.deleteAttachmentFiles(ctx, attachments)
+	return nil
+}
+
+// Makes history for moving transactions, and the lines of split
+// transactions, off the account and out of its payment category.
+func (c *accountContract) historyOfMove(ctx context.Context, auth *beans.BudgetAuthContext, transactions []beans.TransactionWithRelations, account beans.Account, moveTo beans.Account) ([]beans.TransactionHistory, error) {
+	history := []beans.TransactionHistory{}
+	seen := make(map[beans.ID]bool)
+	addMove := func(transaction beans.Transaction) {
+		if seen[transaction.ID] {
+			return
+		}
+		seen[transaction.ID] = true
+
+		moved := transaction
+		if moved.AccountID == account.ID {
+			moved.AccountID = moveTo.ID
+		}
+		if !account.PaymentCategoryID.Empty() && moved.CategoryID == account.PaymentCategoryID {
+			moved.CategoryID = moveTo.PaymentCategoryID
+		}
+		if moved.AccountID == transaction.AccountID && moved.CategoryID == transaction.CategoryID {
+			return
+		}
+		history = append(history, historyOfUpdate(auth, transaction, moved))
+	}
+
+	for _, withRelations := range transactions {
+		transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), withRelations.ID)
+		if err != nil {
+			return nil, err
+		}
+		addMove(transaction)
+
+		if withRelations.Variant == beans.TransactionSplit {
+			splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), withRelations.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, split := range splits {
+				addMove(split.Transaction)
+			}
+		}
+	}
+
+	return history, nil
+}
+
+func (c *accountContract) GetBalances(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AccountBalanceParams) ([]beans.AccountBalance, error) {
+	if err := params.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	if params.Interval == "" {
+		params.Interval = beans.BalanceMonthly
+	}
+
+	if !params.AccountID.Empty() {
+		if _, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), params.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.ds().AccountRepository().GetBalances(ctx, auth.BudgetID(), params)
+}
+
+func (c *accountContract) Reconcile(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AccountReconcileParams) (beans.ID, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), params.AccountID)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+
+	return beans.ExecTx(ctx, c.ds().TxManager(), func(tx beans.Tx) (beans.ID, error) {
+		// only transactions up to the statement date are on the statement
+		cleared, err := c.ds().TransactionRepository().GetClearedBalance(ctx, tx, account.ID, params.Date)
+		if err != nil {
+			return beans.EmptyID(), err
+		}
+
+		difference, err := beans.Arithmetic.Add(params.Balance, beans.Arithmetic.Negate(cleared))
+		if err != nil {
+			return beans.EmptyID(), err
+		}
+
+		adjustmentID := beans.EmptyID()
+		if difference.Compare(beans.NewAmount(0, 0)) != 0 {
+			if !params.CreateAdjustment {
+				return beans.EmptyID(), beans.NewError(beans.EINVALID, fmt.Sprintf(
+					"Cleared balance of %s does not match statement balance of %s.",
+					cleared.String(),
+					params.Balance.String(),
+				))
+			}
+
+			// made reconciled, as it is only on the statement being reconciled
+			adjustment := beans.Transaction{
+				ID:        beans.NewID(),
+				AccountID: account.ID,
+				Amount:    difference,
+				Date:      params.Date,
+				Notes:     beans.NewTransactionNotes("Reconciliation balance adjustment"),
+				Status:    beans.TransactionReconciled,
+			}
+			if err := (&transactionContract{c.contract}).saveCreate(ctx, tx, auth, []beans.Transaction{adjustment}); err != nil {
+				return beans.EmptyID(), err
+			}
+			adjustmentID = adjustment.ID
+		}
+
+		toReconcile, err := c.ds().TransactionRepository().GetToReconcile(ctx, tx, account.ID, params.Date)
+		if err != nil {
+			return beans.EmptyID(), err
+		}
+		history := make([]beans.TransactionHistory, len(toReconcile))
+		for i, transaction := range toReconcile {
+			reconciled := transaction
+			reconciled.Status = beans.TransactionReconciled
+			history[i] = historyOfUpdate(auth, transaction, reconciled)
+		}
+
+		if err := c.ds().TransactionRepository().Reconcile(ctx, tx, account.ID, params.Date); err != nil {
+			return beans.EmptyID(), err
+		}
+		if err := c.ds().TransactionHistoryRepository().Create(ctx, tx, history); err != nil {
+			return beans.EmptyID(), err
+		}
+
+		return adjustmentID, nil
+	})
+}
+
+func (c *accountContract) getIncomeCategory(ctx context.Context, auth *beans.BudgetAuthContext) (beans.Category, error) {
+	groups, err := c.ds().CategoryRepository().GetGroupsForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return beans.Category{}, err
+	}
+
+	for _, group := range groups {
+		if !group.IsIncome {
+			continue
+		}
+
+		categories, err := c.ds().CategoryRepository().GetCategoriesForGroup(ctx, group.ID, auth.BudgetID())
+		if err != nil {
+			return beans.Category{}, err
+		}
+		if len(categories) > 0 {
+			return categories[0], nil
+		}
+	}
+
+	return beans.Category{}, errors.New("budget has no income category")
+}
+
+// Creates the payment category for a credit account. The budget's credit card
+// payments group is made the first time it is needed.
+func (c *accountContract) createPaymentCategory(ctx context.Context, tx beans.Tx, account beans.Account) (beans.ID, error) {
+	groups, err := c.ds().CategoryRepository().GetGroupsForBudget(ctx, account.BudgetID)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+
+	var group beans.CategoryGroup
+	for _, g := range groups {
+		if g.IsCreditCardPayments {
+			group = g
+			break
+		}
+	}
+
+	if group.ID.Empty() {
+		group = beans.CategoryGroup{
+			ID:                   beans.NewID(),
+			BudgetID:             account.BudgetID,
+			Name:                 "Credit Card Payments",
+			IsCreditCardPayments: true,
+			SortOrder:            nextGroupSortOrder(groups),
+		}
+		if err := c.ds().CategoryRepository().CreateGroup(ctx, tx, group); err != nil {
+			return beans.EmptyID(), err
+		}
+	}
+
+	siblings, err := c.ds().CategoryRepository().GetCategoriesForGroup(ctx, group.ID, account.BudgetID)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+
+	category := beans.Category{
+		ID:        beans.NewID(),
+		BudgetID:  account.BudgetID,
+		GroupID:   group.ID,
+		Name:      account.Name,
+		SortOrder: nextCategorySortOrder(siblings),
+	}
+	if err := c.ds().CategoryRepository().Create(ctx, tx, category); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	return category.ID, nil
+}
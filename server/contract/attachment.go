@@ -0,0 +1,125 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type attachmentContract struct{ contract }
+
+var _ beans.AttachmentContract = (*attachmentContract)(nil)
+
+func (c *attachmentContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, params beans.AttachmentParams) (beans.ID, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	if err := c.validateTransaction(ctx, auth, params.TransactionID); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	// read one byte past the limit to know if the file is too large
+	file, err := io.ReadAll(io.LimitReader(params.File, beans.MaxAttachmentSize+1))
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	mimeType, _, err := mime.ParseMediaType(http.DetectContentType(file))
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	if err := beans.ValidateAttachmentFile(int64(len(file)), mimeType); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	attachment := beans.Attachment{
+		ID:            beans.NewID(),
+		TransactionID: params.TransactionID,
+		FileName:      params.FileName,
+		MimeType:      mimeType,
+		Size:          int64(len(file)),
+	}
+
+	if err := c.blobStore.Put(ctx, attachment.ID.String(), bytes.NewReader(file)); err != nil {
+		return beans.EmptyID(), err
+	}
+	if err := c.ds().AttachmentRepository().Create(ctx, attachment); err != nil {
+		_ = c.blobStore.Delete(ctx, attachment.ID.String())
+		return beans.EmptyID(), err
+	}
+
+	return attachment.ID, nil
+}
+
+func (c *attachmentContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext, transactionID beans.ID) ([]beans.Attachment, error) {
+	if err := c.validateTransaction(ctx, auth, transactionID); err != nil {
+		return nil, err
+	}
+
+	return c.ds().AttachmentRepository().GetForTransaction(ctx, auth.BudgetID(), transactionID)
+}
+
+func (c *attachmentContract) Get(ctx context.Context, auth *beans.BudgetAuthContext, transactionID beans.ID, id beans.ID) (beans.Attachment, io.ReadCloser, error) {
+	attachment, err := c.get(ctx, auth, transactionID, id)
+	if err != nil {
+		return beans.Attachment{}, nil, err
+	}
+
+	file, err := c.blobStore.Get(ctx, attachment.ID.String())
+	if err != nil {
+		return beans.Attachment{}, nil, err
+	}
+
+	return attachment, file, nil
+}
+
+func (c *attachmentContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, transactionID beans.ID, id beans.ID) error {
+	attachment, err := c.get(ctx, auth, transactionID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.ds().AttachmentRepository().Delete(ctx, auth.BudgetID(), attachment.ID); err != nil {
+		return err
+	}
+
+	c.deleteAttachmentFiles(ctx, []beans.Attachment{attachment})
+	return nil
+}
+
+func (c *attachmentContract) get(ctx context.Context, auth *beans.BudgetAuthContext, transactionID beans.ID, id beans.ID) (beans.Attachment, error) {
+	attachment, err := c.ds().AttachmentRepository().Get(ctx, auth.BudgetID(), id)
+	if err != nil {
+		return beans.Attachment{}, err
+	}
+	if attachment.TransactionID != transactionID {
+		return beans.Attachment{}, beans.ErrorNotFound
+	}
+
+	return attachment, nil
+}
+
+// Files can only be attached to transactions, not split lines.
+func (c *attachmentContract) validateTransaction(ctx context.Context, auth *beans.BudgetAuthContext, transactionID beans.ID) error {
+	transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), transactionID)
+	if err != nil {
+		return err
+	}
+	if !transaction.SplitID.Empty() {
+		return beans.ErrorNotFound
+	}
+
+	return nil
+}
+
+// Deletes the files of attachments that are already deleted. The rows are
+// gone, so a file that fails to delete is only wasted space.
+func (c *contract) deleteAttachmentFiles(ctx context.Context, attachments []beans.Attachment) {
+	for _, attachment := range attachments {
+		_ = c.blobStore.Delete(ctx, attachment.ID.String())
+	}
+}
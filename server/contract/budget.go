@@ -43,8 +43,9 @@ func (c *budgetContract) Create(ctx context.Context, auth *beans.AuthContext, na
 		}
 
 		return beans.Budget{
-			ID:   budgetID,
-			Name: name,
+			ID:                   budgetID,
+			Name:                 name,
+			OverspendingRollover: beans.OverspendingCarryForward,
 		}, nil
 	})
 }
@@ -73,3 +74,19 @@ func (c *budgetContract) Get(ctx context.Context, auth *beans.AuthContext, id be
 func (c *budgetContract) GetAll(ctx context.Context, auth *beans.AuthContext) ([]beans.Budget, error) {
 	return c.ds().BudgetRepository().GetBudgetsForUser(ctx, auth.UserID())
 }
+
+func (c *budgetContract) Update(ctx context.Context, auth *beans.AuthContext, params beans.BudgetUpdateParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	budget, err := c.Get(ctx, auth, params.ID)
+	if err != nil {
+		return err
+	}
+
+	budget.Name = params.Name
+	budget.OverspendingRollover = params.OverspendingRollover
+
+	return c.ds().BudgetRepository().Update(ctx, budget)
+}
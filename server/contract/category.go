@@ -27,7 +27,7 @@ func (c *categoryContract) CreateCategory(ctx context.Context, auth *beans.Budge
 	}
 
 	err := beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
-		_, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, groupID, auth.BudgetID())
+		group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, groupID, auth.BudgetID())
 		if err != nil {
 			if errors.Is(err, beans.ErrorNotFound) {
 				return beans.NewError(beans.EINVALID, "Invalid Group ID.")
@@ -35,7 +35,18 @@ func (c *categoryContract) CreateCategory(ctx context.Context, auth *beans.Budge
 			return err
 		}
 
-		if err := c.ds().CategoryRepository().Create(ctx, nil, category); err != nil {
+		// payment categories are made along with their credit accounts
+		if group.IsCreditCardPayments {
+			return beans.NewError(beans.EINVALID, "Cannot add categories to the credit card payments group.")
+		}
+
+		siblings, err := c.ds().CategoryRepository().GetCategoriesForGroup(ctx, groupID, auth.BudgetID())
+		if err != nil {
+			return err
+		}
+		category.SortOrder = nextCategorySortOrder(siblings)
+
+		if err := c.ds().CategoryRepository().Create(ctx, tx, category); err != nil {
 			return err
 		}
 
@@ -75,10 +86,16 @@ func (c *categoryContract) CreateGroup(ctx context.Context, auth *beans.BudgetAu
 		return beans.CategoryGroup{}, err
 	}
 
+	groups, err := c.ds().CategoryRepository().GetGroupsForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return beans.CategoryGroup{}, err
+	}
+
 	group := beans.CategoryGroup{
-		ID:       beans.NewID(),
-		BudgetID: auth.BudgetID(),
-		Name:     name,
+		ID:        beans.NewID(),
+		BudgetID:  auth.BudgetID(),
+		Name:      name,
+		SortOrder: nextGroupSortOrder(groups),
 	}
 
 	if err := c.ds().CategoryRepository().CreateGroup(ctx, nil, group); err != nil {
@@ -100,16 +117,13 @@ func (c *categoryContract) GetAll(ctx context.Context, auth *beans.BudgetAuthCon
 	}
 
 	// group categories by group
-	categoriesByGroup := make(map[string][]beans.RelatedCategory)
+	categoriesByGroup := make(map[string][]beans.Category)
 	for _, group := range groups {
-		categoriesByGroup[group.ID.String()] = make([]beans.RelatedCategory, 0)
+		categoriesByGroup[group.ID.String()] = make([]beans.Category, 0)
 	}
 	for _, category := range categories {
 		groupID := category.GroupID.String()
-		categoriesByGroup[groupID] = append(categoriesByGroup[groupID], beans.RelatedCategory{
-			ID:   category.ID,
-			Name: category.Name,
-		})
+		categoriesByGroup[groupID] = append(categoriesByGroup[groupID], category)
 	}
 
 	// associate categories with their groups
@@ -135,17 +149,9 @@ func (c *categoryContract) GetGroup(ctx context.Context, auth *beans.BudgetAuthC
 		return beans.CategoryGroupWithCategories{}, err
 	}
 
-	relatedCategories := make([]beans.RelatedCategory, len(categories))
-	for i, category := range categories {
-		relatedCategories[i] = beans.RelatedCategory{
-			ID:   category.ID,
-			Name: category.Name,
-		}
-	}
-
 	return beans.CategoryGroupWithCategories{
 		CategoryGroup: group,
-		Categories:    relatedCategories,
+		Categories:    categories,
 	}, nil
 }
 
@@ -157,3 +163,240 @@ func (c *categoryContract) GetCategory(ctx context.Context, auth *beans.BudgetAu
 
 	return category, nil
 }
+
+func (c *categoryContract) UpdateCategory(ctx context.Context, auth *beans.BudgetAuthContext, params beans.CategoryUpdateParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	category, err := c.ds().CategoryRepository().GetSingleForBudget(ctx, params.ID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, category.GroupID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	if group.IsIncome && params.Hidden {
+		return beans.NewError(beans.EINVALID, "Cannot hide the income category.")
+	}
+
+	if params.GroupID != category.GroupID {
+		newGroup, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, params.GroupID, auth.BudgetID())
+		if err != nil {
+			if errors.Is(err, beans.ErrorNotFound) {
+				return beans.NewError(beans.EINVALID, "Invalid Group ID.")
+			}
+			return err
+		}
+
+		// income and payment categories are managed by the budget and accounts
+		if group.IsIncome || group.IsCreditCardPayments || newGroup.IsIncome || newGroup.IsCreditCardPayments {
+			return beans.NewError(beans.EINVALID, "Cannot move categories into or out of the income or credit card payments groups.")
+		}
+	}
+
+	category.GroupID = params.GroupID
+	category.Name = params.Name
+	category.SortOrder = params.SortOrder
+	category.Hidden = params.Hidden
+
+	return c.ds().CategoryRepository().Update(ctx, nil, category)
+}
+
+func (c *categoryContract) UpdateGroup(ctx context.Context, auth *beans.BudgetAuthContext, params beans.CategoryGroupUpdateParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, params.ID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	if group.IsIncome && params.Hidden {
+		return beans.NewError(beans.EINVALID, "Cannot hide the income group.")
+	}
+
+	group.Name = params.Name
+	group.SortOrder = params.SortOrder
+	group.Hidden = params.Hidden
+
+	return c.ds().CategoryRepository().UpdateGroup(ctx, nil, group)
+}
+
+func (c *categoryContract) DeleteCategory(ctx context.Context, auth *beans.BudgetAuthContext, params beans.CategoryDeleteParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	category, err := c.ds().CategoryRepository().GetSingleForBudget(ctx, params.ID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, category.GroupID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	if group.IsIncome {
+		return beans.NewError(beans.EINVALID, "Cannot delete the income category.")
+	}
+	if group.IsCreditCardPayments {
+		return beans.NewError(beans.EINVALID, "Cannot delete a credit card payment category.")
+	}
+
+	if params.ReplacementID == category.ID {
+		return beans.NewError(beans.EINVALID, "Cannot replace a category with itself.")
+	}
+
+	replacement, err := c.ds().CategoryRepository().GetSingleForBudget(ctx, params.ReplacementID, auth.BudgetID())
+	if err != nil {
+		if errors.Is(err, beans.ErrorNotFound) {
+			return beans.NewError(beans.EINVALID, "Invalid Replacement Category ID.")
+		}
+		return err
+	}
+
+	replacementGroup, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, replacement.GroupID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	if replacementGroup.IsIncome || replacementGroup.IsCreditCardPayments {
+		return beans.NewError(beans.EINVALID, "Cannot replace with an income or credit card payment category.")
+	}
+
+	history, err := c.historyOfReassign(ctx, auth, category.ID, replacement.ID)
+	if err != nil {
+		return err
+	}
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if err := c.ds().CategoryRepository().Reassign(ctx, tx, auth.BudgetID(), category.ID, replacement.ID); err != nil {
+			return err
+		}
+		if err := c.ds().TransactionHistoryRepository().Create(ctx, tx, history); err != nil {
+			return err
+		}
+
+		return c.ds().CategoryRepository().Delete(ctx, tx, auth.BudgetID(), category.ID)
+	})
+}
+
+func (c *categoryContract) DeleteGroup(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, id, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	if group.IsIncome {
+		return beans.NewError(beans.EINVALID, "Cannot delete the income group.")
+	}
+	if group.IsCreditCardPayments {
+		return beans.NewError(beans.EINVALID, "Cannot delete the credit card payments group.")
+	}
+
+	categories, err := c.ds().CategoryRepository().GetCategoriesForGroup(ctx, group.ID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	if len(categories) > 0 {
+		return beans.NewError(beans.EINVALID, "Group has categories. Move or delete them first.")
+	}
+
+	return c.ds().CategoryRepository().DeleteGroup(ctx, nil, auth.BudgetID(), group.ID)
+}
+
+func (c *categoryContract) SetGoal(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID, goal beans.Optional[beans.CategoryGoal]) error {
+	if g, ok := goal.Value(); ok {
+		if err := g.ValidateAll(); err != nil {
+			return err
+		}
+
+		// only target balance by date goals have a deadline
+		if g.Type != beans.GoalTargetBalanceByDate {
+			g.TargetMonth = beans.MonthDate{}
+			goal = beans.OptionalWrap(g)
+		}
+	}
+
+	category, err := c.ds().CategoryRepository().GetSingleForBudget(ctx, id, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+
+	group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, category.GroupID, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	if group.IsIncome {
+		return beans.NewError(beans.EINVALID, "Cannot set a goal on the income category.")
+	}
+
+	category.Goal = goal
+
+	return c.ds().CategoryRepository().Update(ctx, nil, category)
+}
+
+// New categories are placed after the other categories in their group.
+func nextCategorySortOrder(categories []beans.Category) int {
+	next := 0
+	for _, category := range categories {
+		next = max(next, category.SortOrder+1)
+	}
+	return next
+}
+
+// New groups are placed after the other groups in the budget.
+func nextGroupSortOrder(groups []beans.CategoryGroup) int {
+	next := 0
+	for _, group := range groups {
+		next = max(next, group.SortOrder+1)
+	}
+	return next
+}
+
+// Builds update history for the transactions and splits that will be moved
+// from one category to another.
+func (c *categoryContract) historyOfReassign(ctx context.Context, auth *beans.BudgetAuthContext, fromID beans.ID, toID beans.ID) ([]beans.TransactionHistory, error) {
+	transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{
+		TransactionFilter: beans.TransactionFilter{CategoryID: fromID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := []beans.TransactionHistory{}
+	for _, withRelations := range transactions {
+		if withRelations.Variant == beans.TransactionSplit {
+			splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), withRelations.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, split := range splits {
+				if split.Transaction.CategoryID != fromID {
+					continue
+				}
+
+				moved := split.Transaction
+				moved.CategoryID = toID
+				history = append(history, historyOfUpdate(auth, split.Transaction, moved))
+			}
+			continue
+		}
+
+		transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), withRelations.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		moved := transaction
+		moved.CategoryID = toID
+		history = append(history, historyOfUpdate(auth, transaction, moved))
+	}
+
+	return history, nil
+}
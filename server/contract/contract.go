@@ -8,6 +8,7 @@ import (
 type contract struct {
 	datasource        beans.DataSource
 	sessionRepository beans.SessionRepository
+	blobStore         beans.BlobStore
 	services          *service.All
 }
 
@@ -17,24 +18,34 @@ func (c *contract) ds() beans.DataSource {
 
 type Contracts struct {
 	Account     beans.AccountContract
+	Attachment  beans.AttachmentContract
 	Budget      beans.BudgetContract
 	Category    beans.CategoryContract
+	Import      beans.ImportContract
 	Month       beans.MonthContract
 	Payee       beans.PayeeContract
+	Rule        beans.RuleContract
+	Scheduled   beans.ScheduledTransactionContract
+	Tag         beans.TagContract
 	Transaction beans.TransactionContract
 	User        beans.UserContract
 }
 
-func NewContracts(datasource beans.DataSource, sessionRepository beans.SessionRepository) *Contracts {
+func NewContracts(datasource beans.DataSource, sessionRepository beans.SessionRepository, blobStore beans.BlobStore) *Contracts {
 	services := service.NewServices(datasource, sessionRepository)
-	contract := contract{datasource, sessionRepository, services}
+	contract := contract{datasource, sessionRepository, blobStore, services}
 
 	return &Contracts{
 		Account:     &accountContract{contract},
+		Attachment:  &attachmentContract{contract},
 		Budget:      &budgetContract{contract},
 		Category:    &categoryContract{contract},
+		Import:      &importContract{contract},
 		Month:       &monthContract{contract},
 		Payee:       &payeeContract{contract},
+		Rule:        &ruleContract{contract},
+		Scheduled:   &scheduledTransactionContract{contract},
+		Tag:         &tagContract{contract},
 		Transaction: &transactionContract{contract},
 		User:        &userContract{contract},
 	}
@@ -0,0 +1,486 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/statement"
+)
+
+type importContract struct{ contract }
+
+var _ beans.ImportContract = (*importContract)(nil)
+
+func (c *importContract) PreviewCSV(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportCSVParams) ([]beans.ImportPreviewRow, error) {
+	account, rows, err := c.readCSV(ctx, auth, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.preview(ctx, auth, account, rows)
+}
+
+func (c *importContract) ImportCSV(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportCSVParams) (beans.ImportResult, error) {
+	account, rows, err := c.readCSV(ctx, auth, params)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	preview, err := c.preview(ctx, auth, account, rows)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return c.save(ctx, auth, account, preview, c.makeTransactions(account, preview))
+}
+
+func (c *importContract) PreviewOFX(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportOFXParams) ([]beans.ImportPreviewRow, error) {
+	account, rows, err := c.readOFX(ctx, auth, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.preview(ctx, auth, account, rows)
+}
+
+func (c *importContract) ImportOFX(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportOFXParams) (beans.ImportResult, error) {
+	account, rows, err := c.readOFX(ctx, auth, params)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	preview, err := c.preview(ctx, auth, account, rows)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return c.save(ctx, auth, account, preview, c.makeTransactions(account, preview))
+}
+
+func (c *importContract) ImportQIF(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportQIFParams) (beans.ImportResult, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	account, err := c.getAccount(ctx, auth, params.AccountID)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	rows, err := statement.ReadQIF(strings.NewReader(params.File), params.DateFormat)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	preview, err := c.preview(ctx, auth, account, rows)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	transactions, err := c.makeQIFTransactions(ctx, auth, account, preview)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return c.save(ctx, auth, account, preview, transactions)
+}
+
+func (c *importContract) ExportQIF(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.ExportedFile, error) {
+	accounts, err := c.ds().AccountRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{
+		Sort: beans.TransactionSortDateAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[beans.ID][]beans.TransactionWithRelations)
+	for _, t := range transactions {
+		byAccount[t.Account.ID] = append(byAccount[t.Account.ID], t)
+	}
+
+	files := make([]beans.ExportedFile, len(accounts))
+	for i, account := range accounts {
+		// splits are read once for the whole account
+		splits, err := c.ds().TransactionRepository().GetSplitsForAccount(ctx, auth.BudgetID(), account.ID)
+		if err != nil {
+			return nil, err
+		}
+		splitsByTransaction := make(map[beans.ID][]beans.Split)
+		for _, split := range splits {
+			splitsByTransaction[split.SplitID] = append(splitsByTransaction[split.SplitID], split.Split)
+		}
+
+		qifTransactions := make([]statement.QIFTransaction, len(byAccount[account.ID]))
+		for j, t := range byAccount[account.ID] {
+			qifTransactions[j] = statement.QIFTransaction{TransactionWithRelations: t}
+			if t.Variant == beans.TransactionSplit {
+				qifTransactions[j].Splits = splitsByTransaction[t.ID]
+			}
+		}
+
+		var file strings.Builder
+		if err := statement.WriteQIF(&file, account.Type, qifTransactions); err != nil {
+			return nil, err
+		}
+
+		files[i] = beans.ExportedFile{Account: account.ToRelated(), File: file.String()}
+	}
+
+	return files, nil
+}
+
+func (c *importContract) readCSV(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportCSVParams) (beans.Account, []beans.ImportRow, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	account, err := c.getAccount(ctx, auth, params.AccountID)
+	if err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	rows, err := statement.ReadCSV(strings.NewReader(params.File), params.CSVFormat)
+	if err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	return account, rows, nil
+}
+
+func (c *importContract) readOFX(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ImportOFXParams) (beans.Account, []beans.ImportRow, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	account, err := c.getAccount(ctx, auth, params.AccountID)
+	if err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	rows, err := statement.ReadOFX(strings.NewReader(params.File))
+	if err != nil {
+		return beans.Account{}, nil, err
+	}
+
+	return account, rows, nil
+}
+
+func (c *importContract) getAccount(ctx context.Context, auth *beans.BudgetAuthContext, accountID beans.ID) (beans.Account, error) {
+	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), accountID)
+	if err != nil {
+		if errors.Is(err, beans.ErrorNotFound) {
+			return beans.Account{}, beans.NewError(beans.EINVALID, "Invalid Account ID")
+		}
+
+		return beans.Account{}, err
+	}
+
+	if account.Closed {
+		return beans.Account{}, errorAccountClosed
+	}
+
+	return account, nil
+}
+
+// Matches rows to existing payees and flags rows that look like transactions
+// already on the account. Rows with an import ID are only compared by that ID,
+// since banks can report several identical transactions on the same day.
+func (c *importContract) preview(ctx context.Context, auth *beans.BudgetAuthContext, account beans.Account, rows []beans.ImportRow) ([]beans.ImportPreviewRow, error) {
+	preview := make([]beans.ImportPreviewRow, len(rows))
+	if len(rows) == 0 {
+		return preview, nil
+	}
+
+	payees, err := c.ds().PayeeRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+	payeesByName := make(map[string]beans.Payee)
+	payeeNames := make(map[beans.ID]string)
+	for _, payee := range payees {
+		payeesByName[payeeKey(payee.Name)] = payee
+		payeeNames[payee.ID] = payeeKey(payee.Name)
+	}
+
+	// load transactions that could be duplicates
+	begin, end := rows[0].Date, rows[0].Date
+	for _, row := range rows {
+		if row.Date.Before(begin.Time) {
+			begin = row.Date
+		}
+		if row.Date.After(end.Time) {
+			end = row.Date
+		}
+	}
+	existing, err := c.ds().TransactionRepository().GetForAccountBetween(ctx, auth.BudgetID(), account.ID, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	existingCount := make(map[duplicateKey]int)
+	for _, t := range existing {
+		// rules may have changed the payee of an imported transaction
+		payeeName := payeeNames[t.PayeeID]
+		if !t.ImportPayee.Empty() {
+			payeeName = payeeKey(beans.Name(t.ImportPayee.String()))
+		}
+		existingCount[newDuplicateKey(t.Date, t.Amount, payeeName)]++
+	}
+
+	importIDs, err := c.ds().TransactionRepository().GetImportIDs(ctx, auth.BudgetID(), account.ID)
+	if err != nil {
+		return nil, err
+	}
+	seenImportIDs := make(map[string]bool)
+	for _, id := range importIDs {
+		seenImportIDs[id] = true
+	}
+
+	for i, row := range rows {
+		preview[i] = beans.ImportPreviewRow{ImportRow: row}
+
+		// transfers cannot have a payee
+		payeeName := ""
+		if row.TransferAccountName == "" {
+			payeeName = payeeKey(row.PayeeName)
+			if payee, ok := payeesByName[payeeName]; ok {
+				preview[i].Payee = beans.OptionalWrap(beans.RelatedPayee{ID: payee.ID, Name: payee.Name})
+			}
+		}
+
+		if !row.ImportID.Empty() {
+			// also catches an ID repeated within the file
+			preview[i].Duplicate = seenImportIDs[row.ImportID.String()]
+			seenImportIDs[row.ImportID.String()] = true
+			continue
+		}
+
+		// each existing transaction can only match one row. Payees are
+		// compared by name, so a payee not made yet matches nothing.
+		key := newDuplicateKey(row.Date, row.Amount, payeeName)
+		if existingCount[key] > 0 {
+			existingCount[key]--
+			preview[i].Duplicate = true
+		}
+	}
+
+	return preview, nil
+}
+
+// Builds a single transaction for each row that is not a duplicate. Rows come
+// from a bank statement, so the transactions have cleared.
+func (c *importContract) makeTransactions(account beans.Account, preview []beans.ImportPreviewRow) [][]beans.Transaction {
+	transactions := make([][]beans.Transaction, len(preview))
+	for i, row := range preview {
+		if row.Duplicate {
+			continue
+		}
+
+		transactions[i] = []beans.Transaction{{
+			ID:        beans.NewID(),
+			AccountID: account.ID,
+			Amount:    row.Amount,
+			Date:      row.Date,
+			Notes:     row.Notes,
+			Status:    beans.TransactionCleared,
+			ImportID:  row.ImportID,
+		}}
+	}
+
+	return transactions
+}
+
+// Saves the transactions made for each row that is not a duplicate, creating
+// any new payees. Rules run on each row, matching on the payee name in the
+// statement. The payee is set on every transaction made for the row, except
+// for transfers.
+func (c *importContract) save(ctx context.Context, auth *beans.BudgetAuthContext, account beans.Account, preview []beans.ImportPreviewRow, transactions [][]beans.Transaction) (beans.ImportResult, error) {
+	rules, err := c.ds().RuleRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return beans.ExecTx(ctx, c.ds().TxManager(), func(tx beans.Tx) (beans.ImportResult, error) {
+		result := beans.ImportResult{TransactionIDs: []beans.ID{}}
+		newPayees := make(map[string]beans.Payee)
+		toCreate := []beans.Transaction{}
+
+		for i, row := range preview {
+			if row.Duplicate {
+				result.Duplicates++
+				continue
+			}
+
+			rowTransactions := transactions[i]
+			isTransfer := !rowTransactions[0].TransferID.Empty()
+
+			if payee, ok := row.Payee.Value(); ok {
+				rowTransactions[0].PayeeID = payee.ID
+			}
+			if !isTransfer {
+				rowTransactions[0].ImportPayee = beans.NewNullString(string(row.PayeeName))
+			}
+			applyRulesToNew(rules, account, row.PayeeName, rowTransactions)
+
+			payeeID := rowTransactions[0].PayeeID
+			if payeeID.Empty() && !isTransfer && !beans.ValidatableString(row.PayeeName).Empty() {
+				payee, ok := newPayees[payeeKey(row.PayeeName)]
+				if !ok {
+					payee = beans.Payee{
+						ID:       beans.NewID(),
+						BudgetID: auth.BudgetID(),
+						Name:     beans.Name(strings.TrimSpace(string(row.PayeeName))),
+					}
+					if err := c.ds().PayeeRepository().Create(ctx, tx, payee); err != nil {
+						return beans.ImportResult{}, err
+					}
+					newPayees[payeeKey(row.PayeeName)] = payee
+				}
+				payeeID = payee.ID
+			}
+
+			for _, transaction := range rowTransactions {
+				if transaction.TransferID.Empty() {
+					transaction.PayeeID = payeeID
+				}
+				toCreate = append(toCreate, transaction)
+			}
+			result.TransactionIDs = append(result.TransactionIDs, rowTransactions[0].ID)
+		}
+
+		if err := (&transactionContract{c.contract}).saveCreate(ctx, tx, auth, toCreate); err != nil {
+			return beans.ImportResult{}, err
+		}
+
+		return result, nil
+	})
+}
+
+// Matches category and transfer account names and builds the transactions
+// for each row the same way as creating them by hand.
+func (c *importContract) makeQIFTransactions(ctx context.Context, auth *beans.BudgetAuthContext, account beans.Account, preview []beans.ImportPreviewRow) ([][]beans.Transaction, error) {
+	categories, err := c.ds().CategoryRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+	categoriesByName := make(map[string]beans.Category)
+	for _, category := range categories {
+		categoriesByName[strings.ToLower(string(category.Name))] = category
+	}
+
+	accounts, err := c.ds().AccountRepository().GetTransactable(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+	accountsByName := make(map[string]beans.Account)
+	for _, account := range accounts {
+		accountsByName[strings.ToLower(string(account.Name))] = account
+	}
+
+	// QIF categories may include a parent, as in Parent:Category
+	findCategory := func(name beans.Name) (beans.ID, error) {
+		if name == "" {
+			return beans.EmptyID(), nil
+		}
+
+		key := strings.ToLower(string(name))
+		if category, ok := categoriesByName[key]; ok {
+			return category.ID, nil
+		}
+		if i := strings.LastIndex(key, ":"); i >= 0 {
+			if category, ok := categoriesByName[strings.TrimSpace(key[i+1:])]; ok {
+				return category.ID, nil
+			}
+		}
+
+		return beans.EmptyID(), beans.NewError(beans.EINVALID, fmt.Sprintf("Category %s does not exist.", name))
+	}
+
+	transactionContract := &transactionContract{c.contract}
+	transactions := make([][]beans.Transaction, len(preview))
+	for i, row := range preview {
+		if row.Duplicate {
+			continue
+		}
+
+		params := beans.TransactionCreateParams{
+			TransactionParams: beans.TransactionParams{
+				AccountID: account.ID,
+				Amount:    row.Amount,
+				Date:      row.Date,
+				Notes:     row.Notes,
+				Status:    beans.TransactionCleared,
+			},
+		}
+
+		// off-budget accounts do not track categories
+		if !account.OffBudget {
+			if params.CategoryID, err = findCategory(row.CategoryName); err != nil {
+				return nil, importRowError(i+1, err)
+			}
+
+			for _, split := range row.Splits {
+				categoryID, err := findCategory(split.CategoryName)
+				if err != nil {
+					return nil, importRowError(i+1, err)
+				}
+
+				params.Splits = append(params.Splits, beans.SplitParams{
+					Amount:     split.Amount,
+					CategoryID: categoryID,
+					Notes:      split.Notes,
+				})
+			}
+		}
+
+		// a transfer to the account itself marks the opening balance
+		if row.TransferAccountName != "" && !strings.EqualFold(string(row.TransferAccountName), string(account.Name)) {
+			transferAccount, ok := accountsByName[strings.ToLower(string(row.TransferAccountName))]
+			if !ok {
+				return nil, importRowError(i+1, beans.NewError(beans.EINVALID, fmt.Sprintf("Account %s does not exist.", row.TransferAccountName)))
+			}
+			params.TransferAccountID = transferAccount.ID
+		}
+
+		transactions[i], err = transactionContract.makeTransactions(ctx, auth, params)
+		if err != nil {
+			return nil, importRowError(i+1, err)
+		}
+	}
+
+	return transactions, nil
+}
+
+// helpers
+
+type duplicateKey struct {
+	date   string
+	amount string
+	payee  string
+}
+
+func newDuplicateKey(date beans.Date, amount beans.Amount, payee string) duplicateKey {
+	amount = amount.Normalize()
+	return duplicateKey{date: date.String(), amount: amount.String(), payee: payee}
+}
+
+func payeeKey(name beans.Name) string {
+	return strings.ToLower(strings.TrimSpace(string(name)))
+}
+
+func importRowError(n int, err error) error {
+	var beansError beans.Error
+	if errors.As(err, &beansError) {
+		code, msg := beansError.BeansError()
+		return beans.NewError(code, fmt.Sprintf("Transaction %d: %s", n, msg))
+	}
+
+	return err
+}
@@ -16,7 +16,7 @@ func TestContracts(t *testing.T) {
 	t.Cleanup(done)
 
 	sessionRepository := inmem.NewSessionRepository()
-	contracts := contract.NewContracts(ds, sessionRepository)
+	contracts := contract.NewContracts(ds, sessionRepository, inmem.NewBlobStore())
 	services := service.NewServices(ds, sessionRepository)
 	adapter := contractadapter.New(contracts, services)
 
@@ -2,6 +2,7 @@ package contract
 
 import (
 	"context"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 )
@@ -26,6 +27,64 @@ func (c *monthContract) GetOrCreate(ctx context.Context, auth *beans.BudgetAuthC
 		return beans.MonthWithDetails{}, err
 	}
 
+	return c.getDetails(ctx, auth, month, pastMonth.Carryover)
+}
+
+func (c *monthContract) GetRange(ctx context.Context, auth *beans.BudgetAuthContext, params beans.MonthRangeParams) ([]beans.MonthWithDetails, error) {
+	if err := params.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.ds().MonthRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+	existingByDate := make(map[string]beans.Month, len(existing))
+	for _, month := range existing {
+		existingByDate[month.Date.String()] = month
+	}
+
+	current := beans.NewMonthDate(beans.NewDate(time.Now()))
+	months := []beans.Month{}
+	for date := params.From; !date.Time().After(params.To.Time()); date = date.Next() {
+		month := beans.Month{BudgetID: auth.BudgetID(), Date: date, Carryover: beans.NewAmount(0, 0)}
+
+		// empty future months are left uncreated unless asked for
+		_, exists := existingByDate[date.String()]
+		if exists || params.CreateFuture || !date.Time().After(current.Time()) {
+			if month, err = c.createMonth(ctx, auth, date); err != nil {
+				return nil, err
+			}
+		}
+
+		months = append(months, month)
+	}
+
+	// build every snapshot through the range at once, so each month only
+	// reads its own
+	if _, err := c.services.MonthCategory.GetSnapshot(ctx, auth.BudgetID(), params.To); err != nil {
+		return nil, err
+	}
+
+	pastCarryover := existingByDate[params.From.Previous().String()].Carryover.OrZero()
+	res := make([]beans.MonthWithDetails, len(months))
+	for i, month := range months {
+		if res[i], err = c.getDetails(ctx, auth, month, pastCarryover); err != nil {
+			return nil, err
+		}
+		pastCarryover = month.Carryover
+	}
+
+	return res, nil
+}
+
+// Gets the categories and budgetable amount of the month.
+func (c *monthContract) getDetails(ctx context.Context, auth *beans.BudgetAuthContext, month beans.Month, pastCarryover beans.Amount) (beans.MonthWithDetails, error) {
+	carriedIn, err := c.services.MonthCategory.GetCarriedIn(ctx, month)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
 	categories, err := c.services.MonthCategory.GetForMonth(ctx, month)
 	if err != nil {
 		return beans.MonthWithDetails{}, err
@@ -43,21 +102,34 @@ func (c *monthContract) GetOrCreate(ctx context.Context, auth *beans.BudgetAuthC
 
 	available, err := beans.Arithmetic.Add(
 		income,
-		pastMonth.Carryover,
+		pastCarryover,
 		beans.Arithmetic.Negate(month.Carryover),
 		beans.Arithmetic.Negate(assignedInMonth),
+		beans.Arithmetic.Negate(carriedIn.Overspent),
 	)
 	if err != nil {
 		return beans.MonthWithDetails{}, err
 	}
 
+	underfunded := beans.NewAmount(0, 0)
+	for _, category := range categories {
+		if progress, ok := category.Goal.Value(); ok {
+			underfunded, err = beans.Arithmetic.Add(underfunded, progress.Underfunded)
+			if err != nil {
+				return beans.MonthWithDetails{}, err
+			}
+		}
+	}
+
 	return beans.MonthWithDetails{
 		Month: month,
 
-		CarriedOver: pastMonth.Carryover,
+		CarriedOver: pastCarryover,
 		Income:      income,
 		Assigned:    assignedInMonth,
 		Budgetable:  available,
+		Underfunded: underfunded.Normalize(),
+		Overspent:   carriedIn.Overspent.Normalize(),
 		Categories:  categories,
 	}, nil
 }
@@ -124,5 +196,239 @@ func (c *monthContract) SetCategoryAmount(ctx context.Context, auth *beans.Budge
 
 	monthCategory.Amount = amount.OrZero()
 
-	return c.ds().MonthCategoryRepository().UpdateAmount(ctx, monthCategory)
+	return c.ds().MonthCategoryRepository().UpdateAmount(ctx, nil, monthCategory)
+}
+
+func (c *monthContract) MoveMoney(ctx context.Context, auth *beans.BudgetAuthContext, params beans.MoveMoneyParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	month, err := c.ds().MonthRepository().Get(ctx, auth.BudgetID(), params.MonthID)
+	if err != nil {
+		return err
+	}
+
+	for _, categoryID := range []beans.ID{params.FromCategoryID, params.ToCategoryID} {
+		if categoryID.Empty() {
+			continue
+		}
+
+		category, err := c.ds().CategoryRepository().GetSingleForBudget(ctx, categoryID, auth.BudgetID())
+		if err != nil {
+			return err
+		}
+		group, err := c.ds().CategoryRepository().GetCategoryGroup(ctx, category.GroupID, auth.BudgetID())
+		if err != nil {
+			return err
+		}
+		if group.IsIncome {
+			return beans.NewError(beans.EINVALID, "Cannot move money to or from the income category.")
+		}
+	}
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if !params.FromCategoryID.Empty() {
+			if err := c.addAssigned(ctx, tx, month, params.FromCategoryID, beans.Arithmetic.Negate(params.Amount)); err != nil {
+				return err
+			}
+		}
+		if !params.ToCategoryID.Empty() {
+			if err := c.addAssigned(ctx, tx, month, params.ToCategoryID, params.Amount); err != nil {
+				return err
+			}
+		}
+
+		return c.ds().MonthCategoryRepository().CreateMovement(ctx, tx, beans.MoneyMovement{
+			ID:             beans.NewID(),
+			MonthID:        month.ID,
+			FromCategoryID: params.FromCategoryID,
+			ToCategoryID:   params.ToCategoryID,
+			Amount:         params.Amount,
+			CreatedAt:      time.Now(),
+		})
+	})
+}
+
+func (c *monthContract) addAssigned(ctx context.Context, tx beans.Tx, month beans.Month, categoryID beans.ID, amount beans.Amount) error {
+	monthCategory, err := c.ds().MonthCategoryRepository().GetOrCreate(ctx, tx, month, categoryID)
+	if err != nil {
+		return err
+	}
+
+	monthCategory.Amount, err = beans.Arithmetic.Add(monthCategory.Amount, amount)
+	if err != nil {
+		return err
+	}
+
+	return c.ds().MonthCategoryRepository().UpdateAmount(ctx, tx, monthCategory)
+}
+
+func (c *monthContract) GetMovements(ctx context.Context, auth *beans.BudgetAuthContext, monthID beans.ID) ([]beans.MoneyMovement, error) {
+	month, err := c.ds().MonthRepository().Get(ctx, auth.BudgetID(), monthID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ds().MonthCategoryRepository().GetMovementsForMonth(ctx, month)
+}
+
+func (c *monthContract) CheckSnapshots(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.MonthSnapshotMismatch, error) {
+	return c.services.MonthCategory.CheckSnapshots(ctx, auth.BudgetID())
+}
+
+func (c *monthContract) QuickBudget(ctx context.Context, auth *beans.BudgetAuthContext, params beans.QuickBudgetParams) (beans.MonthWithDetails, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	month, err := c.ds().MonthRepository().Get(ctx, auth.BudgetID(), params.MonthID)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	categoryIDs, err := c.quickBudgetCategories(ctx, auth, params.CategoryIDs)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	amounts, err := c.quickBudgetAmounts(ctx, month, params)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	err = beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		for _, categoryID := range categoryIDs {
+			monthCategory, err := c.ds().MonthCategoryRepository().GetOrCreate(ctx, tx, month, categoryID)
+			if err != nil {
+				return err
+			}
+
+			monthCategory.Amount = amounts[categoryID].OrZero()
+			if err := c.ds().MonthCategoryRepository().UpdateAmount(ctx, tx, monthCategory); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	return c.GetOrCreate(ctx, auth, month.Date)
+}
+
+// Gets the categories to quick budget. When none are chosen, every visible
+// category outside of the income group is used.
+func (c *monthContract) quickBudgetCategories(ctx context.Context, auth *beans.BudgetAuthContext, chosen []beans.ID) ([]beans.ID, error) {
+	groups, err := c.ds().CategoryRepository().GetGroupsForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+	groupsByID := make(map[beans.ID]beans.CategoryGroup, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	categories, err := c.ds().CategoryRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chosen) == 0 {
+		ids := []beans.ID{}
+		for _, category := range categories {
+			group := groupsByID[category.GroupID]
+			if !group.IsIncome && !group.Hidden && !category.Hidden {
+				ids = append(ids, category.ID)
+			}
+		}
+		return ids, nil
+	}
+
+	categoriesByID := make(map[beans.ID]beans.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+
+	for _, id := range chosen {
+		category, ok := categoriesByID[id]
+		if !ok {
+			return nil, beans.NewError(beans.EINVALID, "Invalid Category ID.")
+		}
+		if groupsByID[category.GroupID].IsIncome {
+			return nil, beans.NewError(beans.EINVALID, "Cannot assign to the income category.")
+		}
+	}
+
+	return chosen, nil
+}
+
+// Gets the amount to assign to each category. Categories that are missing
+// are assigned zero.
+func (c *monthContract) quickBudgetAmounts(ctx context.Context, month beans.Month, params beans.QuickBudgetParams) (map[beans.ID]beans.Amount, error) {
+	lastMonth := month.Date.Previous()
+
+	switch params.Type {
+	case beans.QuickBudgetAssignedLastMonth:
+		assignedBefore, err := c.ds().MonthCategoryRepository().GetAssignedByCategory(ctx, month.BudgetID, lastMonth.FirstDay())
+		if err != nil {
+			return nil, err
+		}
+		assignedThrough, err := c.ds().MonthCategoryRepository().GetAssignedByCategory(ctx, month.BudgetID, month.Date.FirstDay())
+		if err != nil {
+			return nil, err
+		}
+
+		amounts := make(map[beans.ID]beans.Amount, len(assignedThrough))
+		for categoryID, assigned := range assignedThrough {
+			amount, err := beans.Arithmetic.Add(assigned, beans.Arithmetic.Negate(assignedBefore[categoryID].OrZero()))
+			if err != nil {
+				return nil, err
+			}
+			amounts[categoryID] = amount
+		}
+		return amounts, nil
+
+	case beans.QuickBudgetSpentLastMonth:
+		return c.averageSpent(ctx, month, 1)
+
+	case beans.QuickBudgetAverageSpent:
+		return c.averageSpent(ctx, month, params.Months)
+	}
+
+	return map[beans.ID]beans.Amount{}, nil
+}
+
+// Gets the average spent by category over the months before the month,
+// rounded up to the cent. Categories that took in more than they spent have
+// spent nothing.
+func (c *monthContract) averageSpent(ctx context.Context, month beans.Month, months int) (map[beans.ID]beans.Amount, error) {
+	from := month.Date
+	for range months {
+		from = from.Previous()
+	}
+
+	activity, err := c.services.MonthCategory.GetActivityByCategory(ctx, month.BudgetID, from.FirstDay(), month.Date.Previous().LastDay())
+	if err != nil {
+		return nil, err
+	}
+
+	zero := beans.NewAmount(0, 0)
+	amounts := make(map[beans.ID]beans.Amount, len(activity))
+	for categoryID, amount := range activity {
+		spent := beans.Arithmetic.Negate(amount)
+		if spent.Compare(zero) <= 0 {
+			continue
+		}
+
+		average, err := beans.Arithmetic.DivideCeil(spent, int64(months))
+		if err != nil {
+			return nil, err
+		}
+		amounts[categoryID] = average
+	}
+
+	return amounts, nil
 }
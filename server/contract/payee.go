@@ -23,7 +23,7 @@ func (c *payeeContract) CreatePayee(ctx context.Context, auth *beans.BudgetAuthC
 		Name:     name,
 	}
 
-	err := c.ds().PayeeRepository().Create(ctx, payee)
+	err := c.ds().PayeeRepository().Create(ctx, nil, payee)
 	if err != nil {
 		return beans.EmptyID(), err
 	}
@@ -0,0 +1,251 @@
+package contract
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type ruleContract struct{ contract }
+
+var _ beans.RuleContract = (*ruleContract)(nil)
+
+func (c *ruleContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, params beans.RuleParams) (beans.ID, error) {
+	if err := c.validateParams(ctx, auth, params); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	rule := beans.Rule{
+		ID:             beans.NewID(),
+		BudgetID:       auth.BudgetID(),
+		Name:           params.Name,
+		RuleConditions: params.RuleConditions,
+		RuleActions:    params.RuleActions,
+	}
+	if err := c.ds().RuleRepository().Create(ctx, rule); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	return rule.ID, nil
+}
+
+func (c *ruleContract) Update(ctx context.Context, auth *beans.BudgetAuthContext, params beans.RuleUpdateParams) error {
+	if err := beans.ValidateFields(beans.Field("Rule ID", beans.Required(params.ID))); err != nil {
+		return err
+	}
+
+	rule, err := c.ds().RuleRepository().Get(ctx, auth.BudgetID(), params.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.validateParams(ctx, auth, params.RuleParams); err != nil {
+		return err
+	}
+
+	rule.Name = params.Name
+	rule.RuleConditions = params.RuleConditions
+	rule.RuleActions = params.RuleActions
+
+	return c.ds().RuleRepository().Update(ctx, rule)
+}
+
+func (c *ruleContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	return c.ds().RuleRepository().Delete(ctx, auth.BudgetID(), id)
+}
+
+func (c *ruleContract) Get(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) (beans.Rule, error) {
+	return c.ds().RuleRepository().Get(ctx, auth.BudgetID(), id)
+}
+
+func (c *ruleContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.Rule, error) {
+	return c.ds().RuleRepository().GetForBudget(ctx, auth.BudgetID())
+}
+
+func (c *ruleContract) Run(ctx context.Context, auth *beans.BudgetAuthContext, dryRun bool) ([]beans.RuleChange, error) {
+	rules, err := c.ds().RuleRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), beans.TransactionListParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []beans.RuleChange{}
+	for _, t := range transactions {
+		payee, _ := t.Payee.Value()
+		category, _ := t.Category.Value()
+		subject := beans.RuleSubject{
+			PayeeName:     payee.Name,
+			Amount:        t.Amount,
+			AllowPayee:    t.TransferAccount.Empty(),
+			AllowCategory: t.TransferAccount.Empty() && t.Variant == beans.TransactionStandard,
+			PayeeID:       payee.ID,
+			CategoryID:    category.ID,
+			Notes:         t.Notes,
+		}
+
+		result := beans.ApplyRules(rules, subject)
+		if result.PayeeID != subject.PayeeID || result.CategoryID != subject.CategoryID || result.Notes != subject.Notes {
+			changes = append(changes, beans.RuleChange{
+				Transaction: t,
+				PayeeID:     result.PayeeID,
+				CategoryID:  result.CategoryID,
+				Notes:       result.Notes,
+			})
+		}
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	// splits share the payee and transfers share the notes
+	updates := []beans.Transaction{}
+	history := []beans.TransactionHistory{}
+	update := func(original beans.Transaction, updated beans.Transaction) {
+		updates = append(updates, updated)
+		if !original.HistoryValues().Equal(updated.HistoryValues()) {
+			history = append(history, historyOfUpdate(auth, original, updated))
+		}
+	}
+	for _, change := range changes {
+		original, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), change.Transaction.ID)
+		if err != nil {
+			return nil, err
+		}
+		transaction := original
+		transaction.PayeeID = change.PayeeID
+		transaction.CategoryID = change.CategoryID
+		transaction.Notes = change.Notes
+		update(original, transaction)
+
+		if transaction.IsSplit {
+			splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), transaction.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, split := range splits {
+				updated := split.Transaction
+				updated.PayeeID = change.PayeeID
+				update(split.Transaction, updated)
+			}
+		}
+
+		if !transaction.TransferID.Empty() {
+			transfer, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), transaction.TransferID)
+			if err != nil {
+				return nil, err
+			}
+			updated := transfer
+			updated.Notes = change.Notes
+			update(transfer, updated)
+		}
+	}
+
+	err = beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if err := c.ds().TransactionRepository().Update(ctx, tx, updates); err != nil {
+			return err
+		}
+		return c.ds().TransactionHistoryRepository().Create(ctx, tx, history)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (c *ruleContract) validateParams(ctx context.Context, auth *beans.BudgetAuthContext, params beans.RuleParams) error {
+	if err := params.ValidateAll(); err != nil {
+		return err
+	}
+
+	if !params.PayeeID.Empty() {
+		if _, err := c.ds().PayeeRepository().Get(ctx, auth.BudgetID(), params.PayeeID); err != nil {
+			if errors.Is(err, beans.ErrorNotFound) {
+				return beans.NewError(beans.EINVALID, "Invalid Payee ID")
+			}
+
+			return err
+		}
+	}
+
+	return (&transactionContract{c.contract}).validateCategory(ctx, auth, params.CategoryID)
+}
+
+// Runs the budget's rules on new transactions. Each entry is made the same
+// way as by makeTransactions, and rules match on the payee of its first
+// transaction.
+func (c *contract) runRulesOnNew(ctx context.Context, auth *beans.BudgetAuthContext, transactions [][]beans.Transaction) error {
+	rules, err := c.ds().RuleRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	payees, err := c.ds().PayeeRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	payeeNames := make(map[beans.ID]beans.Name)
+	for _, payee := range payees {
+		payeeNames[payee.ID] = payee.Name
+	}
+
+	accounts := make(map[beans.ID]beans.Account)
+	for _, entry := range transactions {
+		if len(entry) == 0 {
+			continue
+		}
+
+		account, ok := accounts[entry[0].AccountID]
+		if !ok {
+			account, err = c.ds().AccountRepository().Get(ctx, auth.BudgetID(), entry[0].AccountID)
+			if err != nil {
+				return err
+			}
+			accounts[account.ID] = account
+		}
+
+		applyRulesToNew(rules, account, payeeNames[entry[0].PayeeID], entry)
+	}
+
+	return nil
+}
+
+// Runs the rules on a new transaction, the first of the transactions, and
+// copies the changes to its splits and transfer.
+func applyRulesToNew(rules []beans.Rule, account beans.Account, payeeName beans.Name, transactions []beans.Transaction) {
+	if len(rules) == 0 {
+		return
+	}
+
+	t := &transactions[0]
+	isTransfer := !t.TransferID.Empty()
+	result := beans.ApplyRules(rules, beans.RuleSubject{
+		PayeeName:     payeeName,
+		Amount:        t.Amount,
+		AllowPayee:    !isTransfer,
+		AllowCategory: !isTransfer && !t.IsSplit && !account.OffBudget,
+		PayeeID:       t.PayeeID,
+		CategoryID:    t.CategoryID,
+		Notes:         t.Notes,
+	})
+
+	t.PayeeID = result.PayeeID
+	t.CategoryID = result.CategoryID
+	t.Notes = result.Notes
+
+	for i := range transactions[1:] {
+		other := &transactions[i+1]
+		if other.SplitID == t.ID {
+			other.PayeeID = t.PayeeID
+		}
+		if other.TransferID == t.ID {
+			other.Notes = t.Notes
+		}
+	}
+}
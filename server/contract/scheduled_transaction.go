@@ -0,0 +1,202 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type scheduledTransactionContract struct{ contract }
+
+var _ beans.ScheduledTransactionContract = (*scheduledTransactionContract)(nil)
+
+func (c *scheduledTransactionContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ScheduledTransactionParams) (beans.ID, error) {
+	scheduled, err := c.makeScheduledTransaction(ctx, auth, params)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	scheduled.ID = beans.NewID()
+
+	if err := c.ds().ScheduledTransactionRepository().Create(ctx, scheduled); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	return scheduled.ID, nil
+}
+
+func (c *scheduledTransactionContract) Update(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ScheduledTransactionUpdateParams) error {
+	if err := beans.ValidateFields(beans.Field("Scheduled transaction ID", beans.Required(params.ID))); err != nil {
+		return err
+	}
+
+	existing, err := c.ds().ScheduledTransactionRepository().Get(ctx, auth.BudgetID(), params.ID)
+	if err != nil {
+		return err
+	}
+
+	scheduled, err := c.makeScheduledTransaction(ctx, auth, params.ScheduledTransactionParams)
+	if err != nil {
+		return err
+	}
+	scheduled.ID = existing.ID
+
+	// occurrences already posted are never posted again
+	scheduled.NextDate = existing.NextDate
+	if scheduled.Rule.Frequency != existing.Rule.Frequency ||
+		scheduled.Rule.Interval != existing.Rule.Interval ||
+		!scheduled.Rule.Start.Equal(existing.Rule.Start.Time) {
+		from := scheduled.Rule.Start
+		if existing.NextDate.After(from.Time) {
+			from = existing.NextDate
+		}
+		scheduled.NextDate = scheduled.Rule.After(from.Previous())
+	}
+
+	return c.ds().ScheduledTransactionRepository().Update(ctx, nil, scheduled)
+}
+
+func (c *scheduledTransactionContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	return c.ds().ScheduledTransactionRepository().Delete(ctx, auth.BudgetID(), id)
+}
+
+func (c *scheduledTransactionContract) Get(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) (beans.ScheduledTransaction, error) {
+	return c.ds().ScheduledTransactionRepository().Get(ctx, auth.BudgetID(), id)
+}
+
+func (c *scheduledTransactionContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.ScheduledTransaction, error) {
+	return c.ds().ScheduledTransactionRepository().GetForBudget(ctx, auth.BudgetID())
+}
+
+func (c *scheduledTransactionContract) PostDue(ctx context.Context, auth *beans.BudgetAuthContext, date beans.Date) (beans.PostDueResult, error) {
+	today := beans.NewDate(time.Now())
+	if date.Empty() {
+		date = today
+	}
+	// a day of grace covers clients ahead of the server's time zone
+	if date.After(today.AddDate(0, 0, 1)) {
+		return beans.PostDueResult{}, beans.NewError(beans.EINVALID, "Cannot post scheduled transactions after today.")
+	}
+
+	due, err := c.ds().ScheduledTransactionRepository().GetDue(ctx, auth.BudgetID(), date)
+	if err != nil {
+		return beans.PostDueResult{}, err
+	}
+
+	// build every occurrence the same way as creating a transaction by hand
+	transactionContract := &transactionContract{c.contract}
+	failed := []beans.PostDueFailure{}
+	occurrences := [][]beans.Transaction{}
+	occurrencesBySchedule := make([][][]beans.Transaction, len(due))
+	nextDates := make([]beans.Date, len(due))
+schedules:
+	for i, scheduled := range due {
+		nextDates[i] = scheduled.NextDate
+		for !nextDates[i].After(date.Time) {
+			if len(occurrences)+len(occurrencesBySchedule[i]) >= beans.MaxPostedOccurrences {
+				return beans.PostDueResult{}, beans.NewError(beans.EINVALID, fmt.Sprintf("Cannot post more than %d occurrences at once. Post up to an earlier date first.", beans.MaxPostedOccurrences))
+			}
+
+			params := scheduled.Template
+			params.Date = nextDates[i]
+
+			transactions, err := transactionContract.makeTransactions(ctx, auth, params)
+			if err != nil {
+				// a template that is no longer valid is skipped, not posted
+				var beansError beans.Error
+				if !errors.As(err, &beansError) {
+					return beans.PostDueResult{}, err
+				}
+				code, msg := beansError.BeansError()
+				if code == beans.EINTERNAL {
+					return beans.PostDueResult{}, err
+				}
+
+				failed = append(failed, beans.PostDueFailure{ScheduledTransactionID: scheduled.ID, Error: msg})
+				occurrencesBySchedule[i] = nil
+				continue schedules
+			}
+
+			occurrencesBySchedule[i] = append(occurrencesBySchedule[i], transactions)
+			nextDates[i] = scheduled.Rule.After(nextDates[i])
+		}
+		occurrences = append(occurrences, occurrencesBySchedule[i]...)
+	}
+
+	if err := c.runRulesOnNew(ctx, auth, occurrences); err != nil {
+		return beans.PostDueResult{}, err
+	}
+
+	return beans.ExecTx(ctx, c.ds().TxManager(), func(tx beans.Tx) (beans.PostDueResult, error) {
+		toCreate := []beans.Transaction{}
+		ids := []beans.ID{}
+		for i, scheduled := range due {
+			if len(occurrencesBySchedule[i]) == 0 {
+				continue
+			}
+
+			// a schedule posted by someone else since it was read is skipped
+			advanced, err := c.ds().ScheduledTransactionRepository().AdvanceNextDate(ctx, tx, scheduled.ID, scheduled.NextDate, nextDates[i])
+			if err != nil {
+				return beans.PostDueResult{}, err
+			}
+			if !advanced {
+				continue
+			}
+
+			for _, transactions := range occurrencesBySchedule[i] {
+				toCreate = append(toCreate, transactions...)
+				ids = append(ids, transactions[0].ID)
+			}
+		}
+
+		if err := transactionContract.saveCreate(ctx, tx, auth, toCreate); err != nil {
+			return beans.PostDueResult{}, err
+		}
+
+		return beans.PostDueResult{TransactionIDs: ids, Failed: failed}, nil
+	})
+}
+
+// Validates the params and builds the scheduled transaction, without an ID.
+func (c *scheduledTransactionContract) makeScheduledTransaction(ctx context.Context, auth *beans.BudgetAuthContext, params beans.ScheduledTransactionParams) (beans.ScheduledTransaction, error) {
+	if hasTags(params.Template.TransactionParams) {
+		return beans.ScheduledTransaction{}, beans.NewError(beans.EINVALID, "Scheduled transactions cannot have tags.")
+	}
+
+	// the template must be a valid transaction on its first occurrence
+	if _, err := (&transactionContract{c.contract}).makeTransactions(ctx, auth, params.Template); err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+
+	rule := params.Rule()
+	if err := rule.ValidateAll(); err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+
+	// posted transactions are always uncleared
+	template := params.Template
+	template.Date = beans.Date{}
+	template.Status = ""
+
+	return beans.ScheduledTransaction{
+		BudgetID: auth.BudgetID(),
+		Template: template,
+		Rule:     rule,
+		NextDate: rule.After(rule.Start.Previous()),
+	}, nil
+}
+
+func hasTags(params beans.TransactionParams) bool {
+	if len(params.TagIDs) > 0 {
+		return true
+	}
+	for _, split := range params.Splits {
+		if len(split.TagIDs) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package contract
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type tagContract struct{ contract }
+
+var _ beans.TagContract = (*tagContract)(nil)
+
+func (c *tagContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, name beans.Name) (beans.ID, error) {
+	if err := c.validateName(ctx, auth, beans.EmptyID(), name); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	tag := beans.Tag{
+		ID:       beans.NewID(),
+		BudgetID: auth.BudgetID(),
+		Name:     name,
+	}
+	if err := c.ds().TagRepository().Create(ctx, tag); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	return tag.ID, nil
+}
+
+func (c *tagContract) Update(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID, name beans.Name) error {
+	tag, err := c.ds().TagRepository().Get(ctx, auth.BudgetID(), id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.validateName(ctx, auth, tag.ID, name); err != nil {
+		return err
+	}
+
+	tag.Name = name
+	return c.ds().TagRepository().Update(ctx, tag)
+}
+
+func (c *tagContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) error {
+	return c.ds().TagRepository().Delete(ctx, auth.BudgetID(), id)
+}
+
+func (c *tagContract) Get(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) (beans.Tag, error) {
+	return c.ds().TagRepository().Get(ctx, auth.BudgetID(), id)
+}
+
+func (c *tagContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.Tag, error) {
+	return c.ds().TagRepository().GetForBudget(ctx, auth.BudgetID())
+}
+
+// Tag names are unique in a budget, ignoring case.
+func (c *tagContract) validateName(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID, name beans.Name) error {
+	if err := beans.ValidateFields(beans.Field("Name", name)); err != nil {
+		return err
+	}
+
+	tags, err := c.ds().TagRepository().GetForBudget(ctx, auth.BudgetID())
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if tag.ID != id && strings.EqualFold(string(tag.Name), string(name)) {
+			return beans.NewError(beans.EINVALID, "Tag name already exists.")
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 )
@@ -12,21 +14,62 @@ type transactionContract struct{ contract }
 
 var _ beans.TransactionContract = (*transactionContract)(nil)
 
+var errorReconcileDirectly = beans.NewError(beans.EINVALID, "Transactions can only be reconciled by reconciling the account.")
+
+var errorAccountClosed = beans.NewError(beans.EINVALID, "Account is closed.")
+
 func (c *transactionContract) Create(ctx context.Context, auth *beans.BudgetAuthContext, data beans.TransactionCreateParams) (beans.ID, error) {
-	if err := data.ValidateAll(); err != nil {
+	transactions, err := c.makeTransactions(ctx, auth, data)
+	if err != nil {
 		return beans.EmptyID(), err
 	}
 
-	account, err := c.getAndValidateAccount(ctx, auth, data.AccountID, "Invalid Account ID")
+	if err := c.runRulesOnNew(ctx, auth, [][]beans.Transaction{transactions}); err != nil {
+		return beans.EmptyID(), err
+	}
+
+	err = beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if err := c.saveCreate(ctx, tx, auth, transactions); err != nil {
+			return err
+		}
+
+		return c.ds().TagRepository().SetForTransactions(ctx, tx, newTransactionTags(data.TransactionParams, transactions))
+	})
 	if err != nil {
 		return beans.EmptyID(), err
 	}
 
+	return transactions[0].ID, nil
+}
+
+// Validates the params and builds the transactions to save. The first
+// transaction is the one created, followed by its splits or transfer.
+func (c *transactionContract) makeTransactions(ctx context.Context, auth *beans.BudgetAuthContext, data beans.TransactionCreateParams) ([]beans.Transaction, error) {
+	if err := data.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	account, err := c.getAndValidateAccount(ctx, auth, data.AccountID, "Invalid Account ID")
+	if err != nil {
+		return nil, err
+	}
+
 	isSplit := len(data.Splits) > 0
 
 	// validate relations
 	if err := c.validateRelations(ctx, auth, account, data.TransferAccountID, isSplit, data.PayeeID, data.CategoryID); err != nil {
-		return beans.EmptyID(), err
+		return nil, err
+	}
+	if err := c.validateTags(ctx, auth, data.TagIDs); err != nil {
+		return nil, err
+	}
+
+	status := data.Status
+	switch status {
+	case "":
+		status = beans.TransactionUncleared
+	case beans.TransactionReconciled:
+		return nil, errorReconcileDirectly
 	}
 
 	// make new transaction
@@ -38,6 +81,7 @@ func (c *transactionContract) Create(ctx context.Context, auth *beans.BudgetAuth
 		Amount:     data.Amount,
 		Date:       data.Date,
 		Notes:      data.Notes,
+		Status:     status,
 		IsSplit:    isSplit,
 	}
 
@@ -46,7 +90,10 @@ func (c *transactionContract) Create(ctx context.Context, auth *beans.BudgetAuth
 	// add splits if split
 	for _, split := range data.Splits {
 		if err := c.validateCategory(ctx, auth, split.CategoryID); err != nil {
-			return beans.EmptyID(), err
+			return nil, err
+		}
+		if err := c.validateTags(ctx, auth, split.TagIDs); err != nil {
+			return nil, err
 		}
 
 		transactions = append(transactions, beans.Transaction{
@@ -54,6 +101,7 @@ func (c *transactionContract) Create(ctx context.Context, auth *beans.BudgetAuth
 			AccountID: data.AccountID,
 			PayeeID:   data.PayeeID,
 			Date:      data.Date,
+			Status:    status,
 			SplitID:   transaction.ID,
 
 			CategoryID: split.CategoryID,
@@ -71,39 +119,141 @@ func (c *transactionContract) Create(ctx context.Context, auth *beans.BudgetAuth
 			Amount:     beans.Arithmetic.Negate(data.Amount),
 			Date:       data.Date,
 			Notes:      data.Notes,
+			Status:     beans.TransactionUncleared,
 			TransferID: transaction.ID,
 		}
 
 		// make transactionA a transfer
 		transaction.TransferID = transactionB.ID
 
-		// save
 		transactions = []beans.Transaction{transaction, transactionB}
 	}
 
-	err = c.ds().TransactionRepository().Create(ctx, transactions)
-	if err != nil {
-		return beans.EmptyID(), err
+	return transactions, nil
+}
+
+// Maps the transactions built by makeTransactions to their tags. Splits
+// follow the transaction in the same order as in the params.
+func newTransactionTags(data beans.TransactionParams, transactions []beans.Transaction) map[beans.ID][]beans.ID {
+	tags := map[beans.ID][]beans.ID{transactions[0].ID: data.TagIDs}
+	for i, split := range data.Splits {
+		tags[transactions[i+1].ID] = split.TagIDs
 	}
 
-	return transaction.ID, nil
+	return tags
 }
 
 func (c *transactionContract) Update(ctx context.Context, auth *beans.BudgetAuthContext, data beans.TransactionUpdateParams) error {
+	changes, err := c.makeUpdate(ctx, auth, data)
+	if err != nil {
+		return err
+	}
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		return c.saveUpdate(ctx, tx, changes)
+	})
+}
+
+func (c *transactionContract) BulkUpdate(ctx context.Context, auth *beans.BudgetAuthContext, data beans.TransactionBulkUpdateParams) error {
 	if err := data.ValidateAll(); err != nil {
 		return err
 	}
 
+	// each transaction goes through the same rules as a single update
+	seen := make(map[beans.ID]bool, len(data.IDs))
+	allChanges := make([]transactionUpdate, 0, len(data.IDs))
+	for _, id := range data.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), id)
+		if err != nil {
+			return err
+		}
+		withRelations, err := c.ds().TransactionRepository().GetWithRelations(ctx, auth.BudgetID(), id)
+		if err != nil {
+			return err
+		}
+
+		// updating one side of a transfer also updates the other
+		if slices.Contains(data.IDs, transaction.TransferID) {
+			return beans.NewError(beans.EINVALID, "Cannot bulk edit both sides of a transfer.")
+		}
+
+		splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), id)
+		if err != nil {
+			return err
+		}
+		splitParams := make([]beans.SplitParams, len(splits))
+		for i, split := range splits {
+			splitParams[i] = beans.SplitParams{
+				Amount:     split.Split.Amount,
+				CategoryID: split.Category.ID,
+				Notes:      split.Split.Notes,
+				TagIDs:     relatedTagIDs(split.Split.Tags),
+			}
+		}
+
+		changes, err := c.makeUpdate(ctx, auth, beans.TransactionUpdateParams{
+			ID: id,
+			TransactionParams: data.Apply(beans.TransactionParams{
+				AccountID:  transaction.AccountID,
+				CategoryID: transaction.CategoryID,
+				PayeeID:    transaction.PayeeID,
+				Amount:     transaction.Amount,
+				Date:       transaction.Date,
+				Notes:      transaction.Notes,
+				Status:     transaction.Status,
+				Splits:     splitParams,
+				TagIDs:     relatedTagIDs(withRelations.Tags),
+			}),
+		})
+		if err != nil {
+			return err
+		}
+		allChanges = append(allChanges, changes)
+	}
+
+	return beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		for _, changes := range allChanges {
+			if err := c.saveUpdate(ctx, tx, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Transactions to save when updating a transaction.
+type transactionUpdate struct {
+	creates []beans.Transaction
+	updates []beans.Transaction
+	deletes []beans.ID
+
+	// Tags of the transaction and its remaining splits.
+	tags map[beans.ID][]beans.ID
+
+	history []beans.TransactionHistory
+}
+
+// Validates the params and builds the changes to save.
+func (c *transactionContract) makeUpdate(ctx context.Context, auth *beans.BudgetAuthContext, data beans.TransactionUpdateParams) (transactionUpdate, error) {
+	if err := data.ValidateAll(); err != nil {
+		return transactionUpdate{}, err
+	}
+
 	// load transaction
 	transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), data.ID)
 	if err != nil {
-		return err
+		return transactionUpdate{}, err
 	}
 
 	// load and validate account
 	account, err := c.getAndValidateAccount(ctx, auth, data.AccountID, "Invalid Account ID")
 	if err != nil {
-		return err
+		return transactionUpdate{}, err
 	}
 
 	// load transfer
@@ -111,81 +261,270 @@ func (c *transactionContract) Update(ctx context.Context, auth *beans.BudgetAuth
 	if !transaction.TransferID.Empty() {
 		transactionB, err = c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), transaction.TransferID)
 		if err != nil {
-			return fmt.Errorf("could not get transfer: %w", err)
+			return transactionUpdate{}, fmt.Errorf("could not get transfer: %w", err)
 		}
 	}
 
 	// load splits
 	splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), data.ID)
 	if err != nil {
-		return err
+		return transactionUpdate{}, err
 	}
 
 	// cannot edit a split itself
 	if !transaction.SplitID.Empty() {
-		return beans.NewError(beans.EINVALID, "cannot update a split directly")
+		return transactionUpdate{}, beans.NewError(beans.EINVALID, "cannot update a split directly")
 	}
 
-	// cannot edit splits
-	if len(data.Splits) != len(splits) {
-		return beans.NewError(beans.EINVALID, "cannot add or remove split")
-	}
+	isSplit := len(data.Splits) > 0
 
 	// validate relations
-	if err := c.validateRelations(ctx, auth, account, transactionB.AccountID, transaction.IsSplit, data.PayeeID, data.CategoryID); err != nil {
-		return err
+	if err := c.validateRelations(ctx, auth, account, transactionB.AccountID, isSplit, data.PayeeID, data.CategoryID); err != nil {
+		return transactionUpdate{}, err
+	}
+	if err := c.validateTags(ctx, auth, data.TagIDs); err != nil {
+		return transactionUpdate{}, err
+	}
+
+	// validate status
+	status := data.Status
+	if status == "" {
+		status = transaction.Status
+	}
+	if status == beans.TransactionReconciled && transaction.Status != beans.TransactionReconciled {
+		return transactionUpdate{}, errorReconcileDirectly
+	}
+	// reconciled transactions stay locked whatever status is asked for
+	if transaction.Status == beans.TransactionReconciled {
+		if status != beans.TransactionReconciled {
+			return transactionUpdate{}, beans.NewError(beans.EINVALID, "Cannot change the status of a reconciled transaction.")
+		}
+		if transaction.AccountID != data.AccountID || transaction.Amount.Compare(data.Amount) != 0 {
+			return transactionUpdate{}, beans.NewError(beans.EINVALID, "Cannot change the amount or account of a reconciled transaction.")
+		}
+	}
+	if transactionB.Status == beans.TransactionReconciled && transaction.Amount.Compare(data.Amount) != 0 {
+		return transactionUpdate{}, beans.NewError(beans.EINVALID, "Cannot change the amount of a transfer with a reconciled transaction.")
 	}
 
 	// update primary transaction
+	original := transaction
 	transaction.AccountID = data.AccountID
 	transaction.CategoryID = data.CategoryID
 	transaction.PayeeID = data.PayeeID
 	transaction.Amount = data.Amount
 	transaction.Date = data.Date
 	transaction.Notes = data.Notes
+	transaction.Status = status
+	transaction.IsSplit = isSplit
 
 	updates := []beans.Transaction{transaction}
+	creates := []beans.Transaction{}
+	deletes := []beans.ID{}
+	tags := map[beans.ID][]beans.ID{transaction.ID: data.TagIDs}
+	history := []beans.TransactionHistory{}
+	if !original.HistoryValues().Equal(transaction.HistoryValues()) {
+		history = append(history, historyOfUpdate(auth, original, transaction))
+	}
 
-	// update splits
+	// update existing splits in order, creating or deleting the difference
 	for i, split := range data.Splits {
 		if err := c.validateCategory(ctx, auth, split.CategoryID); err != nil {
-			return err
+			return transactionUpdate{}, err
+		}
+		if err := c.validateTags(ctx, auth, split.TagIDs); err != nil {
+			return transactionUpdate{}, err
+		}
+
+		if i >= len(splits) {
+			t := beans.Transaction{
+				ID:        beans.NewID(),
+				AccountID: data.AccountID,
+				PayeeID:   data.PayeeID,
+				Date:      data.Date,
+				Status:    status,
+				SplitID:   transaction.ID,
+
+				CategoryID: split.CategoryID,
+				Amount:     split.Amount,
+				Notes:      split.Notes,
+			}
+			creates = append(creates, t)
+			tags[t.ID] = split.TagIDs
+			history = append(history, historyOfCreate(auth, t))
+			continue
 		}
 
 		t := splits[i].Transaction
 		t.AccountID = data.AccountID
 		t.PayeeID = data.PayeeID
 		t.Date = data.Date
+		t.Status = status
 
 		t.CategoryID = split.CategoryID
 		t.Amount = split.Amount
 		t.Notes = split.Notes
 
 		updates = append(updates, t)
+		tags[t.ID] = split.TagIDs
+		if !splits[i].Transaction.HistoryValues().Equal(t.HistoryValues()) {
+			history = append(history, historyOfUpdate(auth, splits[i].Transaction, t))
+		}
+	}
+	for _, split := range splits[min(len(data.Splits), len(splits)):] {
+		deletes = append(deletes, split.Transaction.ID)
+		history = append(history, historyOfDelete(auth, split.Transaction))
 	}
 
 	// update transfer, if it exists
 	if !transaction.TransferID.Empty() {
+		originalB := transactionB
 		transactionB.Amount = beans.Arithmetic.Negate(data.Amount)
 		transactionB.Date = data.Date
 		transactionB.Notes = data.Notes
 
 		updates = append(updates, transactionB)
+		if !originalB.HistoryValues().Equal(transactionB.HistoryValues()) {
+			history = append(history, historyOfUpdate(auth, originalB, transactionB))
+		}
+	}
+
+	return transactionUpdate{creates: creates, updates: updates, deletes: deletes, tags: tags, history: history}, nil
+}
+
+// Saves new transactions along with the history of their creation.
+func (c *transactionContract) saveCreate(ctx context.Context, tx beans.Tx, auth *beans.BudgetAuthContext, transactions []beans.Transaction) error {
+	history := make([]beans.TransactionHistory, len(transactions))
+	for i, transaction := range transactions {
+		history[i] = historyOfCreate(auth, transaction)
 	}
 
-	if err := c.ds().TransactionRepository().Update(ctx, updates); err != nil {
+	if err := c.ds().TransactionRepository().Create(ctx, tx, transactions); err != nil {
 		return err
 	}
+	return c.ds().TransactionHistoryRepository().Create(ctx, tx, history)
+}
 
-	return nil
+func (c *transactionContract) saveUpdate(ctx context.Context, tx beans.Tx, changes transactionUpdate) error {
+	if err := c.ds().TransactionRepository().Create(ctx, tx, changes.creates); err != nil {
+		return err
+	}
+	if err := c.ds().TransactionRepository().DeleteSplits(ctx, tx, changes.deletes); err != nil {
+		return err
+	}
+
+	if err := c.ds().TransactionRepository().Update(ctx, tx, changes.updates); err != nil {
+		return err
+	}
+	if err := c.ds().TransactionHistoryRepository().Create(ctx, tx, changes.history); err != nil {
+		return err
+	}
+
+	return c.ds().TagRepository().SetForTransactions(ctx, tx, changes.tags)
 }
 
 func (c *transactionContract) Delete(ctx context.Context, auth *beans.BudgetAuthContext, transactionIDs []beans.ID) error {
-	return c.ds().TransactionRepository().Delete(ctx, auth.BudgetID(), transactionIDs)
+	// attachments are deleted with their transactions, but their files are not
+	attachments, err := c.ds().AttachmentRepository().GetForTransactions(ctx, auth.BudgetID(), transactionIDs)
+	if err != nil {
+		return err
+	}
+
+	history, err := c.historyOfDeletes(ctx, auth, transactionIDs)
+	if err != nil {
+		return err
+	}
+
+	err = beans.ExecTxNil(ctx, c.ds().TxManager(), func(tx beans.Tx) error {
+		if err := c.ds().TransactionRepository().Delete(ctx, tx, auth.BudgetID(), transactionIDs); err != nil {
+			return err
+		}
+
+		return c.ds().TransactionHistoryRepository().Create(ctx, tx, history)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.deleteAttachmentFiles(ctx, attachments)
+	return nil
+}
+
+// Builds the history for deleting transactions, which also deletes their
+// splits and transfers. Split lines cannot be deleted directly.
+func (c *transactionContract) historyOfDeletes(ctx context.Context, auth *beans.BudgetAuthContext, transactionIDs []beans.ID) ([]beans.TransactionHistory, error) {
+	history := []beans.TransactionHistory{}
+	seen := make(map[beans.ID]bool)
+	for _, id := range transactionIDs {
+		transaction, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), id)
+		if errors.Is(err, beans.ErrorNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if !transaction.SplitID.Empty() {
+			continue
+		}
+
+		deleted := []beans.Transaction{transaction}
+		if !transaction.TransferID.Empty() {
+			transfer, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), transaction.TransferID)
+			if err != nil {
+				return nil, fmt.Errorf("could not get transfer: %w", err)
+			}
+			deleted = append(deleted, transfer)
+		}
+
+		splits, err := c.ds().TransactionRepository().GetSplits(ctx, auth.BudgetID(), transaction.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, split := range splits {
+			deleted = append(deleted, split.Transaction)
+		}
+
+		for _, t := range deleted {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				history = append(history, historyOfDelete(auth, t))
+			}
+		}
+	}
+
+	return history, nil
+}
+
+func (c *transactionContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext, params beans.TransactionListParams) (beans.TransactionPage, error) {
+	if err := params.ValidateAll(); err != nil {
+		return beans.TransactionPage{}, err
+	}
+
+	// get one extra transaction to know if there is another page
+	limit := params.Limit
+	if limit > 0 {
+		params.Limit = limit + 1
+	}
+
+	transactions, err := c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID(), params)
+	if err != nil {
+		return beans.TransactionPage{}, err
+	}
+
+	page := beans.TransactionPage{Transactions: transactions}
+	if limit > 0 && len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		page.NextCursor = beans.NewTransactionCursor(params.Sort, transactions[limit-1])
+	}
+
+	return page, nil
 }
 
-func (c *transactionContract) GetAll(ctx context.Context, auth *beans.BudgetAuthContext) ([]beans.TransactionWithRelations, error) {
-	return c.ds().TransactionRepository().GetForBudget(ctx, auth.BudgetID())
+func (c *transactionContract) Search(ctx context.Context, auth *beans.BudgetAuthContext, params beans.TransactionSearchParams) ([]beans.TransactionWithRelations, error) {
+	if err := params.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	return c.ds().TransactionRepository().Search(ctx, auth.BudgetID(), params)
 }
 
 func (c *transactionContract) GetSplits(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) ([]beans.Split, error) {
@@ -204,6 +543,22 @@ func (c *transactionContract) Get(ctx context.Context, auth *beans.BudgetAuthCon
 	return c.ds().TransactionRepository().GetWithRelations(ctx, auth.BudgetID(), id)
 }
 
+func (c *transactionContract) GetHistory(ctx context.Context, auth *beans.BudgetAuthContext, id beans.ID) ([]beans.TransactionHistory, error) {
+	history, err := c.ds().TransactionHistoryRepository().GetForTransaction(ctx, auth.BudgetID(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// transactions made before history was kept have none
+	if len(history) == 0 {
+		if _, err := c.ds().TransactionRepository().Get(ctx, auth.BudgetID(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
 func (c *transactionContract) getAndValidateAccount(ctx context.Context, auth *beans.BudgetAuthContext, accountID beans.ID, msg string) (beans.Account, error) {
 	account, err := c.ds().AccountRepository().Get(ctx, auth.BudgetID(), accountID)
 	if err != nil {
@@ -214,6 +569,10 @@ func (c *transactionContract) getAndValidateAccount(ctx context.Context, auth *b
 		return beans.Account{}, err
 	}
 
+	if account.Closed {
+		return beans.Account{}, errorAccountClosed
+	}
+
 	return account, nil
 }
 
@@ -234,6 +593,31 @@ func (c *transactionContract) validateCategory(
 	return nil
 }
 
+func (c *transactionContract) validateTags(
+	ctx context.Context,
+	auth *beans.BudgetAuthContext,
+	tagIDs []beans.ID,
+) error {
+	for _, id := range tagIDs {
+		if _, err := c.ds().TagRepository().Get(ctx, auth.BudgetID(), id); err != nil {
+			if errors.Is(err, beans.ErrorNotFound) {
+				return beans.NewError(beans.EINVALID, "Invalid Tag ID")
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+func relatedTagIDs(tags []beans.RelatedTag) []beans.ID {
+	var ids []beans.ID
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
 func (c *transactionContract) validateRelations(
 	ctx context.Context,
 	auth *beans.BudgetAuthContext,
@@ -264,6 +648,9 @@ func (c *transactionContract) validateRelations(
 			}
 			return fmt.Errorf("could not get transfer account: %w", err)
 		}
+		if got.Closed {
+			return beans.NewError(beans.EINVALID, "Transfer account is closed.")
+		}
 		transferAccount = beans.OptionalWrap(got.ToRelated())
 	}
 
@@ -299,3 +686,34 @@ func (c *transactionContract) validateRelations(
 
 	return nil
 }
+
+func newTransactionHistory(auth *beans.BudgetAuthContext, action beans.TransactionHistoryAction, transaction beans.Transaction) beans.TransactionHistory {
+	return beans.TransactionHistory{
+		ID:            beans.NewID(),
+		BudgetID:      auth.BudgetID(),
+		TransactionID: transaction.ID,
+		SplitID:       transaction.SplitID,
+		User:          beans.UserPublic{ID: auth.UserID()},
+		Action:        action,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func historyOfCreate(auth *beans.BudgetAuthContext, transaction beans.Transaction) beans.TransactionHistory {
+	history := newTransactionHistory(auth, beans.TransactionCreated, transaction)
+	history.After = beans.OptionalWrap(transaction.HistoryValues())
+	return history
+}
+
+func historyOfUpdate(auth *beans.BudgetAuthContext, before beans.Transaction, after beans.Transaction) beans.TransactionHistory {
+	history := newTransactionHistory(auth, beans.TransactionUpdated, after)
+	history.Before = beans.OptionalWrap(before.HistoryValues())
+	history.After = beans.OptionalWrap(after.HistoryValues())
+	return history
+}
+
+func historyOfDelete(auth *beans.BudgetAuthContext, transaction beans.Transaction) beans.TransactionHistory {
+	history := newTransactionHistory(auth, beans.TransactionDeleted, transaction)
+	history.Before = beans.OptionalWrap(transaction.HistoryValues())
+	return history
+}
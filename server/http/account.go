@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/http/request"
@@ -19,7 +20,11 @@ func (s *Server) handleAccountCreate() http.HandlerFunc {
 
 		accountID, err := s.contracts.Account.Create(r.Context(), getBudgetAuth(r), beans.AccountCreate{
 			Name:      req.Name,
+			Type:      req.Type,
 			OffBudget: req.OffBudget,
+
+			OpeningBalance: req.OpeningBalance,
+			OpeningDate:    req.OpeningDate,
 		})
 		if err != nil {
 			Error(w, err)
@@ -44,10 +49,15 @@ func (s *Server) handleAccountsGet() http.HandlerFunc {
 		res := make([]response.ListAccount, 0, len(accounts))
 		for _, a := range accounts {
 			res = append(res, response.ListAccount{
-				ID:        a.ID,
-				Name:      string(a.Name),
-				Balance:   a.Balance,
-				OffBudget: a.OffBudget,
+				ID:                a.ID,
+				Name:              string(a.Name),
+				Balance:           a.Balance,
+				ClearedBalance:    a.ClearedBalance,
+				UnclearedBalance:  a.UnclearedBalance,
+				Type:              a.Type,
+				OffBudget:         a.OffBudget,
+				PaymentCategoryID: a.PaymentCategoryID,
+				Closed:            a.Closed,
 			})
 		}
 
@@ -66,7 +76,7 @@ func (s *Server) handleAccountsGetTransactable() http.HandlerFunc {
 
 		res := make([]response.Account, 0, len(accounts))
 		for _, a := range accounts {
-			res = append(res, response.Account{ID: a.ID, Name: string(a.Name), OffBudget: a.OffBudget})
+			res = append(res, responseFromAccount(a))
 		}
 
 		jsonResponse(w, response.GetTransactableAccounts{Data: res}, http.StatusOK)
@@ -88,10 +98,191 @@ func (s *Server) handleAccountGet() http.HandlerFunc {
 			return
 		}
 
-		jsonResponse(w, response.GetAccountResponse{Data: response.Account{
-			ID:        account.ID,
-			Name:      string(account.Name),
-			OffBudget: account.OffBudget,
-		}}, http.StatusOK)
+		jsonResponse(w, response.GetAccountResponse{Data: responseFromAccount(account)}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleAccountUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.UpdateAccount
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Account.Update(r.Context(), getBudgetAuth(r), beans.AccountUpdateParams{
+			ID:        accountID,
+			Name:      req.Name,
+			OffBudget: req.OffBudget,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleAccountClose() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Account.Close(r.Context(), getBudgetAuth(r), accountID); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleAccountReopen() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Account.Reopen(r.Context(), getBudgetAuth(r), accountID); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleAccountDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.DeleteAccount
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Account.Delete(r.Context(), getBudgetAuth(r), beans.AccountDeleteParams{
+			ID:              accountID,
+			MoveToAccountID: req.MoveToAccountID,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleAccountReconcile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ReconcileAccount
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		adjustmentID, err := s.contracts.Account.Reconcile(r.Context(), getBudgetAuth(r), beans.AccountReconcileParams{
+			AccountID:        accountID,
+			Balance:          req.Balance,
+			Date:             req.Date,
+			CreateAdjustment: req.CreateAdjustment,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.ReconcileAccountResponse{
+			Data: response.ReconcileAccount{AdjustmentID: adjustmentID},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleAccountsGetBalances() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := accountBalanceParamsFromQuery(r.URL.Query())
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		s.writeAccountBalances(w, r, params)
+	}
+}
+
+func (s *Server) handleAccountGetBalances() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, err := beans.IDFromString(chi.URLParam(r, "accountID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		params, err := accountBalanceParamsFromQuery(r.URL.Query())
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		params.AccountID = accountID
+
+		s.writeAccountBalances(w, r, params)
+	}
+}
+
+func (s *Server) writeAccountBalances(w http.ResponseWriter, r *http.Request, params beans.AccountBalanceParams) {
+	balances, err := s.contracts.Account.GetBalances(r.Context(), getBudgetAuth(r), params)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+
+	res := make([]response.AccountBalance, len(balances))
+	for i, b := range balances {
+		res[i] = response.AccountBalance{
+			AccountID: b.AccountID,
+			Date:      b.Date,
+			Balance:   b.Balance,
+		}
+	}
+
+	jsonResponse(w, response.ListAccountBalancesResponse{Data: res}, http.StatusOK)
+}
+
+func accountBalanceParamsFromQuery(query url.Values) (beans.AccountBalanceParams, error) {
+	params := beans.AccountBalanceParams{
+		Interval: beans.BalanceInterval(query.Get("interval")),
+	}
+
+	err := decodeQuery(query, map[string]any{
+		"from": &params.From,
+		"to":   &params.To,
+	})
+	return params, err
+}
+
+func responseFromAccount(account beans.Account) response.Account {
+	return response.Account{
+		ID:                account.ID,
+		Name:              string(account.Name),
+		Type:              account.Type,
+		OffBudget:         account.OffBudget,
+		PaymentCategoryID: account.PaymentCategoryID,
+		Closed:            account.Closed,
 	}
 }
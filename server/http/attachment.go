@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// Leaves room for the rest of the multipart form around the file.
+const maxAttachmentRequestSize = beans.MaxAttachmentSize + 1<<20
+
+func (s *Server) handleAttachmentCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transactionID, err := beans.IDFromString(chi.URLParam(r, "transactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentRequestSize)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			Error(w, attachmentFormError(err))
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		id, err := s.contracts.Attachment.Create(r.Context(), getBudgetAuth(r), beans.AttachmentParams{
+			TransactionID: transactionID,
+			FileName:      beans.Name(header.Filename),
+			File:          file,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.CreateAttachmentResponse{
+			Data: response.ID{ID: id},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleAttachmentGetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transactionID, err := beans.IDFromString(chi.URLParam(r, "transactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		attachments, err := s.contracts.Attachment.GetAll(r.Context(), getBudgetAuth(r), transactionID)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ListAttachmentsResponse{Data: make([]response.Attachment, len(attachments))}
+		for i, attachment := range attachments {
+			res.Data[i] = response.Attachment{
+				ID:       attachment.ID,
+				FileName: attachment.FileName,
+				MimeType: attachment.MimeType,
+				Size:     attachment.Size,
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func (s *Server) handleAttachmentGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transactionID, id, err := attachmentIDsFromURL(r)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		attachment, file, err := s.contracts.Attachment.Get(r.Context(), getBudgetAuth(r), transactionID, id)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		w.Header().Set("Content-Type", attachment.MimeType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": string(attachment.FileName),
+		}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, file)
+	}
+}
+
+func (s *Server) handleAttachmentDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transactionID, id, err := attachmentIDsFromURL(r)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		if err := s.contracts.Attachment.Delete(r.Context(), getBudgetAuth(r), transactionID, id); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func attachmentIDsFromURL(r *http.Request) (beans.ID, beans.ID, error) {
+	transactionID, err := beans.IDFromString(chi.URLParam(r, "transactionID"))
+	if err != nil {
+		return beans.EmptyID(), beans.EmptyID(), beans.WrapError(err, beans.ErrorNotFound)
+	}
+	id, err := beans.IDFromString(chi.URLParam(r, "attachmentID"))
+	if err != nil {
+		return beans.EmptyID(), beans.EmptyID(), beans.WrapError(err, beans.ErrorNotFound)
+	}
+
+	return transactionID, id, nil
+}
+
+func attachmentFormError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return beans.NewError(beans.EINVALID, fmt.Sprintf("File must be at most %d MB.", beans.MaxAttachmentSize>>20))
+	}
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		return beans.NewError(beans.EINVALID, "File is required.")
+	}
+
+	return beans.NewError(beans.EUNPROCESSABLE, "Invalid file upload.")
+}
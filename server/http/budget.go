@@ -45,7 +45,7 @@ func (s *Server) handleBudgetGetAll() http.HandlerFunc {
 
 		res := response.ListBudgetsResponse{Data: []response.Budget{}}
 		for _, b := range budgets {
-			res.Data = append(res.Data, response.Budget{ID: b.ID, Name: b.Name})
+			res.Data = append(res.Data, responseFromBudget(b))
 		}
 
 		jsonResponse(w, res, http.StatusOK)
@@ -66,14 +66,50 @@ func (s *Server) handleBudgetGet() http.HandlerFunc {
 			return
 		}
 
-		res := response.GetBudgetResponse{Data: response.Budget{
-			ID: budget.ID, Name: budget.Name,
-		}}
+		res := response.GetBudgetResponse{Data: responseFromBudget(budget)}
 
 		jsonResponse(w, res, http.StatusOK)
 	}
 }
 
+func (s *Server) handleBudgetUpdate() http.HandlerFunc {
+	type request struct {
+		Name                 beans.Name                 `json:"name"`
+		OverspendingRollover beans.OverspendingRollover `json:"overspendingRollover"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		budgetID, err := beans.IDFromString(chi.URLParam(r, "budgetID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Budget.Update(r.Context(), getAuth(r), beans.BudgetUpdateParams{
+			ID:                   budgetID,
+			Name:                 req.Name,
+			OverspendingRollover: req.OverspendingRollover,
+		}); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func responseFromBudget(budget beans.Budget) response.Budget {
+	return response.Budget{
+		ID:                   budget.ID,
+		Name:                 budget.Name,
+		OverspendingRollover: budget.OverspendingRollover,
+	}
+}
+
 // middleware
 
 func (s *Server) parseBudgetHeader(next http.Handler) http.Handler {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
 	"github.com/bradenrayhorn/beans/server/http/response"
 	"github.com/go-chi/chi/v5"
 )
@@ -67,18 +68,7 @@ func (s *Server) handleCategoryGetAll() http.HandlerFunc {
 
 		res := response.GetCategoriesResponse{Data: make([]response.CategoryGroup, len(groups))}
 		for i, group := range groups {
-			categories := []response.AssociatedCategory{}
-
-			for _, category := range group.Categories {
-				categories = append(categories, response.AssociatedCategory{ID: category.ID, Name: category.Name})
-			}
-
-			res.Data[i] = response.CategoryGroup{
-				ID:         group.ID,
-				Name:       group.Name,
-				IsIncome:   group.IsIncome,
-				Categories: categories,
-			}
+			res.Data[i] = responseFromCategoryGroup(group)
 		}
 
 		jsonResponse(w, res, http.StatusOK)
@@ -100,11 +90,7 @@ func (s *Server) handleCategoryGetCategory() http.HandlerFunc {
 		}
 
 		jsonResponse(w, response.GetCategoryResponse{
-			Data: response.Category{
-				ID:      category.ID,
-				Name:    category.Name,
-				GroupID: category.GroupID,
-			},
+			Data: responseFromCategory(category),
 		}, http.StatusOK)
 	}
 }
@@ -123,18 +109,189 @@ func (s *Server) handleCategoryGetCategoryGroup() http.HandlerFunc {
 			return
 		}
 
-		categories := []response.AssociatedCategory{}
-		for _, category := range group.Categories {
-			categories = append(categories, response.AssociatedCategory{ID: category.ID, Name: category.Name})
-		}
-
 		jsonResponse(w, response.GetCategoryGroupResponse{
-			Data: response.CategoryGroup{
-				ID:         group.ID,
-				Name:       group.Name,
-				IsIncome:   group.IsIncome,
-				Categories: categories,
-			},
+			Data: responseFromCategoryGroup(group),
 		}, http.StatusOK)
 	}
 }
+
+func (s *Server) handleCategoryUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.UpdateCategory
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Category.UpdateCategory(r.Context(), getBudgetAuth(r), beans.CategoryUpdateParams{
+			ID:        id,
+			GroupID:   req.GroupID,
+			Name:      req.Name,
+			SortOrder: req.SortOrder,
+			Hidden:    req.Hidden,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleCategoryDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.DeleteCategory
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Category.DeleteCategory(r.Context(), getBudgetAuth(r), beans.CategoryDeleteParams{
+			ID:            id,
+			ReplacementID: req.ReplacementID,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleCategorySetGoal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.SetCategoryGoal
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		goal := beans.CategoryGoal{
+			Type:   req.Type,
+			Amount: req.Amount,
+		}
+		if !req.TargetMonth.Empty() {
+			goal.TargetMonth = beans.NewMonthDate(req.TargetMonth)
+		}
+
+		if err := s.contracts.Category.SetGoal(r.Context(), getBudgetAuth(r), id, beans.OptionalWrap(goal)); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleCategoryDeleteGoal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Category.SetGoal(r.Context(), getBudgetAuth(r), id, beans.Optional[beans.CategoryGoal]{}); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleCategoryGroupUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.UpdateCategoryGroup
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryGroupID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Category.UpdateGroup(r.Context(), getBudgetAuth(r), beans.CategoryGroupUpdateParams{
+			ID:        id,
+			Name:      req.Name,
+			SortOrder: req.SortOrder,
+			Hidden:    req.Hidden,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleCategoryGroupDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "categoryGroupID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Category.DeleteGroup(r.Context(), getBudgetAuth(r), id); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func responseFromCategory(category beans.Category) response.Category {
+	res := response.Category{
+		ID:        category.ID,
+		Name:      category.Name,
+		GroupID:   category.GroupID,
+		SortOrder: category.SortOrder,
+		Hidden:    category.Hidden,
+	}
+
+	if goal, ok := category.Goal.Value(); ok {
+		responseGoal := responseFromCategoryGoal(goal)
+		res.Goal = &responseGoal
+	}
+
+	return res
+}
+
+func responseFromCategoryGoal(goal beans.CategoryGoal) response.CategoryGoal {
+	return response.CategoryGoal{
+		Type:        goal.Type,
+		Amount:      goal.Amount,
+		TargetMonth: goal.TargetMonth.FirstDay(),
+	}
+}
+
+func responseFromCategoryGroup(group beans.CategoryGroupWithCategories) response.CategoryGroup {
+	categories := []response.Category{}
+	for _, category := range group.Categories {
+		categories = append(categories, responseFromCategory(category))
+	}
+
+	return response.CategoryGroup{
+		ID:                   group.ID,
+		Name:                 group.Name,
+		IsIncome:             group.IsIncome,
+		IsCreditCardPayments: group.IsCreditCardPayments,
+		SortOrder:            group.SortOrder,
+		Hidden:               group.Hidden,
+		Categories:           categories,
+	}
+}
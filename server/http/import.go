@@ -0,0 +1,176 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+)
+
+func importCSVParams(req request.ImportCSV) beans.ImportCSVParams {
+	return beans.ImportCSVParams{
+		AccountID: req.AccountID,
+		File:      req.File,
+		CSVFormat: beans.CSVFormat{
+			HasHeader:  req.HasHeader,
+			DateFormat: req.DateFormat,
+			Columns:    beans.CSVColumns(req.Columns),
+		},
+	}
+}
+
+func responseFromImportPreview(rows []beans.ImportPreviewRow) response.PreviewImportResponse {
+	res := response.PreviewImportResponse{Data: make([]response.ImportPreviewRow, len(rows))}
+	for i, row := range rows {
+		var payee *response.AssociatedPayee
+		if p, ok := row.Payee.Value(); ok {
+			payee = &response.AssociatedPayee{ID: p.ID, Name: p.Name}
+		}
+
+		res.Data[i] = response.ImportPreviewRow{
+			Date:      row.Date,
+			Amount:    row.Amount,
+			PayeeName: row.PayeeName,
+			Payee:     payee,
+			Notes:     row.Notes,
+			ImportID:  row.ImportID,
+			Duplicate: row.Duplicate,
+		}
+	}
+
+	return res
+}
+
+func responseFromImportResult(result beans.ImportResult) response.ImportResponse {
+	return response.ImportResponse{
+		Data: response.ImportResult{
+			TransactionIDs: result.TransactionIDs,
+			Duplicates:     result.Duplicates,
+		},
+	}
+}
+
+func (s *Server) handleImportCSVPreview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ImportCSV
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		rows, err := s.contracts.Import.PreviewCSV(r.Context(), getBudgetAuth(r), importCSVParams(req))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, responseFromImportPreview(rows), http.StatusOK)
+	}
+}
+
+func (s *Server) handleImportCSV() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ImportCSV
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		result, err := s.contracts.Import.ImportCSV(r.Context(), getBudgetAuth(r), importCSVParams(req))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, responseFromImportResult(result), http.StatusOK)
+	}
+}
+
+func (s *Server) handleImportOFXPreview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ImportOFX
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		rows, err := s.contracts.Import.PreviewOFX(r.Context(), getBudgetAuth(r), beans.ImportOFXParams{
+			AccountID: req.AccountID,
+			File:      req.File,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, responseFromImportPreview(rows), http.StatusOK)
+	}
+}
+
+func (s *Server) handleImportOFX() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ImportOFX
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		result, err := s.contracts.Import.ImportOFX(r.Context(), getBudgetAuth(r), beans.ImportOFXParams{
+			AccountID: req.AccountID,
+			File:      req.File,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, responseFromImportResult(result), http.StatusOK)
+	}
+}
+
+func (s *Server) handleImportQIF() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ImportQIF
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		result, err := s.contracts.Import.ImportQIF(r.Context(), getBudgetAuth(r), beans.ImportQIFParams{
+			AccountID:  req.AccountID,
+			File:       req.File,
+			DateFormat: req.DateFormat,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, responseFromImportResult(result), http.StatusOK)
+	}
+}
+
+func (s *Server) handleExportQIF() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		files, err := s.contracts.Import.ExportQIF(r.Context(), getBudgetAuth(r))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ExportResponse{Data: make([]response.ExportedFile, len(files))}
+		for i, file := range files {
+			res.Data[i] = response.ExportedFile{
+				Account: response.AssociatedAccount{
+					ID:        file.Account.ID,
+					Name:      file.Account.Name,
+					OffBudget: file.Account.OffBudget,
+				},
+				File: file.File,
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
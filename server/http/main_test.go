@@ -18,7 +18,7 @@ func TestHTTP(t *testing.T) {
 
 	sessionRepository := inmem.NewSessionRepository()
 	httpServer := http.NewServer(
-		contract.NewContracts(ds, sessionRepository),
+		contract.NewContracts(ds, sessionRepository, inmem.NewBlobStore()),
 		service.NewServices(ds, sessionRepository),
 	)
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/http/request"
@@ -26,29 +27,30 @@ func (s *Server) handleMonthGetOrCreate() http.HandlerFunc {
 			return
 		}
 
-		responseCategories := make([]response.MonthCategory, len(month.Categories))
-		for i, category := range month.Categories {
-			responseCategories[i] = response.MonthCategory{
-				ID:         category.ID,
-				Assigned:   category.Amount,
-				Activity:   category.Activity,
-				Available:  category.Available,
-				CategoryID: category.CategoryID,
-			}
+		jsonResponse(w, response.GetMonthResponse{Data: responseFromMonth(month)}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleMonthGetRange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := monthRangeParamsFromQuery(r.URL.Query())
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		months, err := s.contracts.Month.GetRange(r.Context(), getBudgetAuth(r), params)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ListMonthsResponse{Data: make([]response.Month, len(months))}
+		for i, month := range months {
+			res.Data[i] = responseFromMonth(month)
 		}
 
-		jsonResponse(w, response.GetMonthResponse{
-			Data: response.Month{
-				ID:          month.ID,
-				Date:        month.Date,
-				Budgetable:  month.Budgetable,
-				Carryover:   month.Carryover,
-				Income:      month.Income,
-				Assigned:    month.Assigned,
-				CarriedOver: month.CarriedOver,
-				Categories:  responseCategories,
-			},
-		}, http.StatusOK)
+		jsonResponse(w, res, http.StatusOK)
 	}
 }
 
@@ -93,3 +95,157 @@ func (s *Server) handleMonthCategoryUpdate() http.HandlerFunc {
 		}
 	}
 }
+
+func (s *Server) handleMonthQuickBudget() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.QuickBudgetMonth
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		monthID, err := beans.IDFromString(chi.URLParam(r, "monthID"))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		month, err := s.contracts.Month.QuickBudget(r.Context(), getBudgetAuth(r), beans.QuickBudgetParams{
+			MonthID:     monthID,
+			Type:        req.Type,
+			Months:      req.Months,
+			CategoryIDs: req.CategoryIDs,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.GetMonthResponse{Data: responseFromMonth(month)}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleMonthMoveMoney() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.MoveMoney
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		monthID, err := beans.IDFromString(chi.URLParam(r, "monthID"))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		if err := s.contracts.Month.MoveMoney(r.Context(), getBudgetAuth(r), beans.MoveMoneyParams{
+			MonthID:        monthID,
+			FromCategoryID: req.FromCategoryID,
+			ToCategoryID:   req.ToCategoryID,
+			Amount:         req.Amount,
+		}); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleMonthGetMovements() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		monthID, err := beans.IDFromString(chi.URLParam(r, "monthID"))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		movements, err := s.contracts.Month.GetMovements(r.Context(), getBudgetAuth(r), monthID)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.GetMoneyMovementsResponse{Data: make([]response.MoneyMovement, len(movements))}
+		for i, movement := range movements {
+			res.Data[i] = response.MoneyMovement{
+				ID:             movement.ID,
+				FromCategoryID: movement.FromCategoryID,
+				ToCategoryID:   movement.ToCategoryID,
+				Amount:         movement.Amount,
+				CreatedAt:      movement.CreatedAt,
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func (s *Server) handleMonthCheckSnapshots() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mismatches, err := s.contracts.Month.CheckSnapshots(r.Context(), getBudgetAuth(r))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.CheckMonthSnapshotsResponse{Data: make([]response.MonthSnapshotMismatch, len(mismatches))}
+		for i, mismatch := range mismatches {
+			res.Data[i] = response.MonthSnapshotMismatch{
+				Date:       mismatch.Date,
+				CategoryID: mismatch.CategoryID,
+				Field:      mismatch.Field,
+				Snapshot:   mismatch.Snapshot,
+				Recomputed: mismatch.Recomputed,
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func monthRangeParamsFromQuery(query url.Values) (beans.MonthRangeParams, error) {
+	params := beans.MonthRangeParams{
+		CreateFuture: query.Get("create_future") == "true",
+	}
+
+	err := decodeQuery(query, map[string]any{
+		"from": &params.From,
+		"to":   &params.To,
+	})
+	return params, err
+}
+
+func responseFromMonth(month beans.MonthWithDetails) response.Month {
+	categories := make([]response.MonthCategory, len(month.Categories))
+	for i, category := range month.Categories {
+		categories[i] = response.MonthCategory{
+			ID:         category.ID,
+			Assigned:   category.Amount,
+			Activity:   category.Activity,
+			Available:  category.Available,
+			CategoryID: category.CategoryID,
+		}
+
+		if progress, ok := category.Goal.Value(); ok {
+			categories[i].Goal = &response.CategoryGoalProgress{
+				Goal:        responseFromCategoryGoal(progress.Goal),
+				Target:      progress.Target,
+				Percent:     progress.Percent,
+				Underfunded: progress.Underfunded,
+			}
+		}
+	}
+
+	return response.Month{
+		ID:          month.ID,
+		Date:        month.Date,
+		Budgetable:  month.Budgetable,
+		Carryover:   month.Carryover,
+		Income:      month.Income,
+		Assigned:    month.Assigned,
+		CarriedOver: month.CarriedOver,
+		Underfunded: month.Underfunded,
+		Overspent:   month.Overspent,
+		Categories:  categories,
+	}
+}
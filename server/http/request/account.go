@@ -3,6 +3,24 @@ package request
 import "github.com/bradenrayhorn/beans/server/beans"
 
 type CreateAccount struct {
+	Name           beans.Name        `json:"name"`
+	Type           beans.AccountType `json:"type"`
+	OffBudget      bool              `json:"offBudget"`
+	OpeningBalance beans.Amount      `json:"openingBalance"`
+	OpeningDate    beans.Date        `json:"openingDate"`
+}
+
+type UpdateAccount struct {
 	Name      beans.Name `json:"name"`
 	OffBudget bool       `json:"offBudget"`
 }
+
+type DeleteAccount struct {
+	MoveToAccountID beans.ID `json:"move_to_account_id"`
+}
+
+type ReconcileAccount struct {
+	Balance          beans.Amount `json:"balance"`
+	Date             beans.Date   `json:"date"`
+	CreateAdjustment bool         `json:"createAdjustment"`
+}
@@ -0,0 +1,26 @@
+package request
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type UpdateCategory struct {
+	GroupID   beans.ID   `json:"group_id"`
+	Name      beans.Name `json:"name"`
+	SortOrder int        `json:"sortOrder"`
+	Hidden    bool       `json:"hidden"`
+}
+
+type DeleteCategory struct {
+	ReplacementID beans.ID `json:"replacement_id"`
+}
+
+type UpdateCategoryGroup struct {
+	Name      beans.Name `json:"name"`
+	SortOrder int        `json:"sortOrder"`
+	Hidden    bool       `json:"hidden"`
+}
+
+type SetCategoryGoal struct {
+	Type        beans.GoalType `json:"type"`
+	Amount      beans.Amount   `json:"amount"`
+	TargetMonth beans.Date     `json:"targetMonth"`
+}
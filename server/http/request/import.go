@@ -0,0 +1,31 @@
+package request
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type CSVColumns struct {
+	Date   beans.Optional[int] `json:"date"`
+	Payee  beans.Optional[int] `json:"payee"`
+	Notes  beans.Optional[int] `json:"notes"`
+	Amount beans.Optional[int] `json:"amount"`
+	Debit  beans.Optional[int] `json:"debit"`
+	Credit beans.Optional[int] `json:"credit"`
+}
+
+type ImportCSV struct {
+	AccountID  beans.ID               `json:"account_id"`
+	File       string                 `json:"file"`
+	HasHeader  bool                   `json:"has_header"`
+	DateFormat beans.ImportDateFormat `json:"date_format"`
+	Columns    CSVColumns             `json:"columns"`
+}
+
+type ImportOFX struct {
+	AccountID beans.ID `json:"account_id"`
+	File      string   `json:"file"`
+}
+
+type ImportQIF struct {
+	AccountID  beans.ID               `json:"account_id"`
+	File       string                 `json:"file"`
+	DateFormat beans.ImportDateFormat `json:"date_format"`
+}
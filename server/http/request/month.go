@@ -6,6 +6,18 @@ type UpdateMonth struct {
 	Carryover beans.Amount `json:"carryover"`
 }
 
+type QuickBudgetMonth struct {
+	Type        beans.QuickBudgetType `json:"type"`
+	Months      int                   `json:"months"`
+	CategoryIDs []beans.ID            `json:"category_ids"`
+}
+
+type MoveMoney struct {
+	FromCategoryID beans.ID     `json:"from_category_id"`
+	ToCategoryID   beans.ID     `json:"to_category_id"`
+	Amount         beans.Amount `json:"amount"`
+}
+
 type UpdateMonthCategory struct {
 	CategoryID beans.ID     `json:"category_id"`
 	Amount     beans.Amount `json:"amount"`
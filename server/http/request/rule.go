@@ -0,0 +1,18 @@
+package request
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type Rule struct {
+	Name          beans.Name       `json:"name"`
+	PayeeContains beans.NullString `json:"payeeContains"`
+	MinAmount     beans.Amount     `json:"minAmount"`
+	MaxAmount     beans.Amount     `json:"maxAmount"`
+
+	PayeeID     beans.ID               `json:"payee_id"`
+	CategoryID  beans.ID               `json:"category_id"`
+	AppendNotes beans.TransactionNotes `json:"appendNotes"`
+}
+
+type RunRules struct {
+	DryRun bool `json:"dryRun"`
+}
@@ -0,0 +1,14 @@
+package request
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type ScheduledTransaction struct {
+	CreateTransaction
+
+	Frequency beans.ScheduleFrequency `json:"frequency"`
+	Interval  int                     `json:"interval"`
+}
+
+type PostScheduledTransactions struct {
+	Date beans.Date `json:"date"`
+}
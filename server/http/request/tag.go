@@ -0,0 +1,7 @@
+package request
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type Tag struct {
+	Name beans.Name `json:"name"`
+}
@@ -3,33 +3,49 @@ package request
 import "github.com/bradenrayhorn/beans/server/beans"
 
 type CreateTransaction struct {
-	AccountID  beans.ID               `json:"account_id"`
-	CategoryID beans.ID               `json:"category_id"`
-	PayeeID    beans.ID               `json:"payee_id"`
-	Amount     beans.Amount           `json:"amount"`
-	Date       beans.Date             `json:"date"`
-	Notes      beans.TransactionNotes `json:"notes"`
+	AccountID  beans.ID                `json:"account_id"`
+	CategoryID beans.ID                `json:"category_id"`
+	PayeeID    beans.ID                `json:"payee_id"`
+	Amount     beans.Amount            `json:"amount"`
+	Date       beans.Date              `json:"date"`
+	Notes      beans.TransactionNotes  `json:"notes"`
+	Status     beans.TransactionStatus `json:"status"`
 
-	Splits []Split `json:"splits"`
+	Splits []Split    `json:"splits"`
+	TagIDs []beans.ID `json:"tag_ids"`
 
 	TransferAccountID beans.ID `json:"transferAccountID"`
 }
 
 type UpdateTransaction struct {
-	AccountID  beans.ID               `json:"account_id"`
-	CategoryID beans.ID               `json:"category_id"`
-	PayeeID    beans.ID               `json:"payee_id"`
-	Amount     beans.Amount           `json:"amount"`
-	Date       beans.Date             `json:"date"`
-	Notes      beans.TransactionNotes `json:"notes"`
-
-	Splits []Split `json:"splits"`
+	AccountID  beans.ID                `json:"account_id"`
+	CategoryID beans.ID                `json:"category_id"`
+	PayeeID    beans.ID                `json:"payee_id"`
+	Amount     beans.Amount            `json:"amount"`
+	Date       beans.Date              `json:"date"`
+	Notes      beans.TransactionNotes  `json:"notes"`
+	Status     beans.TransactionStatus `json:"status"`
+
+	Splits []Split    `json:"splits"`
+	TagIDs []beans.ID `json:"tag_ids"`
 }
 
 type Split struct {
 	CategoryID beans.ID               `json:"category_id"`
 	Amount     beans.Amount           `json:"amount"`
 	Notes      beans.TransactionNotes `json:"notes"`
+	TagIDs     []beans.ID             `json:"tag_ids"`
+}
+
+// Fields that are missing or null are not changed.
+type BulkUpdateTransactions struct {
+	IDs []beans.ID `json:"ids"`
+
+	AccountID  beans.Optional[beans.ID]               `json:"account_id"`
+	CategoryID beans.Optional[beans.ID]               `json:"category_id"`
+	PayeeID    beans.Optional[beans.ID]               `json:"payee_id"`
+	Date       beans.Optional[beans.Date]             `json:"date"`
+	Notes      beans.Optional[beans.TransactionNotes] `json:"notes"`
 }
 
 type DeleteTransaction struct {
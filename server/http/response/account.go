@@ -9,19 +9,39 @@ type AssociatedAccount struct {
 }
 
 type Account struct {
-	ID        beans.ID `json:"id"`
-	Name      string   `json:"name"`
-	OffBudget bool     `json:"offBudget"`
+	ID                beans.ID          `json:"id"`
+	Name              string            `json:"name"`
+	Type              beans.AccountType `json:"type"`
+	OffBudget         bool              `json:"offBudget"`
+	PaymentCategoryID beans.ID          `json:"paymentCategoryID"`
+	Closed            bool              `json:"closed"`
 }
 
 type ListAccount struct {
-	ID        beans.ID     `json:"id"`
-	Name      string       `json:"name"`
+	ID                beans.ID          `json:"id"`
+	Name              string            `json:"name"`
+	Balance           beans.Amount      `json:"balance"`
+	ClearedBalance    beans.Amount      `json:"clearedBalance"`
+	UnclearedBalance  beans.Amount      `json:"unclearedBalance"`
+	Type              beans.AccountType `json:"type"`
+	OffBudget         bool              `json:"offBudget"`
+	PaymentCategoryID beans.ID          `json:"paymentCategoryID"`
+	Closed            bool              `json:"closed"`
+}
+
+type ReconcileAccount struct {
+	AdjustmentID beans.ID `json:"adjustmentID"`
+}
+
+type AccountBalance struct {
+	AccountID beans.ID     `json:"accountID"`
+	Date      beans.Date   `json:"date"`
 	Balance   beans.Amount `json:"balance"`
-	OffBudget bool         `json:"offBudget"`
 }
 
 type CreateAccountResponse Data[ID]
 type ListAccountResponse Data[[]ListAccount]
 type GetAccountResponse Data[Account]
 type GetTransactableAccounts Data[[]Account]
+type ReconcileAccountResponse Data[ReconcileAccount]
+type ListAccountBalancesResponse Data[[]AccountBalance]
@@ -0,0 +1,13 @@
+package response
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type Attachment struct {
+	ID       beans.ID   `json:"id"`
+	FileName beans.Name `json:"fileName"`
+	MimeType string     `json:"mimeType"`
+	Size     int64      `json:"size"`
+}
+
+type CreateAttachmentResponse Data[ID]
+type ListAttachmentsResponse Data[[]Attachment]
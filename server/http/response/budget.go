@@ -3,8 +3,9 @@ package response
 import "github.com/bradenrayhorn/beans/server/beans"
 
 type Budget struct {
-	ID   beans.ID   `json:"id"`
-	Name beans.Name `json:"name"`
+	ID                   beans.ID                   `json:"id"`
+	Name                 beans.Name                 `json:"name"`
+	OverspendingRollover beans.OverspendingRollover `json:"overspendingRollover"`
 }
 
 type CreateBudgetResponse Data[ID]
@@ -8,16 +8,35 @@ type AssociatedCategory struct {
 }
 
 type Category struct {
-	ID      beans.ID   `json:"id"`
-	Name    beans.Name `json:"name"`
-	GroupID beans.ID   `json:"groupId"`
+	ID        beans.ID      `json:"id"`
+	Name      beans.Name    `json:"name"`
+	GroupID   beans.ID      `json:"groupId"`
+	SortOrder int           `json:"sortOrder"`
+	Hidden    bool          `json:"hidden"`
+	Goal      *CategoryGoal `json:"goal"`
+}
+
+type CategoryGoal struct {
+	Type        beans.GoalType `json:"type"`
+	Amount      beans.Amount   `json:"amount"`
+	TargetMonth beans.Date     `json:"targetMonth"`
+}
+
+type CategoryGoalProgress struct {
+	Goal        CategoryGoal `json:"goal"`
+	Target      beans.Amount `json:"target"`
+	Percent     int          `json:"percent"`
+	Underfunded beans.Amount `json:"underfunded"`
 }
 
 type CategoryGroup struct {
-	ID         beans.ID             `json:"id"`
-	Name       beans.Name           `json:"name"`
-	IsIncome   bool                 `json:"isIncome"`
-	Categories []AssociatedCategory `json:"categories"`
+	ID                   beans.ID   `json:"id"`
+	Name                 beans.Name `json:"name"`
+	IsIncome             bool       `json:"isIncome"`
+	IsCreditCardPayments bool       `json:"isCreditCardPayments"`
+	SortOrder            int        `json:"sortOrder"`
+	Hidden               bool       `json:"hidden"`
+	Categories           []Category `json:"categories"`
 }
 
 type CreateCategoryResponse Data[ID]
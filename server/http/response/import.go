@@ -0,0 +1,29 @@
+package response
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type ImportPreviewRow struct {
+	Date      beans.Date             `json:"date"`
+	Amount    beans.Amount           `json:"amount"`
+	PayeeName beans.Name             `json:"payeeName"`
+	Payee     *AssociatedPayee       `json:"payee"`
+	Notes     beans.TransactionNotes `json:"notes"`
+	ImportID  beans.NullString       `json:"importId"`
+	Duplicate bool                   `json:"duplicate"`
+}
+
+type ImportResult struct {
+	TransactionIDs []beans.ID `json:"transactionIds"`
+	Duplicates     int        `json:"duplicates"`
+}
+
+type ExportedFile struct {
+	Account AssociatedAccount `json:"account"`
+	File    string            `json:"file"`
+}
+
+type PreviewImportResponse Data[[]ImportPreviewRow]
+
+type ImportResponse Data[ImportResult]
+
+type ExportResponse Data[[]ExportedFile]
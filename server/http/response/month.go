@@ -1,13 +1,18 @@
 package response
 
-import "github.com/bradenrayhorn/beans/server/beans"
+import (
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
 
 type MonthCategory struct {
-	ID         beans.ID     `json:"id"`
-	Assigned   beans.Amount `json:"assigned"`
-	Activity   beans.Amount `json:"activity"`
-	Available  beans.Amount `json:"available"`
-	CategoryID beans.ID     `json:"categoryId"`
+	ID         beans.ID              `json:"id"`
+	Assigned   beans.Amount          `json:"assigned"`
+	Activity   beans.Amount          `json:"activity"`
+	Available  beans.Amount          `json:"available"`
+	CategoryID beans.ID              `json:"categoryId"`
+	Goal       *CategoryGoalProgress `json:"goal"`
 }
 type Month struct {
 	ID          beans.ID        `json:"id"`
@@ -17,7 +22,31 @@ type Month struct {
 	Income      beans.Amount    `json:"income"`
 	Assigned    beans.Amount    `json:"assigned"`
 	CarriedOver beans.Amount    `json:"carriedOver"`
+	Underfunded beans.Amount    `json:"underfunded"`
+	Overspent   beans.Amount    `json:"overspent"`
 	Categories  []MonthCategory `json:"categories"`
 }
 
 type GetMonthResponse Data[Month]
+
+type ListMonthsResponse Data[[]Month]
+
+type MoneyMovement struct {
+	ID             beans.ID     `json:"id"`
+	FromCategoryID beans.ID     `json:"fromCategoryId"`
+	ToCategoryID   beans.ID     `json:"toCategoryId"`
+	Amount         beans.Amount `json:"amount"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
+
+type GetMoneyMovementsResponse Data[[]MoneyMovement]
+
+type MonthSnapshotMismatch struct {
+	Date       beans.MonthDate `json:"date"`
+	CategoryID beans.ID        `json:"categoryId"`
+	Field      string          `json:"field"`
+	Snapshot   beans.Amount    `json:"snapshot"`
+	Recomputed beans.Amount    `json:"recomputed"`
+}
+
+type CheckMonthSnapshotsResponse Data[[]MonthSnapshotMismatch]
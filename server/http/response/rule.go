@@ -0,0 +1,27 @@
+package response
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type Rule struct {
+	ID            beans.ID         `json:"id"`
+	Name          beans.Name       `json:"name"`
+	PayeeContains beans.NullString `json:"payeeContains"`
+	MinAmount     beans.Amount     `json:"minAmount"`
+	MaxAmount     beans.Amount     `json:"maxAmount"`
+
+	PayeeID     beans.ID               `json:"payeeID"`
+	CategoryID  beans.ID               `json:"categoryID"`
+	AppendNotes beans.TransactionNotes `json:"appendNotes"`
+}
+
+type RuleChange struct {
+	Transaction Transaction            `json:"transaction"`
+	PayeeID     beans.ID               `json:"payeeID"`
+	CategoryID  beans.ID               `json:"categoryID"`
+	Notes       beans.TransactionNotes `json:"notes"`
+}
+
+type CreateRuleResponse Data[ID]
+type GetRuleResponse Data[Rule]
+type ListRulesResponse Data[[]Rule]
+type RunRulesResponse Data[[]RuleChange]
@@ -0,0 +1,39 @@
+package response
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type ScheduledTransaction struct {
+	ID                beans.ID                `json:"id"`
+	AccountID         beans.ID                `json:"accountID"`
+	CategoryID        beans.ID                `json:"categoryID"`
+	PayeeID           beans.ID                `json:"payeeID"`
+	TransferAccountID beans.ID                `json:"transferAccountID"`
+	Amount            beans.Amount            `json:"amount"`
+	Notes             beans.TransactionNotes  `json:"notes"`
+	Splits            []ScheduledSplit        `json:"splits"`
+	Frequency         beans.ScheduleFrequency `json:"frequency"`
+	Interval          int                     `json:"interval"`
+	StartDate         beans.Date              `json:"startDate"`
+	NextDate          beans.Date              `json:"nextDate"`
+}
+
+type ScheduledSplit struct {
+	CategoryID beans.ID               `json:"categoryID"`
+	Amount     beans.Amount           `json:"amount"`
+	Notes      beans.TransactionNotes `json:"notes"`
+}
+
+type PostedTransactions struct {
+	TransactionIDs []beans.ID    `json:"transactionIDs"`
+	Failed         []PostFailure `json:"failed"`
+}
+
+type PostFailure struct {
+	ScheduledTransactionID beans.ID `json:"scheduledTransactionID"`
+	Error                  string   `json:"error"`
+}
+
+type CreateScheduledTransactionResponse Data[ID]
+type GetScheduledTransactionResponse Data[ScheduledTransaction]
+type ListScheduledTransactionsResponse Data[[]ScheduledTransaction]
+type PostScheduledTransactionsResponse Data[PostedTransactions]
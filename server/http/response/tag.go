@@ -0,0 +1,17 @@
+package response
+
+import "github.com/bradenrayhorn/beans/server/beans"
+
+type AssociatedTag struct {
+	ID   beans.ID   `json:"id"`
+	Name beans.Name `json:"name"`
+}
+
+type Tag struct {
+	ID   beans.ID   `json:"id"`
+	Name beans.Name `json:"name"`
+}
+
+type CreateTagResponse Data[ID]
+type GetTagResponse Data[Tag]
+type ListTagsResponse Data[[]Tag]
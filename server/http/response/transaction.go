@@ -11,9 +11,12 @@ type Transaction struct {
 	Amount   beans.Amount             `json:"amount"`
 	Date     beans.Date               `json:"date"`
 	Notes    beans.TransactionNotes   `json:"notes"`
+	Status   beans.TransactionStatus  `json:"status"`
 
 	TransferID      beans.ID           `json:"transferID"`
 	TransferAccount *AssociatedAccount `json:"transferAccount"`
+
+	Tags []AssociatedTag `json:"tags"`
 }
 
 type Split struct {
@@ -21,11 +24,17 @@ type Split struct {
 	Category AssociatedCategory     `json:"category"`
 	Amount   beans.Amount           `json:"amount"`
 	Notes    beans.TransactionNotes `json:"notes"`
+	Tags     []AssociatedTag        `json:"tags"`
 }
 
 type CreateTransactionResponse Data[ID]
 
-type ListTransactionsResponse Data[[]Transaction]
+type ListTransactionsResponse struct {
+	Data       []Transaction    `json:"data"`
+	NextCursor beans.NullString `json:"nextCursor"`
+}
+
+type SearchTransactionsResponse Data[[]Transaction]
 
 type GetTransactionResponse Data[Transaction]
 
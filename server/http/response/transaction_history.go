@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type TransactionHistory struct {
+	ID            beans.ID                       `json:"id"`
+	TransactionID beans.ID                       `json:"transactionID"`
+	SplitID       beans.ID                       `json:"splitID"`
+	User          User                           `json:"user"`
+	Action        beans.TransactionHistoryAction `json:"action"`
+	CreatedAt     time.Time                      `json:"createdAt"`
+
+	Before *TransactionHistoryValues `json:"before"`
+	After  *TransactionHistoryValues `json:"after"`
+}
+
+type TransactionHistoryValues struct {
+	AccountID  beans.ID                `json:"accountID"`
+	CategoryID beans.ID                `json:"categoryID"`
+	PayeeID    beans.ID                `json:"payeeID"`
+	Amount     beans.Amount            `json:"amount"`
+	Date       beans.Date              `json:"date"`
+	Notes      beans.TransactionNotes  `json:"notes"`
+	Status     beans.TransactionStatus `json:"status"`
+}
+
+type GetTransactionHistoryResponse Data[[]TransactionHistory]
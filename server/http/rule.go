@@ -0,0 +1,162 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+func responseFromRule(rule beans.Rule) response.Rule {
+	return response.Rule{
+		ID:            rule.ID,
+		Name:          rule.Name,
+		PayeeContains: rule.PayeeContains,
+		MinAmount:     rule.MinAmount,
+		MaxAmount:     rule.MaxAmount,
+		PayeeID:       rule.PayeeID,
+		CategoryID:    rule.CategoryID,
+		AppendNotes:   rule.AppendNotes,
+	}
+}
+
+func ruleParamsFromRequest(req request.Rule) beans.RuleParams {
+	return beans.RuleParams{
+		Name: req.Name,
+		RuleConditions: beans.RuleConditions{
+			PayeeContains: req.PayeeContains,
+			MinAmount:     req.MinAmount,
+			MaxAmount:     req.MaxAmount,
+		},
+		RuleActions: beans.RuleActions{
+			PayeeID:     req.PayeeID,
+			CategoryID:  req.CategoryID,
+			AppendNotes: req.AppendNotes,
+		},
+	}
+}
+
+func (s *Server) handleRuleCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.Rule
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := s.contracts.Rule.Create(r.Context(), getBudgetAuth(r), ruleParamsFromRequest(req))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.CreateRuleResponse{
+			Data: response.ID{ID: id},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleRuleUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.Rule
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "ruleID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Rule.Update(r.Context(), getBudgetAuth(r), beans.RuleUpdateParams{
+			ID:         id,
+			RuleParams: ruleParamsFromRequest(req),
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleRuleDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "ruleID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Rule.Delete(r.Context(), getBudgetAuth(r), id); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleRuleGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "ruleID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		rule, err := s.contracts.Rule.Get(r.Context(), getBudgetAuth(r), id)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.GetRuleResponse{Data: responseFromRule(rule)}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleRuleGetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rules, err := s.contracts.Rule.GetAll(r.Context(), getBudgetAuth(r))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ListRulesResponse{Data: make([]response.Rule, len(rules))}
+		for i, rule := range rules {
+			res.Data[i] = responseFromRule(rule)
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func (s *Server) handleRuleRun() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.RunRules
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		changes, err := s.contracts.Rule.Run(r.Context(), getBudgetAuth(r), req.DryRun)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.RunRulesResponse{Data: make([]response.RuleChange, len(changes))}
+		for i, change := range changes {
+			res.Data[i] = response.RuleChange{
+				Transaction: responseFromTransaction(change.Transaction),
+				PayeeID:     change.PayeeID,
+				CategoryID:  change.CategoryID,
+				Notes:       change.Notes,
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
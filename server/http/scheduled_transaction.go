@@ -0,0 +1,192 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+func responseFromScheduledTransaction(scheduled beans.ScheduledTransaction) response.ScheduledTransaction {
+	splits := make([]response.ScheduledSplit, len(scheduled.Template.Splits))
+	for i, split := range scheduled.Template.Splits {
+		splits[i] = response.ScheduledSplit{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Notes:      split.Notes,
+		}
+	}
+
+	return response.ScheduledTransaction{
+		ID:                scheduled.ID,
+		AccountID:         scheduled.Template.AccountID,
+		CategoryID:        scheduled.Template.CategoryID,
+		PayeeID:           scheduled.Template.PayeeID,
+		TransferAccountID: scheduled.Template.TransferAccountID,
+		Amount:            scheduled.Template.Amount,
+		Notes:             scheduled.Template.Notes,
+		Splits:            splits,
+		Frequency:         scheduled.Rule.Frequency,
+		Interval:          scheduled.Rule.Interval,
+		StartDate:         scheduled.Rule.Start,
+		NextDate:          scheduled.NextDate,
+	}
+}
+
+func scheduledTransactionParamsFromRequest(req request.ScheduledTransaction) beans.ScheduledTransactionParams {
+	splits := make([]beans.SplitParams, len(req.Splits))
+	for i, s := range req.Splits {
+		splits[i] = beans.SplitParams{
+			Amount:     s.Amount,
+			CategoryID: s.CategoryID,
+			Notes:      s.Notes,
+			TagIDs:     s.TagIDs,
+		}
+	}
+
+	return beans.ScheduledTransactionParams{
+		Template: beans.TransactionCreateParams{
+			TransferAccountID: req.TransferAccountID,
+			TransactionParams: beans.TransactionParams{
+				AccountID:  req.AccountID,
+				CategoryID: req.CategoryID,
+				PayeeID:    req.PayeeID,
+				Amount:     req.Amount,
+				Date:       req.Date,
+				Notes:      req.Notes,
+				Splits:     splits,
+				Status:     req.Status,
+				TagIDs:     req.TagIDs,
+			},
+		},
+		Frequency: req.Frequency,
+		Interval:  req.Interval,
+	}
+}
+
+func (s *Server) handleScheduledTransactionCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ScheduledTransaction
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := s.contracts.Scheduled.Create(r.Context(), getBudgetAuth(r), scheduledTransactionParamsFromRequest(req))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.CreateScheduledTransactionResponse{
+			Data: response.ID{ID: id},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleScheduledTransactionUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.ScheduledTransaction
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "scheduledTransactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		err = s.contracts.Scheduled.Update(r.Context(), getBudgetAuth(r), beans.ScheduledTransactionUpdateParams{
+			ID:                         id,
+			ScheduledTransactionParams: scheduledTransactionParamsFromRequest(req),
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleScheduledTransactionDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "scheduledTransactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Scheduled.Delete(r.Context(), getBudgetAuth(r), id); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleScheduledTransactionGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "scheduledTransactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		scheduled, err := s.contracts.Scheduled.Get(r.Context(), getBudgetAuth(r), id)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.GetScheduledTransactionResponse{
+			Data: responseFromScheduledTransaction(scheduled),
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleScheduledTransactionGetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scheduled, err := s.contracts.Scheduled.GetAll(r.Context(), getBudgetAuth(r))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ListScheduledTransactionsResponse{Data: make([]response.ScheduledTransaction, len(scheduled))}
+		for i, it := range scheduled {
+			res.Data[i] = responseFromScheduledTransaction(it)
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func (s *Server) handleScheduledTransactionPostDue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.PostScheduledTransactions
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		result, err := s.contracts.Scheduled.PostDue(r.Context(), getBudgetAuth(r), req.Date)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		failed := make([]response.PostFailure, len(result.Failed))
+		for i, failure := range result.Failed {
+			failed[i] = response.PostFailure{
+				ScheduledTransactionID: failure.ScheduledTransactionID,
+				Error:                  failure.Error,
+			}
+		}
+
+		jsonResponse(w, response.PostScheduledTransactionsResponse{
+			Data: response.PostedTransactions{TransactionIDs: result.TransactionIDs, Failed: failed},
+		}, http.StatusOK)
+	}
+}
@@ -54,6 +54,7 @@ func NewServer(
 			r.Post("/", s.handleBudgetCreate())
 			r.Get("/", s.handleBudgetGetAll())
 			r.Get("/{budgetID}", s.handleBudgetGet())
+			r.Put("/{budgetID}", s.handleBudgetUpdate())
 		})
 
 		// endpoints that require budget header
@@ -63,19 +64,32 @@ func NewServer(
 			r.Route("/accounts", func(r chi.Router) {
 				r.Get("/", s.handleAccountsGet())
 				r.Get("/transactable", s.handleAccountsGetTransactable())
+				r.Get("/balances", s.handleAccountsGetBalances())
 
 				r.Post("/", s.handleAccountCreate())
 				r.Get("/{accountID}", s.handleAccountGet())
+				r.Put("/{accountID}", s.handleAccountUpdate())
+				r.Delete("/{accountID}", s.handleAccountDelete())
+				r.Post("/{accountID}/close", s.handleAccountClose())
+				r.Post("/{accountID}/reopen", s.handleAccountReopen())
+				r.Post("/{accountID}/reconcile", s.handleAccountReconcile())
+				r.Get("/{accountID}/balances", s.handleAccountGetBalances())
 			})
 
 			r.Route("/categories", func(r chi.Router) {
 				r.Get("/", s.handleCategoryGetAll())
 				r.Post("/", s.handleCategoryCreate())
 				r.Get("/{categoryID}", s.handleCategoryGetCategory())
+				r.Put("/{categoryID}", s.handleCategoryUpdate())
+				r.Delete("/{categoryID}", s.handleCategoryDelete())
+				r.Put("/{categoryID}/goal", s.handleCategorySetGoal())
+				r.Delete("/{categoryID}/goal", s.handleCategoryDeleteGoal())
 
 				r.Route("/groups", func(r chi.Router) {
 					r.Post("/", s.handleCategoryGroupCreate())
 					r.Get("/{categoryGroupID}", s.handleCategoryGetCategoryGroup())
+					r.Put("/{categoryGroupID}", s.handleCategoryGroupUpdate())
+					r.Delete("/{categoryGroupID}", s.handleCategoryGroupDelete())
 				})
 			})
 
@@ -83,8 +97,13 @@ func NewServer(
 				r.Route("/{monthID}", func(r chi.Router) {
 					r.Put("/", s.handleMonthUpdate())
 					r.Post("/categories", s.handleMonthCategoryUpdate())
+					r.Post("/quick-budget", s.handleMonthQuickBudget())
+					r.Post("/move-money", s.handleMonthMoveMoney())
+					r.Get("/movements", s.handleMonthGetMovements())
 				})
 
+				r.Get("/", s.handleMonthGetRange())
+				r.Get("/snapshots/check", s.handleMonthCheckSnapshots())
 				r.Get("/{date}", s.handleMonthGetOrCreate())
 			})
 
@@ -94,13 +113,52 @@ func NewServer(
 				r.Get("/{payeeID}", s.handlePayeeGet())
 			})
 
+			r.Route("/rules", func(r chi.Router) {
+				r.Get("/", s.handleRuleGetAll())
+				r.Post("/", s.handleRuleCreate())
+				r.Post("/run", s.handleRuleRun())
+				r.Get("/{ruleID}", s.handleRuleGet())
+				r.Put("/{ruleID}", s.handleRuleUpdate())
+				r.Delete("/{ruleID}", s.handleRuleDelete())
+			})
+
+			r.Route("/scheduled-transactions", func(r chi.Router) {
+				r.Get("/", s.handleScheduledTransactionGetAll())
+				r.Post("/", s.handleScheduledTransactionCreate())
+				r.Post("/post-due", s.handleScheduledTransactionPostDue())
+				r.Get("/{scheduledTransactionID}", s.handleScheduledTransactionGet())
+				r.Put("/{scheduledTransactionID}", s.handleScheduledTransactionUpdate())
+				r.Delete("/{scheduledTransactionID}", s.handleScheduledTransactionDelete())
+			})
+
+			r.Route("/tags", func(r chi.Router) {
+				r.Get("/", s.handleTagGetAll())
+				r.Post("/", s.handleTagCreate())
+				r.Get("/{tagID}", s.handleTagGet())
+				r.Put("/{tagID}", s.handleTagUpdate())
+				r.Delete("/{tagID}", s.handleTagDelete())
+			})
+
 			r.Route("/transactions", func(r chi.Router) {
 				r.Get("/", s.handleTransactionGetAll())
 				r.Post("/", s.handleTransactionCreate())
 				r.Post("/delete", s.handleTransactionDelete())
+				r.Post("/bulk-update", s.handleTransactionBulkUpdate())
+				r.Get("/search", s.handleTransactionSearch())
+				r.Post("/import/csv", s.handleImportCSV())
+				r.Post("/import/csv/preview", s.handleImportCSVPreview())
+				r.Post("/import/ofx", s.handleImportOFX())
+				r.Post("/import/ofx/preview", s.handleImportOFXPreview())
+				r.Post("/import/qif", s.handleImportQIF())
+				r.Get("/export/qif", s.handleExportQIF())
 				r.Put("/{transactionID}", s.handleTransactionUpdate())
 				r.Get("/{transactionID}", s.handleTransactionGet())
 				r.Get("/{transactionID}/splits", s.handleTransactionGetSplits())
+				r.Get("/{transactionID}/history", s.handleTransactionGetHistory())
+				r.Get("/{transactionID}/attachments", s.handleAttachmentGetAll())
+				r.Post("/{transactionID}/attachments", s.handleAttachmentCreate())
+				r.Get("/{transactionID}/attachments/{attachmentID}", s.handleAttachmentGet())
+				r.Delete("/{transactionID}/attachments/{attachmentID}", s.handleAttachmentDelete())
 			})
 
 		})
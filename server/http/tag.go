@@ -0,0 +1,122 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *Server) handleTagCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.Tag
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := s.contracts.Tag.Create(r.Context(), getBudgetAuth(r), req.Name)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.CreateTagResponse{
+			Data: response.ID{ID: id},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleTagUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.Tag
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		id, err := beans.IDFromString(chi.URLParam(r, "tagID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Tag.Update(r.Context(), getBudgetAuth(r), id, req.Name); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleTagDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "tagID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		if err := s.contracts.Tag.Delete(r.Context(), getBudgetAuth(r), id); err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleTagGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "tagID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		tag, err := s.contracts.Tag.Get(r.Context(), getBudgetAuth(r), id)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		jsonResponse(w, response.GetTagResponse{
+			Data: response.Tag{
+				ID:   tag.ID,
+				Name: tag.Name,
+			},
+		}, http.StatusOK)
+	}
+}
+
+func (s *Server) handleTagGetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tags, err := s.contracts.Tag.GetAll(r.Context(), getBudgetAuth(r))
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := make([]response.Tag, len(tags))
+		for i, tag := range tags {
+			res[i] = response.Tag{
+				ID:   tag.ID,
+				Name: tag.Name,
+			}
+		}
+
+		jsonResponse(w, response.ListTagsResponse{
+			Data: res,
+		}, http.StatusOK)
+	}
+}
+
+func responseFromTags(tags []beans.RelatedTag) []response.AssociatedTag {
+	res := make([]response.AssociatedTag, len(tags))
+	for i, tag := range tags {
+		res[i] = response.AssociatedTag{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+	return res
+}
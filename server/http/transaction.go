@@ -1,7 +1,11 @@
 package http
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/http/request"
@@ -49,7 +53,9 @@ func responseFromTransaction(transaction beans.TransactionWithRelations) respons
 		Amount:          transaction.Amount,
 		Date:            transaction.Date,
 		Notes:           transaction.Notes,
+		Status:          transaction.Status,
 		TransferAccount: transferAccount,
+		Tags:            responseFromTags(transaction.Tags),
 	}
 }
 
@@ -67,6 +73,7 @@ func (s *Server) handleTransactionCreate() http.HandlerFunc {
 				Amount:     s.Amount,
 				CategoryID: s.CategoryID,
 				Notes:      s.Notes,
+				TagIDs:     s.TagIDs,
 			}
 		}
 
@@ -80,6 +87,8 @@ func (s *Server) handleTransactionCreate() http.HandlerFunc {
 				Date:       req.Date,
 				Notes:      req.Notes,
 				Splits:     splits,
+				Status:     req.Status,
+				TagIDs:     req.TagIDs,
 			},
 		})
 
@@ -114,6 +123,7 @@ func (s *Server) handleTransactionUpdate() http.HandlerFunc {
 				Amount:     s.Amount,
 				CategoryID: s.CategoryID,
 				Notes:      s.Notes,
+				TagIDs:     s.TagIDs,
 			}
 		}
 
@@ -127,6 +137,8 @@ func (s *Server) handleTransactionUpdate() http.HandlerFunc {
 				Date:       req.Date,
 				Notes:      req.Notes,
 				Splits:     splits,
+				Status:     req.Status,
+				TagIDs:     req.TagIDs,
 			},
 		})
 
@@ -153,15 +165,70 @@ func (s *Server) handleTransactionDelete() http.HandlerFunc {
 	}
 }
 
+func (s *Server) handleTransactionBulkUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request.BulkUpdateTransactions
+		if err := decodeRequest(r, &req); err != nil {
+			Error(w, err)
+			return
+		}
+
+		err := s.contracts.Transaction.BulkUpdate(r.Context(), getBudgetAuth(r), beans.TransactionBulkUpdateParams{
+			IDs:        req.IDs,
+			AccountID:  req.AccountID,
+			CategoryID: req.CategoryID,
+			PayeeID:    req.PayeeID,
+			Date:       req.Date,
+			Notes:      req.Notes,
+		})
+		if err != nil {
+			Error(w, err)
+			return
+		}
+	}
+}
+
 func (s *Server) handleTransactionGetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		transactions, err := s.contracts.Transaction.GetAll(r.Context(), getBudgetAuth(r))
+		params, err := transactionListParamsFromQuery(r.URL.Query())
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		page, err := s.contracts.Transaction.GetAll(r.Context(), getBudgetAuth(r), params)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.ListTransactionsResponse{
+			Data:       make([]response.Transaction, len(page.Transactions)),
+			NextCursor: beans.NewNullString(page.NextCursor.String()),
+		}
+		for i, t := range page.Transactions {
+			res.Data[i] = responseFromTransaction(t)
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func (s *Server) handleTransactionSearch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := transactionSearchParamsFromQuery(r.URL.Query())
 		if err != nil {
 			Error(w, err)
 			return
 		}
 
-		res := response.ListTransactionsResponse{Data: make([]response.Transaction, len(transactions))}
+		transactions, err := s.contracts.Transaction.Search(r.Context(), getBudgetAuth(r), params)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.SearchTransactionsResponse{Data: make([]response.Transaction, len(transactions))}
 		for i, t := range transactions {
 			res.Data[i] = responseFromTransaction(t)
 		}
@@ -190,6 +257,58 @@ func (s *Server) handleTransactionGet() http.HandlerFunc {
 	}
 }
 
+func (s *Server) handleTransactionGetHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beans.IDFromString(chi.URLParam(r, "transactionID"))
+		if err != nil {
+			Error(w, beans.WrapError(err, beans.ErrorNotFound))
+			return
+		}
+
+		history, err := s.contracts.Transaction.GetHistory(r.Context(), getBudgetAuth(r), id)
+		if err != nil {
+			Error(w, err)
+			return
+		}
+
+		res := response.GetTransactionHistoryResponse{Data: make([]response.TransactionHistory, len(history))}
+		for i, h := range history {
+			res.Data[i] = response.TransactionHistory{
+				ID:            h.ID,
+				TransactionID: h.TransactionID,
+				SplitID:       h.SplitID,
+				User: response.User{
+					ID:       h.User.ID,
+					Username: string(h.User.Username),
+				},
+				Action:    h.Action,
+				CreatedAt: h.CreatedAt,
+				Before:    responseFromHistoryValues(h.Before),
+				After:     responseFromHistoryValues(h.After),
+			}
+		}
+
+		jsonResponse(w, res, http.StatusOK)
+	}
+}
+
+func responseFromHistoryValues(values beans.Optional[beans.TransactionHistoryValues]) *response.TransactionHistoryValues {
+	v, ok := values.Value()
+	if !ok {
+		return nil
+	}
+
+	return &response.TransactionHistoryValues{
+		AccountID:  v.AccountID,
+		CategoryID: v.CategoryID,
+		PayeeID:    v.PayeeID,
+		Amount:     v.Amount,
+		Date:       v.Date,
+		Notes:      v.Notes,
+		Status:     v.Status,
+	}
+}
+
 func (s *Server) handleTransactionGetSplits() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id, err := beans.IDFromString(chi.URLParam(r, "transactionID"))
@@ -211,9 +330,95 @@ func (s *Server) handleTransactionGetSplits() http.HandlerFunc {
 				Amount:   t.Amount,
 				Category: response.AssociatedCategory(t.Category),
 				Notes:    t.Notes,
+				Tags:     responseFromTags(t.Tags),
 			}
 		}
 
 		jsonResponse(w, res, http.StatusOK)
 	}
 }
+
+func transactionListParamsFromQuery(query url.Values) (beans.TransactionListParams, error) {
+	params := beans.TransactionListParams{
+		Sort: beans.TransactionSort(query.Get("sort")),
+		TransactionFilter: beans.TransactionFilter{
+			Variant: beans.TransactionVariant(query.Get("variant")),
+		},
+	}
+
+	err := decodeQuery(query, map[string]any{
+		"account_id":  &params.AccountID,
+		"category_id": &params.CategoryID,
+		"payee_id":    &params.PayeeID,
+		"tag_id":      &params.TagID,
+		"from":        &params.From,
+		"to":          &params.To,
+		"min_amount":  &params.MinAmount,
+		"max_amount":  &params.MaxAmount,
+	})
+	if err != nil {
+		return params, err
+	}
+
+	params.Limit, err = decodeQueryLimit(query)
+	if err != nil {
+		return params, err
+	}
+
+	cursor, err := beans.ParseTransactionCursor(query.Get("cursor"))
+	if err != nil {
+		return params, err
+	}
+	params.Cursor = cursor
+
+	return params, nil
+}
+
+func transactionSearchParamsFromQuery(query url.Values) (beans.TransactionSearchParams, error) {
+	params := beans.TransactionSearchParams{
+		Query: beans.NewNullString(query.Get("q")),
+	}
+
+	err := decodeQuery(query, map[string]any{
+		"account_id": &params.AccountID,
+		"from":       &params.From,
+		"to":         &params.To,
+	})
+	if err != nil {
+		return params, err
+	}
+
+	params.Limit, err = decodeQueryLimit(query)
+	return params, err
+}
+
+// Decodes query values into the targets. Values are parsed the same way as
+// they are in request bodies.
+func decodeQuery(query url.Values, values map[string]any) error {
+	for key, value := range values {
+		if !query.Has(key) {
+			continue
+		}
+		b, err := json.Marshal(query.Get(key))
+		if err == nil {
+			err = json.Unmarshal(b, value)
+		}
+		if err != nil {
+			return beans.NewError(beans.EINVALID, fmt.Sprintf("Invalid %s.", key))
+		}
+	}
+
+	return nil
+}
+
+func decodeQueryLimit(query url.Values) (int, error) {
+	if !query.Has("limit") {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		return 0, beans.NewError(beans.EINVALID, "Invalid limit.")
+	}
+	return limit, nil
+}
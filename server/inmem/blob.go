@@ -0,0 +1,55 @@
+package inmem
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+type blobStore struct {
+	blobs map[string][]byte
+	mu    sync.RWMutex
+}
+
+func NewBlobStore() *blobStore {
+	return &blobStore{
+		blobs: make(map[string][]byte),
+	}
+}
+
+func (s *blobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = b
+
+	return nil
+}
+
+func (s *blobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if b, ok := s.blobs[key]; ok {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	return nil, beans.ErrorNotFound
+}
+
+func (s *blobStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package inmem_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanPutAndGetBlob(t *testing.T) {
+	s := inmem.NewBlobStore()
+
+	require.Nil(t, s.Put(context.Background(), "key", strings.NewReader("contents")))
+
+	r, err := s.Get(context.Background(), "key")
+	require.Nil(t, err)
+	b, err := io.ReadAll(r)
+	require.Nil(t, err)
+	assert.Equal(t, "contents", string(b))
+}
+
+func TestCanDeleteBlob(t *testing.T) {
+	s := inmem.NewBlobStore()
+
+	require.Nil(t, s.Put(context.Background(), "key", strings.NewReader("contents")))
+	require.Nil(t, s.Delete(context.Background(), "key"))
+
+	_, err := s.Get(context.Background(), "key")
+	assert.ErrorIs(t, err, beans.ErrorNotFound)
+}
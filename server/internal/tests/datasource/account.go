@@ -22,9 +22,10 @@ func testAccount(t *testing.T, ds beans.DataSource) {
 			ID:        beans.NewID(),
 			BudgetID:  budget.ID,
 			Name:      beans.Name("Account1"),
+			Type:      beans.AccountSavings,
 			OffBudget: true,
 		}
-		err := accountRepository.Create(ctx, account)
+		err := accountRepository.Create(ctx, nil, account)
 		require.NoError(t, err)
 
 		res, err := accountRepository.Get(context.Background(), budget.ID, account.ID)
@@ -32,6 +33,24 @@ func testAccount(t *testing.T, ds beans.DataSource) {
 		assert.Equal(t, account, res)
 	})
 
+	t.Run("can create with payment category", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+		account := beans.Account{
+			ID:                beans.NewID(),
+			BudgetID:          budget.ID,
+			Name:              beans.Name("Card"),
+			Type:              beans.AccountCreditCard,
+			PaymentCategoryID: category.ID,
+		}
+		require.NoError(t, accountRepository.Create(ctx, nil, account))
+
+		res, err := accountRepository.Get(ctx, budget.ID, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account, res)
+	})
+
 	t.Run("cannot create duplicate account", func(t *testing.T) {
 		budget, _ := factory.MakeBudgetAndUser()
 
@@ -41,10 +60,10 @@ func testAccount(t *testing.T, ds beans.DataSource) {
 			Name:     beans.Name("Account1"),
 		}
 
-		err := accountRepository.Create(ctx, account)
+		err := accountRepository.Create(ctx, nil, account)
 		require.NoError(t, err)
 
-		err = accountRepository.Create(ctx, account)
+		err = accountRepository.Create(ctx, nil, account)
 		require.NotNil(t, err)
 	})
 
@@ -101,13 +120,30 @@ func testAccount(t *testing.T, ds beans.DataSource) {
 
 			// accounts 1 and 2 should be in the response with a balance
 			expectedAccounts := []beans.AccountWithBalance{
-				{Account: account1, Balance: beans.NewAmount(0, 0)},
-				{Account: account2, Balance: beans.NewAmount(2, 0)},
+				{Account: account1, Balance: beans.NewAmount(0, 0), ClearedBalance: beans.NewAmount(0, 0), UnclearedBalance: beans.NewAmount(0, 0)},
+				{Account: account2, Balance: beans.NewAmount(2, 0), ClearedBalance: beans.NewAmount(0, 0), UnclearedBalance: beans.NewAmount(2, 0)},
 			}
 
 			assert.ElementsMatch(t, expectedAccounts, res)
 		})
 
+		t.Run("sums cleared and uncleared", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(5, 0), Status: beans.TransactionUncleared})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(3, 0), Status: beans.TransactionCleared})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(-1, 0), Status: beans.TransactionReconciled})
+
+			res, err := accountRepository.GetWithBalance(ctx, budget.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(res))
+
+			assert.Equal(t, beans.NewAmount(7, 0), res[0].Balance)
+			assert.Equal(t, beans.NewAmount(2, 0), res[0].ClearedBalance)
+			assert.Equal(t, beans.NewAmount(5, 0), res[0].UnclearedBalance)
+		})
+
 		t.Run("includes off budget accounts", func(t *testing.T) {
 			budget, _ := factory.MakeBudgetAndUser()
 
@@ -172,6 +208,167 @@ func testAccount(t *testing.T, ds beans.DataSource) {
 			assert.Equal(t, 1, len(res))
 			assert.Equal(t, account, res[0])
 		})
+
+		t.Run("excludes closed", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			account.Closed = true
+			require.NoError(t, accountRepository.Update(ctx, nil, account))
+
+			res, err := accountRepository.GetTransactable(ctx, budget.ID)
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, len(res))
+		})
+	})
+
+	t.Run("get for budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+		account.Closed = true
+		require.NoError(t, accountRepository.Update(ctx, nil, account))
+		factory.Account(beans.Account{BudgetID: budget2.ID})
+
+		res, err := accountRepository.GetForBudget(ctx, budget.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.Account{account}, res)
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		require.NoError(t, accountRepository.Delete(ctx, nil, budget.ID, account.ID))
+
+		_, err := accountRepository.Get(ctx, budget.ID, account.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("cannot delete account for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		require.NoError(t, accountRepository.Delete(ctx, nil, budget2.ID, account.ID))
+
+		_, err := accountRepository.Get(ctx, budget.ID, account.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("can move transactions", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		from := factory.Account(beans.Account{BudgetID: budget.ID})
+		to := factory.Account(beans.Account{BudgetID: budget.ID})
+		transaction := factory.Transaction(budget.ID, beans.Transaction{AccountID: from.ID})
+
+		require.NoError(t, accountRepository.MoveTransactions(ctx, nil, budget.ID, from.ID, to.ID))
+
+		res, err := ds.TransactionRepository().Get(ctx, budget.ID, transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, to.ID, res.AccountID)
+	})
+
+	t.Run("move transactions clears import IDs already on account", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		from := factory.Account(beans.Account{BudgetID: budget.ID})
+		to := factory.Account(beans.Account{BudgetID: budget.ID})
+		colliding := factory.Transaction(budget.ID, beans.Transaction{AccountID: from.ID, ImportID: beans.NewNullString("fitid-1")})
+		unique := factory.Transaction(budget.ID, beans.Transaction{AccountID: from.ID, ImportID: beans.NewNullString("fitid-2")})
+		existing := factory.Transaction(budget.ID, beans.Transaction{AccountID: to.ID, ImportID: beans.NewNullString("fitid-1")})
+
+		require.NoError(t, accountRepository.MoveTransactions(ctx, nil, budget.ID, from.ID, to.ID))
+
+		res, err := ds.TransactionRepository().Get(ctx, budget.ID, colliding.ID)
+		require.NoError(t, err)
+		assert.Equal(t, to.ID, res.AccountID)
+		assert.True(t, res.ImportID.Empty())
+
+		res, err = ds.TransactionRepository().Get(ctx, budget.ID, unique.ID)
+		require.NoError(t, err)
+		assert.Equal(t, to.ID, res.AccountID)
+		assert.Equal(t, "fitid-2", res.ImportID.String())
+
+		res, err = ds.TransactionRepository().Get(ctx, budget.ID, existing.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "fitid-1", res.ImportID.String())
+	})
+
+	t.Run("cannot move transactions to account in other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		from := factory.Account(beans.Account{BudgetID: budget.ID})
+		to := factory.Account(beans.Account{BudgetID: budget2.ID})
+		transaction := factory.Transaction(budget.ID, beans.Transaction{AccountID: from.ID})
+
+		require.NoError(t, accountRepository.MoveTransactions(ctx, nil, budget.ID, from.ID, to.ID))
+
+		res, err := ds.TransactionRepository().Get(ctx, budget.ID, transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, from.ID, res.AccountID)
+	})
+
+	t.Run("get balance", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+		empty := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(3, 0)})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(-125, -2)})
+
+		res, err := accountRepository.GetBalance(ctx, nil, budget.ID, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, beans.NewAmount(175, -2), res)
+
+		res, err = accountRepository.GetBalance(ctx, nil, budget.ID, empty.ID)
+		require.NoError(t, err)
+		assert.Equal(t, beans.NewAmount(0, 0), res)
+
+		// other budgets have nothing on the account
+		res, err = accountRepository.GetBalance(ctx, nil, budget2.ID, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, beans.NewAmount(0, 0), res)
+	})
+
+	t.Run("get balances", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+		other := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(3, 0), Date: testutils.NewDate(t, "2022-03-31")})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Amount: beans.NewAmount(-125, -2), Date: testutils.NewDate(t, "2022-05-01")})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: other.ID, Amount: beans.NewAmount(9, 0), Date: testutils.NewDate(t, "2022-05-01")})
+
+		res, err := accountRepository.GetBalances(ctx, budget.ID, beans.AccountBalanceParams{
+			AccountID: account.ID,
+			Interval:  beans.BalanceMonthly,
+			From:      testutils.NewDate(t, "2022-04-01"),
+			To:        testutils.NewDate(t, "2022-05-31"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.AccountBalance{
+			{AccountID: account.ID, Date: testutils.NewDate(t, "2022-04-01"), Balance: beans.NewAmount(3, 0)},
+			{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-01"), Balance: beans.NewAmount(175, -2)},
+		}, res)
+	})
+
+	t.Run("can update", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		account.Name = "Savings"
+		account.OffBudget = true
+		account.Closed = true
+		require.NoError(t, accountRepository.Update(ctx, nil, account))
+
+		res, err := accountRepository.Get(ctx, budget.ID, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account, res)
 	})
 
 }
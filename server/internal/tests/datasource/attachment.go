@@ -0,0 +1,116 @@
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttachment(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+
+	attachmentRepository := ds.AttachmentRepository()
+	ctx := context.Background()
+
+	makeAttachment := func(transactionID beans.ID) beans.Attachment {
+		attachment := beans.Attachment{
+			ID:            beans.NewID(),
+			TransactionID: transactionID,
+			FileName:      "receipt.png",
+			MimeType:      "image/png",
+			Size:          1024,
+		}
+		require.Nil(t, attachmentRepository.Create(ctx, attachment))
+		return attachment
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment := makeAttachment(transaction.ID)
+
+		res, err := attachmentRepository.Get(ctx, budget.ID, attachment.ID)
+		require.Nil(t, err)
+		assert.Equal(t, attachment, res)
+	})
+
+	t.Run("cannot get for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment := makeAttachment(transaction.ID)
+
+		_, err := attachmentRepository.Get(ctx, budget2.ID, attachment.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can get for transaction in order", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment1 := makeAttachment(transaction.ID)
+		attachment2 := makeAttachment(transaction.ID)
+		makeAttachment(factory.Transaction(budget.ID, beans.Transaction{}).ID)
+
+		res, err := attachmentRepository.GetForTransaction(ctx, budget.ID, transaction.ID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.Attachment{attachment1, attachment2}, res)
+
+		res, err = attachmentRepository.GetForTransaction(ctx, budget2.ID, transaction.ID)
+		require.Nil(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("can get for transactions and transfers", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		transferA := factory.Transaction(budget.ID, beans.Transaction{})
+		transferB := factory.Transaction(budget.ID, beans.Transaction{TransferID: transferA.ID})
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+
+		attachment1 := makeAttachment(transferB.ID)
+		attachment2 := makeAttachment(transaction.ID)
+		makeAttachment(factory.Transaction(budget.ID, beans.Transaction{}).ID)
+
+		res, err := attachmentRepository.GetForTransactions(ctx, budget.ID, []beans.ID{transferA.ID, transaction.ID})
+		require.Nil(t, err)
+		assert.Equal(t, []beans.Attachment{attachment1, attachment2}, res)
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment := makeAttachment(transaction.ID)
+
+		require.Nil(t, attachmentRepository.Delete(ctx, budget.ID, attachment.ID))
+
+		_, err := attachmentRepository.Get(ctx, budget.ID, attachment.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("cannot delete for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment := makeAttachment(transaction.ID)
+
+		require.Nil(t, attachmentRepository.Delete(ctx, budget2.ID, attachment.ID))
+
+		_, err := attachmentRepository.Get(ctx, budget.ID, attachment.ID)
+		require.Nil(t, err)
+	})
+
+	t.Run("deleted with transaction", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		transaction := factory.Transaction(budget.ID, beans.Transaction{})
+		attachment := makeAttachment(transaction.ID)
+
+		require.Nil(t, ds.TransactionRepository().Delete(ctx, nil, budget.ID, []beans.ID{transaction.ID}))
+
+		_, err := attachmentRepository.Get(ctx, budget.ID, attachment.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+}
@@ -58,6 +58,18 @@ func testBudget(t *testing.T, ds beans.DataSource) {
 		assert.ElementsMatch(t, ids, []beans.ID{user.ID})
 	})
 
+	t.Run("can update", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+
+		budget.Name = "Renamed"
+		budget.OverspendingRollover = beans.OverspendingResetCreditAsDebt
+		require.NoError(t, budgetRepository.Update(ctx, budget))
+
+		res, err := budgetRepository.Get(ctx, budget.ID)
+		require.NoError(t, err)
+		assert.Equal(t, budget, res)
+	})
+
 	t.Run("get for user", func(t *testing.T) {
 		budget, user := factory.MakeBudgetAndUser()
 		factory.MakeBudgetAndUser() // this budget should not be in the result
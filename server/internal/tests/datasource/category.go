@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/internal/testutils"
@@ -159,4 +160,194 @@ func testCategory(t *testing.T, ds beans.DataSource) {
 			testutils.IsEqualInAnyOrder(t, []beans.Category{category}, res, testutils.CmpCategory)
 		})
 	})
+
+	t.Run("can update", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		group := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID})
+
+		category.GroupID = group.ID
+		category.Name = "Water"
+		category.SortOrder = 3
+		category.Hidden = true
+		require.Nil(t, categoryRepository.Update(ctx, nil, category))
+
+		res, err := categoryRepository.GetSingleForBudget(ctx, category.ID, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, category, res)
+	})
+
+	t.Run("can update goal", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+		category.Goal = beans.OptionalWrap(beans.CategoryGoal{
+			Type:        beans.GoalTargetBalanceByDate,
+			Amount:      beans.NewAmount(1025, -2),
+			TargetMonth: testutils.NewMonthDate(t, "2022-09-01"),
+		})
+		require.Nil(t, categoryRepository.Update(ctx, nil, category))
+
+		res, err := categoryRepository.GetSingleForBudget(ctx, category.ID, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, category, res)
+
+		category.Goal = beans.Optional[beans.CategoryGoal]{}
+		require.Nil(t, categoryRepository.Update(ctx, nil, category))
+
+		res, err = categoryRepository.GetSingleForBudget(ctx, category.ID, budget.ID)
+		require.Nil(t, err)
+		assert.True(t, res.Goal.Empty())
+	})
+
+	t.Run("can update group", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		group := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID})
+
+		group.Name = "Bills"
+		group.SortOrder = 2
+		group.Hidden = true
+		require.Nil(t, categoryRepository.UpdateGroup(ctx, nil, group))
+
+		res, err := categoryRepository.GetCategoryGroup(ctx, group.ID, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, group, res)
+	})
+
+	t.Run("lists in sort order", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		group1 := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID, SortOrder: 1})
+		group2 := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID, SortOrder: 0})
+		category1 := factory.Category(beans.Category{BudgetID: budget.ID, GroupID: group1.ID, SortOrder: 1})
+		category2 := factory.Category(beans.Category{BudgetID: budget.ID, GroupID: group1.ID, SortOrder: 0})
+
+		groups, err := categoryRepository.GetGroupsForBudget(ctx, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.CategoryGroup{group2, group1}, groups)
+
+		categories, err := categoryRepository.GetCategoriesForGroup(ctx, group1.ID, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.Category{category2, category1}, categories)
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+		require.Nil(t, categoryRepository.Delete(ctx, nil, budget.ID, category.ID))
+
+		_, err := categoryRepository.GetSingleForBudget(ctx, category.ID, budget.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("cannot delete for another budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		group := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID})
+
+		require.Nil(t, categoryRepository.Delete(ctx, nil, budget2.ID, category.ID))
+		require.Nil(t, categoryRepository.DeleteGroup(ctx, nil, budget2.ID, group.ID))
+
+		_, err := categoryRepository.GetSingleForBudget(ctx, category.ID, budget.ID)
+		require.Nil(t, err)
+		_, err = categoryRepository.GetCategoryGroup(ctx, group.ID, budget.ID)
+		require.Nil(t, err)
+	})
+
+	t.Run("can delete group", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		group := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID})
+
+		require.Nil(t, categoryRepository.DeleteGroup(ctx, nil, budget.ID, group.ID))
+
+		_, err := categoryRepository.GetCategoryGroup(ctx, group.ID, budget.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		t.Run("moves transactions and rules", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			from := factory.Category(beans.Category{BudgetID: budget.ID})
+			to := factory.Category(beans.Category{BudgetID: budget.ID})
+			transaction := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, CategoryID: from.ID})
+			rule := beans.Rule{
+				ID:             beans.NewID(),
+				BudgetID:       budget.ID,
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+				RuleActions:    beans.RuleActions{CategoryID: from.ID},
+			}
+			require.Nil(t, ds.RuleRepository().Create(ctx, rule))
+			month := factory.Month(beans.Month{BudgetID: budget.ID})
+			movement := beans.MoneyMovement{
+				ID:             beans.NewID(),
+				MonthID:        month.ID,
+				FromCategoryID: from.ID,
+				Amount:         beans.NewAmount(1, 0),
+				CreatedAt:      time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC),
+			}
+			require.Nil(t, ds.MonthCategoryRepository().CreateMovement(ctx, nil, movement))
+
+			require.Nil(t, categoryRepository.Reassign(ctx, nil, budget.ID, from.ID, to.ID))
+
+			res, err := ds.TransactionRepository().Get(ctx, budget.ID, transaction.ID)
+			require.Nil(t, err)
+			assert.Equal(t, to.ID, res.CategoryID)
+
+			resRule, err := ds.RuleRepository().Get(ctx, budget.ID, rule.ID)
+			require.Nil(t, err)
+			assert.Equal(t, to.ID, resRule.CategoryID)
+
+			movements, err := ds.MonthCategoryRepository().GetMovementsForMonth(ctx, month)
+			require.Nil(t, err)
+			movement.FromCategoryID = to.ID
+			assert.Equal(t, []beans.MoneyMovement{movement}, movements)
+		})
+
+		t.Run("merges month categories", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			from := factory.Category(beans.Category{BudgetID: budget.ID})
+			to := factory.Category(beans.Category{BudgetID: budget.ID})
+			month1 := factory.Month(beans.Month{BudgetID: budget.ID, Date: beans.NewMonthDate(testutils.NewDate(t, "2022-04-01"))})
+			month2 := factory.Month(beans.Month{BudgetID: budget.ID, Date: beans.NewMonthDate(testutils.NewDate(t, "2022-05-01"))})
+
+			// both have an amount in the first month, only the old category in the second
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: month1.ID, CategoryID: from.ID, Amount: beans.NewAmount(525, -2)})
+			toMonthCategory := factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: month1.ID, CategoryID: to.ID, Amount: beans.NewAmount(125, -2)})
+			fromMonthCategory := factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: month2.ID, CategoryID: from.ID, Amount: beans.NewAmount(3, 0)})
+
+			require.Nil(t, categoryRepository.Reassign(ctx, nil, budget.ID, from.ID, to.ID))
+			require.Nil(t, categoryRepository.Delete(ctx, nil, budget.ID, from.ID))
+
+			res, err := ds.MonthCategoryRepository().GetForMonth(ctx, month1)
+			require.Nil(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, toMonthCategory.ID, res[0].ID)
+			assert.Equal(t, beans.NewAmount(65, -1), res[0].Amount)
+
+			res, err = ds.MonthCategoryRepository().GetForMonth(ctx, month2)
+			require.Nil(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, fromMonthCategory.ID, res[0].ID)
+			assert.Equal(t, to.ID, res[0].CategoryID)
+			assert.Equal(t, beans.NewAmount(3, 0), res[0].Amount)
+		})
+
+		t.Run("ignores categories of another budget", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			budget2, _ := factory.MakeBudgetAndUser()
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			from := factory.Category(beans.Category{BudgetID: budget.ID})
+			to := factory.Category(beans.Category{BudgetID: budget2.ID})
+			transaction := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, CategoryID: from.ID})
+
+			require.Nil(t, categoryRepository.Reassign(ctx, nil, budget.ID, from.ID, to.ID))
+
+			res, err := ds.TransactionRepository().Get(ctx, budget.ID, transaction.ID)
+			require.Nil(t, err)
+			assert.Equal(t, from.ID, res.CategoryID)
+		})
+	})
 }
@@ -9,11 +9,17 @@ import (
 func DoTestDatasource(t *testing.T, ds beans.DataSource) {
 
 	t.Run("account", func(t *testing.T) { testAccount(t, ds) })
+	t.Run("attachment", func(t *testing.T) { testAttachment(t, ds) })
 	t.Run("budget", func(t *testing.T) { testBudget(t, ds) })
 	t.Run("category", func(t *testing.T) { testCategory(t, ds) })
 	t.Run("month", func(t *testing.T) { testMonth(t, ds) })
 	t.Run("month category", func(t *testing.T) { testMonthCategory(t, ds) })
+	t.Run("month snapshot", func(t *testing.T) { testMonthSnapshot(t, ds) })
 	t.Run("payee", func(t *testing.T) { testPayee(t, ds) })
+	t.Run("rule", func(t *testing.T) { testRule(t, ds) })
+	t.Run("scheduled transaction", func(t *testing.T) { testScheduledTransaction(t, ds) })
+	t.Run("tag", func(t *testing.T) { testTag(t, ds) })
 	t.Run("transaction", func(t *testing.T) { testTransaction(t, ds) })
+	t.Run("transaction history", func(t *testing.T) { testTransactionHistory(t, ds) })
 	t.Run("user", func(t *testing.T) { testUser(t, ds) })
 }
@@ -3,6 +3,7 @@ package datasource
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/internal/testutils"
@@ -122,7 +123,7 @@ func testMonthCategory(t *testing.T, ds beans.DataSource) {
 
 		// update amount
 		monthCategory.Amount = beans.NewAmount(5, -1)
-		require.Nil(t, monthCategoryRepository.UpdateAmount(ctx, monthCategory))
+		require.Nil(t, monthCategoryRepository.UpdateAmount(ctx, nil, monthCategory))
 
 		// get and verify amount is updated
 		res, err := monthCategoryRepository.GetOrCreate(ctx, nil, month, monthCategory.CategoryID)
@@ -131,6 +132,29 @@ func testMonthCategory(t *testing.T, ds beans.DataSource) {
 		assert.Equal(t, beans.NewAmount(5, -1), res.Amount)
 	})
 
+	t.Run("update amount respects tx", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		month := factory.Month(beans.Month{BudgetID: budget.ID})
+		monthCategory := factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: month.ID, Amount: beans.NewAmount(1, 0)})
+
+		tx, err := ds.TxManager().Create(ctx)
+		require.Nil(t, err)
+		defer testutils.MustRollback(t, tx)
+
+		monthCategory.Amount = beans.NewAmount(5, -1)
+		require.Nil(t, monthCategoryRepository.UpdateAmount(ctx, tx, monthCategory))
+
+		res, err := monthCategoryRepository.GetOrCreate(ctx, nil, month, monthCategory.CategoryID)
+		require.Nil(t, err)
+		assert.Equal(t, beans.NewAmount(1, 0), res.Amount)
+
+		require.Nil(t, tx.Commit(ctx))
+
+		res, err = monthCategoryRepository.GetOrCreate(ctx, nil, month, monthCategory.CategoryID)
+		require.Nil(t, err)
+		assert.Equal(t, beans.NewAmount(5, -1), res.Amount)
+	})
+
 	t.Run("get for month", func(t *testing.T) {
 
 		t.Run("can get for month", func(t *testing.T) {
@@ -320,4 +344,41 @@ func testMonthCategory(t *testing.T, ds beans.DataSource) {
 		require.Nil(t, err)
 		assert.Equal(t, beans.NewAmount(0, 0), amount)
 	})
+
+	t.Run("can create and get movements", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		month := factory.Month(beans.Month{BudgetID: budget.ID})
+		otherMonth := factory.Month(beans.Month{BudgetID: budget.ID})
+		category1 := factory.Category(beans.Category{BudgetID: budget.ID})
+		category2 := factory.Category(beans.Category{BudgetID: budget.ID})
+
+		movement1 := beans.MoneyMovement{
+			ID:             beans.NewID(),
+			MonthID:        month.ID,
+			FromCategoryID: category1.ID,
+			ToCategoryID:   category2.ID,
+			Amount:         beans.NewAmount(25, -1),
+			CreatedAt:      time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC),
+		}
+		movement2 := beans.MoneyMovement{
+			ID:           beans.NewID(),
+			MonthID:      month.ID,
+			ToCategoryID: category1.ID,
+			Amount:       beans.NewAmount(1, 0),
+			CreatedAt:    time.Date(2022, 1, 2, 3, 4, 6, 0, time.UTC),
+		}
+		require.Nil(t, monthCategoryRepository.CreateMovement(ctx, nil, movement1))
+		require.Nil(t, monthCategoryRepository.CreateMovement(ctx, nil, movement2))
+		require.Nil(t, monthCategoryRepository.CreateMovement(ctx, nil, beans.MoneyMovement{
+			ID:           beans.NewID(),
+			MonthID:      otherMonth.ID,
+			ToCategoryID: category1.ID,
+			Amount:       beans.NewAmount(1, 0),
+			CreatedAt:    time.Now(),
+		}))
+
+		movements, err := monthCategoryRepository.GetMovementsForMonth(ctx, month)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.MoneyMovement{movement1, movement2}, movements)
+	})
 }
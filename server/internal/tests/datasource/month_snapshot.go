@@ -0,0 +1,186 @@
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMonthSnapshot(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+	monthSnapshotRepository := ds.MonthSnapshotRepository()
+	ctx := context.Background()
+
+	createSnapshots := func(t *testing.T, budgetID beans.ID, categoryID beans.ID, dates ...string) {
+		version, err := monthSnapshotRepository.GetVersion(ctx, budgetID)
+		require.NoError(t, err)
+
+		snapshots := make([]beans.MonthSnapshot, len(dates))
+		for i, date := range dates {
+			snapshots[i] = beans.MonthSnapshot{
+				BudgetID:  budgetID,
+				Date:      testutils.NewMonthDate(t, date),
+				Overspent: beans.NewAmount(0, 0),
+				Categories: map[beans.ID]beans.MonthCategorySnapshot{
+					categoryID: {CarriedIn: beans.NewAmount(1, 0), Assigned: beans.NewAmount(2, 0), Activity: beans.NewAmount(-3, 0)},
+				},
+			}
+		}
+
+		require.NoError(t, beans.ExecTxNil(ctx, ds.TxManager(), func(tx beans.Tx) error {
+			return monthSnapshotRepository.Create(ctx, tx, budgetID, version, snapshots)
+		}))
+	}
+
+	snapshotDates := func(t *testing.T, budgetID beans.ID) []string {
+		snapshots, err := monthSnapshotRepository.GetForBudget(ctx, budgetID)
+		require.NoError(t, err)
+
+		dates := make([]string, len(snapshots))
+		for i, snapshot := range snapshots {
+			dates[i] = snapshot.Date.String()
+		}
+		return dates
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+		version, err := monthSnapshotRepository.GetVersion(ctx, budget.ID)
+		require.NoError(t, err)
+
+		snapshot := beans.MonthSnapshot{
+			BudgetID:  budget.ID,
+			Date:      testutils.NewMonthDate(t, "2022-05-01"),
+			Overspent: beans.NewAmount(4, 0),
+			Categories: map[beans.ID]beans.MonthCategorySnapshot{
+				category.ID: {
+					CarriedIn: beans.NewAmount(1, 0),
+					Assigned:  beans.NewAmount(2, 0),
+					Activity:  beans.NewAmount(-35, -1),
+				},
+			},
+		}
+		require.NoError(t, monthSnapshotRepository.Create(ctx, nil, budget.ID, version, []beans.MonthSnapshot{snapshot}))
+
+		res, err := monthSnapshotRepository.Get(ctx, budget.ID, snapshot.Date)
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, res)
+	})
+
+	t.Run("cannot get missing snapshot", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+
+		_, err := monthSnapshotRepository.Get(ctx, budget.ID, testutils.NewMonthDate(t, "2022-05-01"))
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can get latest before", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01", "2022-04-01", "2022-06-01")
+
+		res, err := monthSnapshotRepository.GetLatestBefore(ctx, budget.ID, testutils.NewMonthDate(t, "2022-06-01"))
+		require.NoError(t, err)
+		assert.Equal(t, "2022-04-01", res.Date.String())
+		assert.Len(t, res.Categories, 1)
+
+		_, err = monthSnapshotRepository.GetLatestBefore(ctx, budget.ID, testutils.NewMonthDate(t, "2022-03-01"))
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can get for budget in order", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-04-01", "2022-03-01")
+
+		assert.Equal(t, []string{"2022-03-01", "2022-04-01"}, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("does not create if version is old", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+
+		version, err := monthSnapshotRepository.GetVersion(ctx, budget.ID)
+		require.NoError(t, err)
+
+		factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-05-01")})
+
+		snapshot := beans.MonthSnapshot{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-05-01"), Overspent: beans.NewAmount(0, 0)}
+		require.NoError(t, monthSnapshotRepository.Create(ctx, nil, budget.ID, version, []beans.MonthSnapshot{snapshot}))
+
+		assert.Empty(t, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("transaction write removes snapshots from its month on", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01", "2022-04-01", "2022-05-01")
+
+		transaction := factory.Transaction(budget.ID, beans.Transaction{
+			AccountID:  account.ID,
+			CategoryID: category.ID,
+			Date:       testutils.NewDate(t, "2022-04-20"),
+		})
+		assert.Equal(t, []string{"2022-03-01"}, snapshotDates(t, budget.ID))
+
+		// moving it earlier removes from the earlier month
+		createSnapshots(t, budget.ID, category.ID, "2022-04-01")
+		transaction.Date = testutils.NewDate(t, "2022-03-02")
+		require.NoError(t, ds.TransactionRepository().Update(ctx, nil, []beans.Transaction{transaction}))
+		assert.Empty(t, snapshotDates(t, budget.ID))
+
+		createSnapshots(t, budget.ID, category.ID, "2022-02-01", "2022-03-01")
+		require.NoError(t, ds.TransactionRepository().Delete(ctx, nil, budget.ID, []beans.ID{transaction.ID}))
+		assert.Equal(t, []string{"2022-02-01"}, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("assignment removes snapshots from its month on", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		month := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01", "2022-04-01", "2022-05-01")
+
+		// assigning nothing changes nothing
+		monthCategory := factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: month.ID, CategoryID: category.ID, Amount: beans.NewAmount(0, 0)})
+		assert.Equal(t, []string{"2022-03-01", "2022-04-01", "2022-05-01"}, snapshotDates(t, budget.ID))
+
+		monthCategory.Amount = beans.NewAmount(5, 0)
+		require.NoError(t, ds.MonthCategoryRepository().UpdateAmount(ctx, nil, monthCategory))
+		assert.Equal(t, []string{"2022-03-01"}, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("new month removes snapshots from it on", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01", "2022-04-01")
+
+		factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+		assert.Equal(t, []string{"2022-03-01"}, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("rollover change removes all snapshots", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01", "2022-04-01")
+
+		budget.OverspendingRollover = beans.OverspendingReset
+		require.NoError(t, ds.BudgetRepository().Update(ctx, budget))
+		assert.Empty(t, snapshotDates(t, budget.ID))
+	})
+
+	t.Run("only removes snapshots of the budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		otherBudget, _ := factory.MakeBudgetAndUser()
+		category := factory.Category(beans.Category{BudgetID: budget.ID})
+		createSnapshots(t, budget.ID, category.ID, "2022-03-01")
+
+		factory.Month(beans.Month{BudgetID: otherBudget.ID, Date: testutils.NewMonthDate(t, "2022-01-01")})
+		assert.Equal(t, []string{"2022-03-01"}, snapshotDates(t, budget.ID))
+	})
+}
@@ -20,7 +20,7 @@ func testPayee(t *testing.T, ds beans.DataSource) {
 	t.Run("can create and get", func(t *testing.T) {
 		budget, _ := factory.MakeBudgetAndUser()
 		payee := beans.Payee{ID: beans.NewID(), Name: "payee1", BudgetID: budget.ID}
-		require.Nil(t, payeeRepository.Create(ctx, payee))
+		require.Nil(t, payeeRepository.Create(ctx, nil, payee))
 
 		res, err := payeeRepository.Get(ctx, budget.ID, payee.ID)
 		require.Nil(t, err)
@@ -31,7 +31,7 @@ func testPayee(t *testing.T, ds beans.DataSource) {
 		budget, _ := factory.MakeBudgetAndUser()
 		payee := factory.Payee(beans.Payee{BudgetID: budget.ID})
 
-		assert.NotNil(t, payeeRepository.Create(ctx, payee))
+		assert.NotNil(t, payeeRepository.Create(ctx, nil, payee))
 	})
 
 	t.Run("cannot get non existant payee", func(t *testing.T) {
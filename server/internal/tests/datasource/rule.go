@@ -0,0 +1,127 @@
+package datasource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+
+	ruleRepository := ds.RuleRepository()
+	ctx := context.Background()
+
+	makeRule := func(budgetID beans.ID) beans.Rule {
+		return beans.Rule{
+			ID:       beans.NewID(),
+			BudgetID: budgetID,
+			Name:     "Rule",
+			RuleConditions: beans.RuleConditions{
+				PayeeContains: beans.NewNullString("coffee"),
+				MinAmount:     beans.NewAmount(-1025, -2),
+				MaxAmount:     beans.NewAmount(-1, 0),
+			},
+			RuleActions: beans.RuleActions{
+				PayeeID:     factory.Payee(beans.Payee{BudgetID: budgetID}).ID,
+				CategoryID:  factory.Category(beans.Category{BudgetID: budgetID}).ID,
+				AppendNotes: beans.NewTransactionNotes("notes"),
+			},
+		}
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		rule := makeRule(budget.ID)
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		res, err := ruleRepository.Get(ctx, budget.ID, rule.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(rule, res))
+	})
+
+	t.Run("can create and get with empty fields", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		rule := beans.Rule{
+			ID:             beans.NewID(),
+			BudgetID:       budget.ID,
+			Name:           "Rule",
+			RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+			RuleActions:    beans.RuleActions{AppendNotes: beans.NewTransactionNotes("b")},
+		}
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		res, err := ruleRepository.Get(ctx, budget.ID, rule.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(rule, res))
+	})
+
+	t.Run("cannot get for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		rule := makeRule(budget.ID)
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		_, err := ruleRepository.Get(ctx, budget2.ID, rule.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can update", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		rule := makeRule(budget.ID)
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		rule.Name = "New"
+		rule.PayeeContains = beans.NewNullString("tea")
+		rule.MinAmount = beans.NewEmptyAmount()
+		rule.CategoryID = beans.EmptyID()
+		require.Nil(t, ruleRepository.Update(ctx, rule))
+
+		res, err := ruleRepository.Get(ctx, budget.ID, rule.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(rule, res))
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		rule := makeRule(budget.ID)
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		require.Nil(t, ruleRepository.Delete(ctx, budget.ID, rule.ID))
+
+		_, err := ruleRepository.Get(ctx, budget.ID, rule.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("cannot delete for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		rule := makeRule(budget.ID)
+		require.Nil(t, ruleRepository.Create(ctx, rule))
+
+		require.Nil(t, ruleRepository.Delete(ctx, budget2.ID, rule.ID))
+
+		_, err := ruleRepository.Get(ctx, budget.ID, rule.ID)
+		require.Nil(t, err)
+	})
+
+	t.Run("can get for budget in order of creation", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+
+		rules := []beans.Rule{makeRule(budget.ID), makeRule(budget.ID), makeRule(budget.ID)}
+		for _, rule := range rules {
+			require.Nil(t, ruleRepository.Create(ctx, rule))
+		}
+		require.Nil(t, ruleRepository.Create(ctx, makeRule(budget2.ID)))
+
+		res, err := ruleRepository.GetForBudget(ctx, budget.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(rules, res))
+	})
+}
@@ -0,0 +1,137 @@
+package datasource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduledTransaction(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+
+	scheduledRepository := ds.ScheduledTransactionRepository()
+	ctx := context.Background()
+
+	makeScheduled := func(budgetID beans.ID, nextDate string) beans.ScheduledTransaction {
+		account := factory.Account(beans.Account{BudgetID: budgetID})
+		category := factory.Category(beans.Category{BudgetID: budgetID})
+
+		return beans.ScheduledTransaction{
+			ID:       beans.NewID(),
+			BudgetID: budgetID,
+			Template: beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-8, 0),
+					Notes:     beans.NewTransactionNotes("notes"),
+					Splits: []beans.SplitParams{
+						{CategoryID: category.ID, Amount: beans.NewAmount(-5, 0), Notes: beans.NewTransactionNotes("a")},
+						{CategoryID: category.ID, Amount: beans.NewAmount(-3, 0)},
+					},
+				},
+			},
+			Rule: beans.ScheduleRule{
+				Frequency: beans.ScheduleMonths,
+				Interval:  1,
+				Start:     testutils.NewDate(t, "2022-01-15"),
+			},
+			NextDate: testutils.NewDate(t, nextDate),
+		}
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		scheduled := makeScheduled(budget.ID, "2022-01-15")
+		require.Nil(t, scheduledRepository.Create(ctx, scheduled))
+
+		res, err := scheduledRepository.Get(ctx, budget.ID, scheduled.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(scheduled, res))
+	})
+
+	t.Run("cannot get for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		scheduled := makeScheduled(budget.ID, "2022-01-15")
+		require.Nil(t, scheduledRepository.Create(ctx, scheduled))
+
+		_, err := scheduledRepository.Get(ctx, budget2.ID, scheduled.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can update and replace splits", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		scheduled := makeScheduled(budget.ID, "2022-01-15")
+		require.Nil(t, scheduledRepository.Create(ctx, scheduled))
+
+		scheduled.Template.Amount = beans.NewAmount(-5, 0)
+		scheduled.Template.Splits = scheduled.Template.Splits[:1]
+		scheduled.NextDate = testutils.NewDate(t, "2022-02-15")
+		require.Nil(t, scheduledRepository.Update(ctx, nil, scheduled))
+
+		res, err := scheduledRepository.Get(ctx, budget.ID, scheduled.ID)
+		require.Nil(t, err)
+		assert.True(t, reflect.DeepEqual(scheduled, res))
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		scheduled := makeScheduled(budget.ID, "2022-01-15")
+		require.Nil(t, scheduledRepository.Create(ctx, scheduled))
+
+		require.Nil(t, scheduledRepository.Delete(ctx, budget.ID, scheduled.ID))
+
+		_, err := scheduledRepository.Get(ctx, budget.ID, scheduled.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can advance next date only from previous date", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		scheduled := makeScheduled(budget.ID, "2022-01-15")
+		require.Nil(t, scheduledRepository.Create(ctx, scheduled))
+
+		previous := scheduled.NextDate
+		next := testutils.NewDate(t, "2022-02-15")
+
+		advanced, err := scheduledRepository.AdvanceNextDate(ctx, nil, scheduled.ID, previous, next)
+		require.Nil(t, err)
+		assert.True(t, advanced)
+
+		// already advanced, so the previous date is stale
+		advanced, err = scheduledRepository.AdvanceNextDate(ctx, nil, scheduled.ID, previous, testutils.NewDate(t, "2022-03-15"))
+		require.Nil(t, err)
+		assert.False(t, advanced)
+
+		res, err := scheduledRepository.Get(ctx, budget.ID, scheduled.ID)
+		require.Nil(t, err)
+		assert.Equal(t, "2022-02-15", res.NextDate.String())
+	})
+
+	t.Run("can get due", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+
+		due1 := makeScheduled(budget.ID, "2022-01-14")
+		due2 := makeScheduled(budget.ID, "2022-01-15")
+		notDue := makeScheduled(budget.ID, "2022-01-16")
+		otherBudget := makeScheduled(budget2.ID, "2022-01-14")
+		for _, s := range []beans.ScheduledTransaction{due2, notDue, due1, otherBudget} {
+			require.Nil(t, scheduledRepository.Create(ctx, s))
+		}
+
+		res, err := scheduledRepository.GetDue(ctx, budget.ID, testutils.NewDate(t, "2022-01-15"))
+		require.Nil(t, err)
+		require.Len(t, res, 2)
+		assert.True(t, reflect.DeepEqual(due1, res[0]))
+		assert.True(t, reflect.DeepEqual(due2, res[1]))
+
+		res, err = scheduledRepository.GetForBudget(ctx, budget.ID)
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+	})
+}
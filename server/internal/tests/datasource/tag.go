@@ -0,0 +1,146 @@
+package datasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTag(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+
+	tagRepository := ds.TagRepository()
+	ctx := context.Background()
+
+	makeTag := func(budgetID beans.ID, name beans.Name) beans.Tag {
+		tag := beans.Tag{ID: beans.NewID(), BudgetID: budgetID, Name: name}
+		require.Nil(t, tagRepository.Create(ctx, tag))
+		return tag
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		tag := makeTag(budget.ID, "Vacation")
+
+		res, err := tagRepository.Get(ctx, budget.ID, tag.ID)
+		require.Nil(t, err)
+		assert.Equal(t, tag, res)
+	})
+
+	t.Run("cannot get for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		tag := makeTag(budget.ID, "Vacation")
+
+		_, err := tagRepository.Get(ctx, budget2.ID, tag.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("can update", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		tag := makeTag(budget.ID, "Vacation")
+
+		tag.Name = "Trip"
+		require.Nil(t, tagRepository.Update(ctx, tag))
+
+		res, err := tagRepository.Get(ctx, budget.ID, tag.ID)
+		require.Nil(t, err)
+		assert.Equal(t, tag, res)
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		tag := makeTag(budget.ID, "Vacation")
+
+		require.Nil(t, tagRepository.Delete(ctx, budget.ID, tag.ID))
+
+		_, err := tagRepository.Get(ctx, budget.ID, tag.ID)
+		testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+	})
+
+	t.Run("cannot delete for other budget", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		tag := makeTag(budget.ID, "Vacation")
+
+		require.Nil(t, tagRepository.Delete(ctx, budget2.ID, tag.ID))
+
+		_, err := tagRepository.Get(ctx, budget.ID, tag.ID)
+		require.Nil(t, err)
+	})
+
+	t.Run("get for budget is ordered by name", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		b := makeTag(budget.ID, "B")
+		a := makeTag(budget.ID, "A")
+		makeTag(budget2.ID, "C")
+
+		res, err := tagRepository.GetForBudget(ctx, budget.ID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.Tag{a, b}, res)
+	})
+
+	t.Run("set for transactions", func(t *testing.T) {
+
+		t.Run("replaces tags", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			a := makeTag(budget.ID, "A")
+			b := makeTag(budget.ID, "B")
+			transaction := factory.Transaction(budget.ID, beans.Transaction{})
+			other := factory.Transaction(budget.ID, beans.Transaction{})
+
+			require.Nil(t, tagRepository.SetForTransactions(ctx, nil, map[beans.ID][]beans.ID{
+				transaction.ID: {b.ID, a.ID},
+				other.ID:       {a.ID},
+			}))
+			require.Nil(t, tagRepository.SetForTransactions(ctx, nil, map[beans.ID][]beans.ID{
+				transaction.ID: {b.ID},
+			}))
+
+			res, err := ds.TransactionRepository().GetWithRelations(ctx, budget.ID, transaction.ID)
+			require.Nil(t, err)
+			assert.Equal(t, []beans.RelatedTag{{ID: b.ID, Name: b.Name}}, res.Tags)
+
+			res, err = ds.TransactionRepository().GetWithRelations(ctx, budget.ID, other.ID)
+			require.Nil(t, err)
+			assert.Equal(t, []beans.RelatedTag{{ID: a.ID, Name: a.Name}}, res.Tags)
+		})
+
+		t.Run("ignores duplicates", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			a := makeTag(budget.ID, "A")
+			transaction := factory.Transaction(budget.ID, beans.Transaction{})
+
+			require.Nil(t, tagRepository.SetForTransactions(ctx, nil, map[beans.ID][]beans.ID{
+				transaction.ID: {a.ID, a.ID},
+			}))
+
+			res, err := ds.TransactionRepository().GetWithRelations(ctx, budget.ID, transaction.ID)
+			require.Nil(t, err)
+			assert.Equal(t, []beans.RelatedTag{{ID: a.ID, Name: a.Name}}, res.Tags)
+		})
+
+		t.Run("can remove all tags", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			a := makeTag(budget.ID, "A")
+			transaction := factory.Transaction(budget.ID, beans.Transaction{})
+
+			require.Nil(t, tagRepository.SetForTransactions(ctx, nil, map[beans.ID][]beans.ID{
+				transaction.ID: {a.ID},
+			}))
+			require.Nil(t, tagRepository.SetForTransactions(ctx, nil, map[beans.ID][]beans.ID{
+				transaction.ID: nil,
+			}))
+
+			res, err := ds.TransactionRepository().GetWithRelations(ctx, budget.ID, transaction.ID)
+			require.Nil(t, err)
+			assert.Nil(t, res.Tags)
+		})
+
+	})
+}
@@ -26,6 +26,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			err := transactionRepository.Create(
 				ctx,
+				nil,
 				[]beans.Transaction{{
 					ID:         beans.NewID(),
 					AccountID:  account.ID,
@@ -49,7 +50,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Amount:    beans.NewAmount(5, 0),
 				Date:      testutils.NewDate(t, "2022-08-28"),
 			}
-			require.Nil(t, transactionRepository.Create(ctx, []beans.Transaction{transaction}))
+			require.Nil(t, transactionRepository.Create(ctx, nil, []beans.Transaction{transaction}))
 		})
 
 		t.Run("can create multiple transactions, with a transfer_id", func(t *testing.T) {
@@ -76,6 +77,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			err := transactionRepository.Create(
 				ctx,
+				nil,
 				[]beans.Transaction{account1Transaction, account2Transaction},
 			)
 			require.NoError(t, err)
@@ -111,6 +113,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			err := transactionRepository.Create(
 				ctx,
+				nil,
 				[]beans.Transaction{parent, split1, split2},
 			)
 			require.NoError(t, err)
@@ -139,15 +142,18 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 		category := factory.Category(beans.Category{BudgetID: budget.ID})
 
 		transaction := beans.Transaction{
-			ID:         beans.NewID(),
-			AccountID:  account.ID,
-			CategoryID: category.ID,
-			PayeeID:    payee.ID,
-			Amount:     beans.NewAmount(5, 0),
-			Date:       testutils.NewDate(t, "2022-08-28"),
-			Notes:      beans.NewTransactionNotes("notes"),
+			ID:          beans.NewID(),
+			AccountID:   account.ID,
+			CategoryID:  category.ID,
+			PayeeID:     payee.ID,
+			Amount:      beans.NewAmount(5, 0),
+			Date:        testutils.NewDate(t, "2022-08-28"),
+			Notes:       beans.NewTransactionNotes("notes"),
+			Status:      beans.TransactionCleared,
+			ImportID:    beans.NewNullString("fitid"),
+			ImportPayee: beans.NewNullString("STORE #12"),
 		}
-		require.Nil(t, transactionRepository.Create(ctx, []beans.Transaction{transaction}))
+		require.Nil(t, transactionRepository.Create(ctx, nil, []beans.Transaction{transaction}))
 
 		res, err := transactionRepository.Get(ctx, budget.ID, transaction.ID)
 		require.Nil(t, err)
@@ -172,8 +178,9 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			Amount:     beans.NewAmount(5, 0),
 			Date:       testutils.NewDate(t, "2022-08-28"),
 			Notes:      beans.NewTransactionNotes("notes"),
+			Status:     beans.TransactionUncleared,
 		}
-		require.NoError(t, transactionRepository.Create(ctx, []beans.Transaction{transaction}))
+		require.NoError(t, transactionRepository.Create(ctx, nil, []beans.Transaction{transaction}))
 
 		transaction.AccountID = account2.ID
 		transaction.CategoryID = category2.ID
@@ -181,8 +188,9 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 		transaction.Amount = beans.NewAmount(6, 0)
 		transaction.Date = testutils.NewDate(t, "2022-08-30")
 		transaction.Notes = beans.NewTransactionNotes("notes 5")
+		transaction.Status = beans.TransactionCleared
 
-		require.NoError(t, transactionRepository.Update(ctx, []beans.Transaction{transaction}))
+		require.NoError(t, transactionRepository.Update(ctx, nil, []beans.Transaction{transaction}))
 
 		res, err := transactionRepository.Get(ctx, budget.ID, transaction.ID)
 		require.NoError(t, err)
@@ -190,6 +198,57 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 		assert.Equal(t, transaction, res)
 	})
 
+	t.Run("can reconcile", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+
+		date := testutils.NewDate(t, "2022-01-15")
+		uncleared := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: date})
+		cleared := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: date, Status: beans.TransactionCleared})
+		later := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: testutils.NewDate(t, "2022-01-16"), Status: beans.TransactionCleared})
+		other := factory.Transaction(budget.ID, beans.Transaction{Date: date, Status: beans.TransactionCleared})
+
+		toReconcile, err := transactionRepository.GetToReconcile(ctx, nil, account.ID, date)
+		require.NoError(t, err)
+		require.Len(t, toReconcile, 1)
+		assert.Equal(t, cleared.ID, toReconcile[0].ID)
+
+		require.NoError(t, transactionRepository.Reconcile(ctx, nil, account.ID, date))
+
+		// only cleared transactions on the account up to the date are reconciled
+		expected := map[beans.ID]beans.TransactionStatus{
+			uncleared.ID: beans.TransactionUncleared,
+			cleared.ID:   beans.TransactionReconciled,
+			later.ID:     beans.TransactionCleared,
+			other.ID:     beans.TransactionCleared,
+		}
+		for id, status := range expected {
+			res, err := transactionRepository.Get(ctx, budget.ID, id)
+			require.NoError(t, err)
+			assert.Equal(t, status, res.Status)
+		}
+	})
+
+	t.Run("can get cleared balance", func(t *testing.T) {
+		budget, _ := factory.MakeBudgetAndUser()
+		account := factory.Account(beans.Account{BudgetID: budget.ID})
+		date := testutils.NewDate(t, "2022-01-15")
+
+		res, err := transactionRepository.GetClearedBalance(ctx, nil, account.ID, date)
+		require.NoError(t, err)
+		assert.Equal(t, beans.NewAmount(0, 0), res)
+
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: date, Amount: beans.NewAmount(1, 0)})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: date, Amount: beans.NewAmount(2, 0), Status: beans.TransactionCleared})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: date, Amount: beans.NewAmount(3, 0), Status: beans.TransactionReconciled})
+		factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: testutils.NewDate(t, "2022-01-16"), Amount: beans.NewAmount(4, 0), Status: beans.TransactionCleared})
+
+		// uncleared and later transactions are not counted
+		res, err = transactionRepository.GetClearedBalance(ctx, nil, account.ID, date)
+		require.NoError(t, err)
+		assert.Equal(t, beans.NewAmount(5, 0), res)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 
 		t.Run("can delete", func(t *testing.T) {
@@ -201,7 +260,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			transaction3 := factory.Transaction(budget1.ID, beans.Transaction{})
 			transaction4 := factory.Transaction(budget2.ID, beans.Transaction{})
 
-			err := transactionRepository.Delete(ctx, budget1.ID, []beans.ID{transaction1.ID, transaction2.ID, transaction4.ID})
+			err := transactionRepository.Delete(ctx, nil, budget1.ID, []beans.ID{transaction1.ID, transaction2.ID, transaction4.ID})
 			require.NoError(t, err)
 
 			// transaction1 and transaction2 should be deleted, they are passed in and part of budget 1.
@@ -227,7 +286,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			accountB := factory.Account(beans.Account{BudgetID: budget.ID})
 			transactions := factory.Transfer(budget.ID, accountA, accountB, beans.NewAmount(5, 0))
 
-			err := transactionRepository.Delete(ctx, budget.ID, []beans.ID{transactions[0].ID})
+			err := transactionRepository.Delete(ctx, nil, budget.ID, []beans.ID{transactions[0].ID})
 			require.NoError(t, err)
 
 			// both transactions should be deleted
@@ -247,7 +306,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				SplitID: parent.ID,
 			})
 
-			err := transactionRepository.Delete(ctx, budget.ID, []beans.ID{parent.ID})
+			err := transactionRepository.Delete(ctx, nil, budget.ID, []beans.ID{parent.ID})
 			require.NoError(t, err)
 
 			// both transactions should be deleted
@@ -267,7 +326,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				SplitID: parent.ID,
 			})
 
-			err := transactionRepository.Delete(ctx, budget.ID, []beans.ID{child.ID})
+			err := transactionRepository.Delete(ctx, nil, budget.ID, []beans.ID{child.ID})
 			require.NoError(t, err)
 
 			// nothing should be deleted
@@ -296,7 +355,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			// this transaction should not be included
 			factory.Transaction(budget2.ID, beans.Transaction{})
 
-			transactions, err := transactionRepository.GetForBudget(ctx, budget1.ID)
+			transactions, err := transactionRepository.GetForBudget(ctx, budget1.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			assert.Len(t, transactions, 1)
 
@@ -305,6 +364,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:     transaction1.Date,
 				Amount:   transaction1.Amount,
 				Notes:    transaction1.Notes,
+				Status:   transaction1.Status,
 				Variant:  beans.TransactionStandard,
 				Account:  beans.RelatedAccount{ID: account.ID, Name: account.Name, OffBudget: false},
 				Category: beans.OptionalWrap(beans.RelatedCategory{ID: category.ID, Name: category.Name}),
@@ -320,7 +380,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			transaction1 := factory.Transaction(budget.ID, beans.Transaction{Date: testutils.NewDate(t, "2024-03-01")})
 
 			// transactions should be returned with newest first
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 3, len(res))
 
@@ -329,6 +389,35 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			assert.Equal(t, transaction1.ID, res[2].ID)
 		})
 
+		t.Run("applies params", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+
+			transaction1 := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: testutils.NewDate(t, "2024-03-01")})
+			transaction2 := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: testutils.NewDate(t, "2024-03-02")})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, Date: testutils.NewDate(t, "2024-03-03")})
+			factory.Transaction(budget.ID, beans.Transaction{Date: testutils.NewDate(t, "2024-03-01")})
+
+			params := beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{AccountID: account.ID},
+				Sort:              beans.TransactionSortDateAsc,
+				Limit:             2,
+			}
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, params)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(res))
+			assert.Equal(t, transaction1.ID, res[0].ID)
+			assert.Equal(t, transaction2.ID, res[1].ID)
+
+			// continues after the cursor
+			params.Cursor = beans.NewTransactionCursor(params.Sort, res[0])
+			res, err = transactionRepository.GetForBudget(ctx, budget.ID, params)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(res))
+			assert.Equal(t, transaction2.ID, res[0].ID)
+		})
+
 		t.Run("maps off budget variant", func(t *testing.T) {
 			budget, _ := factory.MakeBudgetAndUser()
 
@@ -336,7 +425,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			transaction := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID})
 
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 1, len(res))
 
@@ -345,6 +434,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:     transaction.Date,
 				Amount:   transaction.Amount,
 				Notes:    transaction.Notes,
+				Status:   transaction.Status,
 				Variant:  beans.TransactionOffBudget,
 				Account:  beans.RelatedAccount{ID: account.ID, Name: account.Name, OffBudget: true},
 				Category: beans.Optional[beans.RelatedCategory]{},
@@ -358,7 +448,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			account := factory.Account(beans.Account{BudgetID: budget.ID})
 			transaction := factory.Transaction(budget.ID, beans.Transaction{IsSplit: true, AccountID: account.ID})
 
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 1, len(res))
 
@@ -367,6 +457,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:    transaction.Date,
 				Amount:  transaction.Amount,
 				Notes:   transaction.Notes,
+				Status:  transaction.Status,
 				Variant: beans.TransactionSplit,
 				Account: account.ToRelated(),
 			}, res[0])
@@ -380,7 +471,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			transactions := factory.Transfer(budget.ID, accountA, accountB, beans.NewAmount(5, 0))
 
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 2, len(res))
 
@@ -390,6 +481,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 					Date:            transactions[0].Date,
 					Amount:          transactions[0].Amount,
 					Notes:           transactions[0].Notes,
+					Status:          transactions[0].Status,
 					Variant:         beans.TransactionTransfer,
 					Account:         beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: false},
 					TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: false}),
@@ -399,6 +491,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 					Date:            transactions[1].Date,
 					Amount:          transactions[1].Amount,
 					Notes:           transactions[1].Notes,
+					Status:          transactions[1].Status,
 					Variant:         beans.TransactionTransfer,
 					Account:         beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: false},
 					TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: false}),
@@ -412,7 +505,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			parent := factory.Transaction(budget.ID, beans.Transaction{IsSplit: true})
 			factory.Transaction(budget.ID, beans.Transaction{SplitID: parent.ID})
 
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 1, len(res))
 
@@ -427,7 +520,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 
 			transactions := factory.Transfer(budget.ID, accountA, accountB, beans.NewAmount(5, 0))
 
-			res, err := transactionRepository.GetForBudget(ctx, budget.ID)
+			res, err := transactionRepository.GetForBudget(ctx, budget.ID, beans.TransactionListParams{})
 			require.NoError(t, err)
 			require.Equal(t, 2, len(res))
 
@@ -437,6 +530,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 					Date:            transactions[0].Date,
 					Amount:          transactions[0].Amount,
 					Notes:           transactions[0].Notes,
+					Status:          transactions[0].Status,
 					Variant:         beans.TransactionTransfer,
 					Account:         beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: true},
 					TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: true}),
@@ -446,6 +540,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 					Date:            transactions[1].Date,
 					Amount:          transactions[1].Amount,
 					Notes:           transactions[1].Notes,
+					Status:          transactions[1].Status,
 					Variant:         beans.TransactionTransfer,
 					Account:         beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: true},
 					TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: true}),
@@ -477,6 +572,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:     transaction.Date,
 				Amount:   transaction.Amount,
 				Notes:    transaction.Notes,
+				Status:   transaction.Status,
 				Variant:  beans.TransactionStandard,
 				Account:  beans.RelatedAccount{ID: account.ID, Name: account.Name, OffBudget: false},
 				Category: beans.OptionalWrap(beans.RelatedCategory{ID: category.ID, Name: category.Name}),
@@ -498,6 +594,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:    transaction.Date,
 				Amount:  transaction.Amount,
 				Notes:   transaction.Notes,
+				Status:  transaction.Status,
 				Variant: beans.TransactionOffBudget,
 				Account: beans.RelatedAccount{ID: account.ID, Name: account.Name, OffBudget: true},
 			}, res)
@@ -517,6 +614,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:    transaction.Date,
 				Amount:  transaction.Amount,
 				Notes:   transaction.Notes,
+				Status:  transaction.Status,
 				Variant: beans.TransactionSplit,
 				Account: account.ToRelated(),
 			}, res)
@@ -537,6 +635,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:            transactions[0].Date,
 				Amount:          transactions[0].Amount,
 				Notes:           transactions[0].Notes,
+				Status:          transactions[0].Status,
 				Variant:         beans.TransactionTransfer,
 				Account:         beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: false},
 				TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: false}),
@@ -558,6 +657,7 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 				Date:            transactions[0].Date,
 				Amount:          transactions[0].Amount,
 				Notes:           transactions[0].Notes,
+				Status:          transactions[0].Status,
 				Variant:         beans.TransactionTransfer,
 				Account:         beans.RelatedAccount{ID: accountA.ID, Name: accountA.Name, OffBudget: true},
 				TransferAccount: beans.OptionalWrap(beans.RelatedAccount{ID: accountB.ID, Name: accountB.Name, OffBudget: true}),
@@ -582,6 +682,121 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 		})
 	})
 
+	t.Run("get for account between", func(t *testing.T) {
+
+		t.Run("filters by account and date", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			otherAccount := factory.Account(beans.Account{BudgetID: budget.ID})
+
+			transaction2 := factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: account.ID,
+				Date:      testutils.NewDate(t, "2022-08-31"),
+			})
+			transaction1 := factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: account.ID,
+				Date:      testutils.NewDate(t, "2022-08-01"),
+			})
+			// outside of range
+			factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: account.ID,
+				Date:      testutils.NewDate(t, "2022-09-01"),
+			})
+			// other account
+			factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: otherAccount.ID,
+				Date:      testutils.NewDate(t, "2022-08-15"),
+			})
+
+			res, err := transactionRepository.GetForAccountBetween(ctx, budget.ID, account.ID, testutils.NewDate(t, "2022-08-01"), testutils.NewDate(t, "2022-08-31"))
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+
+			assert.Equal(t, transaction1.ID, res[0].ID)
+			assert.Equal(t, transaction2.ID, res[1].ID)
+		})
+
+		t.Run("excludes splits", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			parent := factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: account.ID,
+				IsSplit:   true,
+				Date:      testutils.NewDate(t, "2022-08-01"),
+			})
+			factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: account.ID,
+				SplitID:   parent.ID,
+				Date:      testutils.NewDate(t, "2022-08-01"),
+			})
+
+			res, err := transactionRepository.GetForAccountBetween(ctx, budget.ID, account.ID, testutils.NewDate(t, "2022-08-01"), testutils.NewDate(t, "2022-08-01"))
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, parent.ID, res[0].ID)
+		})
+
+		t.Run("filters by budget", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			budget2, _ := factory.MakeBudgetAndUser()
+
+			transaction := factory.Transaction(budget.ID, beans.Transaction{
+				Date: testutils.NewDate(t, "2022-08-01"),
+			})
+
+			res, err := transactionRepository.GetForAccountBetween(ctx, budget2.ID, transaction.AccountID, testutils.NewDate(t, "2022-08-01"), testutils.NewDate(t, "2022-08-01"))
+			require.NoError(t, err)
+			assert.Len(t, res, 0)
+		})
+	})
+
+	t.Run("get import ids", func(t *testing.T) {
+
+		t.Run("filters by account", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			otherAccount := factory.Account(beans.Account{BudgetID: budget.ID})
+
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, ImportID: beans.NewNullString("1")})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: otherAccount.ID, ImportID: beans.NewNullString("2")})
+
+			res, err := transactionRepository.GetImportIDs(ctx, budget.ID, account.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"1"}, res)
+		})
+
+		t.Run("filters by budget", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			budget2, _ := factory.MakeBudgetAndUser()
+
+			transaction := factory.Transaction(budget.ID, beans.Transaction{ImportID: beans.NewNullString("1")})
+
+			res, err := transactionRepository.GetImportIDs(ctx, budget2.ID, transaction.AccountID)
+			require.NoError(t, err)
+			assert.Len(t, res, 0)
+		})
+
+		t.Run("import id is unique per account", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, ImportID: beans.NewNullString("1")})
+
+			err := transactionRepository.Create(ctx, nil, []beans.Transaction{{
+				ID:        beans.NewID(),
+				AccountID: account.ID,
+				Amount:    beans.NewAmount(1, 0),
+				Date:      testutils.NewDate(t, "2022-08-01"),
+				ImportID:  beans.NewNullString("1"),
+			}})
+			assert.Error(t, err)
+		})
+	})
+
 	t.Run("get splits", func(t *testing.T) {
 
 		t.Run("filters by budget", func(t *testing.T) {
@@ -662,6 +877,127 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 			_, err := transactionRepository.GetSplits(ctx, budget.ID, parent.ID)
 			assert.ErrorContains(t, err, "category null")
 		})
+
+		t.Run("can get splits for account", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			budget2, _ := factory.MakeBudgetAndUser()
+			account := factory.Account(beans.Account{BudgetID: budget.ID})
+			otherAccount := factory.Account(beans.Account{BudgetID: budget.ID})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+			parent := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, IsSplit: true})
+			child1 := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, SplitID: parent.ID, CategoryID: category.ID})
+			otherParent := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, IsSplit: true})
+			child2 := factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, SplitID: otherParent.ID, CategoryID: category.ID})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: account.ID, CategoryID: category.ID})
+			elsewhere := factory.Transaction(budget.ID, beans.Transaction{AccountID: otherAccount.ID, IsSplit: true})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: otherAccount.ID, SplitID: elsewhere.ID, CategoryID: category.ID})
+
+			res, err := transactionRepository.GetSplitsForAccount(ctx, budget.ID, account.ID)
+			require.NoError(t, err)
+			ids := []beans.ID{}
+			for _, split := range res {
+				ids = append(ids, split.Split.ID)
+			}
+			assert.ElementsMatch(t, []beans.ID{child1.ID, child2.ID}, ids)
+
+			res, err = transactionRepository.GetSplitsForAccount(ctx, budget2.ID, account.ID)
+			require.NoError(t, err)
+			assert.Len(t, res, 0)
+		})
+	})
+
+	t.Run("delete splits", func(t *testing.T) {
+
+		t.Run("deletes only splits", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			parent := factory.Transaction(budget.ID, beans.Transaction{
+				IsSplit: true,
+			})
+			child1 := factory.Transaction(budget.ID, beans.Transaction{
+				SplitID:    parent.ID,
+				CategoryID: category.ID,
+			})
+			child2 := factory.Transaction(budget.ID, beans.Transaction{
+				SplitID:    parent.ID,
+				CategoryID: category.ID,
+			})
+
+			require.NoError(t, transactionRepository.DeleteSplits(ctx, nil, []beans.ID{child1.ID, parent.ID}))
+
+			// parent is kept
+			_, err := transactionRepository.Get(ctx, budget.ID, parent.ID)
+			require.NoError(t, err)
+
+			// only the other split is left
+			res, err := transactionRepository.GetSplits(ctx, budget.ID, parent.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(res))
+			assert.Equal(t, child2.ID, res[0].Transaction.ID)
+		})
+	})
+
+	t.Run("search", func(t *testing.T) {
+
+		search := func(budgetID beans.ID, query string) []beans.ID {
+			res, err := transactionRepository.Search(ctx, budgetID, beans.TransactionSearchParams{
+				Query: beans.NewNullString(query),
+			})
+			require.NoError(t, err)
+
+			ids := make([]beans.ID, len(res))
+			for i, transaction := range res {
+				ids[i] = transaction.ID
+			}
+			return ids
+		}
+
+		t.Run("finds split once by its lines", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			parent := factory.Transaction(budget.ID, beans.Transaction{
+				IsSplit: true,
+			})
+			factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: parent.AccountID,
+				SplitID:   parent.ID,
+				Notes:     beans.NewTransactionNotes("garden hose"),
+			})
+			factory.Transaction(budget.ID, beans.Transaction{
+				AccountID: parent.AccountID,
+				SplitID:   parent.ID,
+				Notes:     beans.NewTransactionNotes("garden soil"),
+			})
+
+			assert.Equal(t, []beans.ID{parent.ID}, search(budget.ID, "garden"))
+		})
+
+		t.Run("follows updates and deletes", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			transaction := factory.Transaction(budget.ID, beans.Transaction{
+				Notes: beans.NewTransactionNotes("old"),
+			})
+
+			transaction.Notes = beans.NewTransactionNotes("new")
+			require.NoError(t, transactionRepository.Update(ctx, nil, []beans.Transaction{transaction}))
+
+			assert.Empty(t, search(budget.ID, "old"))
+			assert.Equal(t, []beans.ID{transaction.ID}, search(budget.ID, "new"))
+
+			require.NoError(t, transactionRepository.Delete(ctx, nil, budget.ID, []beans.ID{transaction.ID}))
+			assert.Empty(t, search(budget.ID, "new"))
+		})
+
+		t.Run("empty query finds nothing", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+
+			factory.Transaction(budget.ID, beans.Transaction{})
+
+			assert.Empty(t, search(budget.ID, "  "))
+		})
 	})
 
 	t.Run("can get activity by category", func(t *testing.T) {
@@ -772,6 +1108,106 @@ func testTransaction(t *testing.T, ds beans.DataSource) {
 		})
 	})
 
+	t.Run("get payment activity by category", func(t *testing.T) {
+
+		t.Run("can get", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			paymentCategory := factory.Category(beans.Category{BudgetID: budget.ID})
+			card := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountCreditCard, PaymentCategoryID: paymentCategory.ID})
+			checking := factory.Account(beans.Account{BudgetID: budget.ID})
+
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			incomeGroup := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID, IsIncome: true})
+			incomeCategory := factory.Category(beans.Category{BudgetID: budget.ID, GroupID: incomeGroup.ID})
+
+			// spending and refunds on the card are moved
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(-1025, -2)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(2, 0)})
+
+			// income, uncategorized, and other accounts are not
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: incomeCategory.ID, Amount: beans.NewAmount(1, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, Amount: beans.NewAmount(-4, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: checking.ID, CategoryID: category.ID, Amount: beans.NewAmount(-5, 0)})
+
+			res, err := transactionRepository.GetPaymentActivityByCategory(ctx, budget.ID, beans.Date{}, beans.Date{})
+			require.NoError(t, err)
+
+			assert.Equal(t, map[beans.ID]beans.Amount{paymentCategory.ID: beans.NewAmount(825, -2)}, res)
+		})
+
+		t.Run("filters by date", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			paymentCategory := factory.Category(beans.Category{BudgetID: budget.ID})
+			card := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountCreditCard, PaymentCategoryID: paymentCategory.ID})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(-3, 0), Date: testutils.NewDate(t, "2022-08-31")})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(-2, 0), Date: testutils.NewDate(t, "2022-09-01")})
+
+			res, err := transactionRepository.GetPaymentActivityByCategory(ctx, budget.ID, testutils.NewDate(t, "2022-09-01"), testutils.NewDate(t, "2022-09-30"))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[beans.ID]beans.Amount{paymentCategory.ID: beans.NewAmount(2, 0)}, res)
+		})
+	})
+
+	t.Run("get credit activity by category", func(t *testing.T) {
+
+		t.Run("groups by category and payment category", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			cardCategory := factory.Category(beans.Category{BudgetID: budget.ID})
+			lineCategory := factory.Category(beans.Category{BudgetID: budget.ID})
+			card := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountCreditCard, PaymentCategoryID: cardCategory.ID})
+			line := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountLineOfCredit, PaymentCategoryID: lineCategory.ID})
+			offBudget := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountCreditCard, PaymentCategoryID: factory.Category(beans.Category{BudgetID: budget.ID}).ID, OffBudget: true})
+			checking := factory.Account(beans.Account{BudgetID: budget.ID})
+			incomeGroup := factory.CategoryGroup(beans.CategoryGroup{BudgetID: budget.ID, IsIncome: true})
+			incomeCategory := factory.Category(beans.Category{BudgetID: budget.ID, GroupID: incomeGroup.ID})
+			category1 := factory.Category(beans.Category{BudgetID: budget.ID})
+			category2 := factory.Category(beans.Category{BudgetID: budget.ID})
+
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category1.ID, Amount: beans.NewAmount(-3, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category1.ID, Amount: beans.NewAmount(-25, -2)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: line.ID, CategoryID: category1.ID, Amount: beans.NewAmount(-1, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category2.ID, Amount: beans.NewAmount(2, 0)})
+
+			// not credit spending in a budgeted category
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: checking.ID, CategoryID: category1.ID, Amount: beans.NewAmount(-7, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: offBudget.ID, CategoryID: category1.ID, Amount: beans.NewAmount(-7, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: incomeCategory.ID, Amount: beans.NewAmount(7, 0)})
+
+			res, err := transactionRepository.GetCreditActivityByCategory(ctx, budget.ID, beans.Date{}, beans.Date{})
+			require.NoError(t, err)
+
+			assert.Equal(t, map[beans.ID]map[beans.ID]beans.Amount{
+				category1.ID: {
+					cardCategory.ID: beans.NewAmount(-325, -2),
+					lineCategory.ID: beans.NewAmount(-1, 0),
+				},
+				category2.ID: {
+					cardCategory.ID: beans.NewAmount(2, 0),
+				},
+			}, res)
+		})
+
+		t.Run("filters by date", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			paymentCategory := factory.Category(beans.Category{BudgetID: budget.ID})
+			card := factory.Account(beans.Account{BudgetID: budget.ID, Type: beans.AccountCreditCard, PaymentCategoryID: paymentCategory.ID})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(-3, 0), Date: testutils.NewDate(t, "2022-08-31")})
+			factory.Transaction(budget.ID, beans.Transaction{AccountID: card.ID, CategoryID: category.ID, Amount: beans.NewAmount(-2, 0), Date: testutils.NewDate(t, "2022-09-01")})
+
+			res, err := transactionRepository.GetCreditActivityByCategory(ctx, budget.ID, testutils.NewDate(t, "2022-09-01"), testutils.NewDate(t, "2022-09-30"))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[beans.ID]map[beans.ID]beans.Amount{
+				category.ID: {paymentCategory.ID: beans.NewAmount(-2, 0)},
+			}, res)
+		})
+	})
+
 	t.Run("can get income", func(t *testing.T) {
 
 		t.Run("can get", func(t *testing.T) {
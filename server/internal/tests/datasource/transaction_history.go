@@ -0,0 +1,93 @@
+package datasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransactionHistory(t *testing.T, ds beans.DataSource) {
+	factory := testutils.NewFactory(t, ds)
+
+	historyRepository := ds.TransactionHistoryRepository()
+	ctx := context.Background()
+
+	makeHistory := func(budget beans.Budget, user beans.User, transactionID beans.ID) beans.TransactionHistory {
+		return beans.TransactionHistory{
+			ID:            beans.NewID(),
+			BudgetID:      budget.ID,
+			TransactionID: transactionID,
+			User:          beans.UserPublic{ID: user.ID, Username: user.Username},
+			Action:        beans.TransactionUpdated,
+			CreatedAt:     time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC),
+			Before: beans.OptionalWrap(beans.TransactionHistoryValues{
+				AccountID: beans.NewID(),
+				Amount:    beans.NewAmount(-1025, -2),
+				Date:      testutils.NewDate(t, "2022-01-01"),
+				Status:    beans.TransactionUncleared,
+			}),
+			After: beans.OptionalWrap(beans.TransactionHistoryValues{
+				AccountID:  beans.NewID(),
+				CategoryID: beans.NewID(),
+				PayeeID:    beans.NewID(),
+				Amount:     beans.NewAmount(125, -1),
+				Date:       testutils.NewDate(t, "2022-01-02"),
+				Notes:      beans.NewTransactionNotes("notes"),
+				Status:     beans.TransactionCleared,
+			}),
+		}
+	}
+
+	t.Run("can create and get", func(t *testing.T) {
+		budget, user := factory.MakeBudgetAndUser()
+		history := makeHistory(budget, user, beans.NewID())
+		require.Nil(t, historyRepository.Create(ctx, nil, []beans.TransactionHistory{history}))
+
+		res, err := historyRepository.GetForTransaction(ctx, budget.ID, history.TransactionID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.TransactionHistory{history}, res)
+	})
+
+	t.Run("can create without before or after", func(t *testing.T) {
+		budget, user := factory.MakeBudgetAndUser()
+		history := makeHistory(budget, user, beans.NewID())
+		history.Action = beans.TransactionCreated
+		history.Before = beans.Optional[beans.TransactionHistoryValues]{}
+		require.Nil(t, historyRepository.Create(ctx, nil, []beans.TransactionHistory{history}))
+
+		res, err := historyRepository.GetForTransaction(ctx, budget.ID, history.TransactionID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.TransactionHistory{history}, res)
+	})
+
+	t.Run("gets oldest first with split lines", func(t *testing.T) {
+		budget, user := factory.MakeBudgetAndUser()
+		transactionID := beans.NewID()
+		first := makeHistory(budget, user, transactionID)
+		split := makeHistory(budget, user, beans.NewID())
+		split.SplitID = transactionID
+		last := makeHistory(budget, user, transactionID)
+		other := makeHistory(budget, user, beans.NewID())
+		require.Nil(t, historyRepository.Create(ctx, nil, []beans.TransactionHistory{first, split, other, last}))
+
+		res, err := historyRepository.GetForTransaction(ctx, budget.ID, transactionID)
+		require.Nil(t, err)
+		assert.Equal(t, []beans.TransactionHistory{first, split, last}, res)
+	})
+
+	t.Run("cannot get for other budget", func(t *testing.T) {
+		budget, user := factory.MakeBudgetAndUser()
+		budget2, _ := factory.MakeBudgetAndUser()
+		history := makeHistory(budget, user, beans.NewID())
+		require.Nil(t, historyRepository.Create(ctx, nil, []beans.TransactionHistory{history}))
+
+		res, err := historyRepository.GetForTransaction(ctx, budget2.ID, history.TransactionID)
+		require.Nil(t, err)
+		assert.Len(t, res, 0)
+	})
+}
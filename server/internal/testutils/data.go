@@ -44,8 +44,9 @@ func (f *Factory) MakeBudgetAndUser() (beans.Budget, beans.User) {
 	budgetName := beans.NewID().String()
 	require.Nil(f.tb, f.ds.BudgetRepository().Create(context.Background(), nil, id, beans.Name(budgetName), userID))
 	return beans.Budget{
-			ID:   id,
-			Name: beans.Name(budgetName),
+			ID:                   id,
+			Name:                 beans.Name(budgetName),
+			OverspendingRollover: beans.OverspendingCarryForward,
 		},
 		beans.User{
 			ID:           userID,
@@ -99,7 +100,11 @@ func (f *Factory) Account(account beans.Account) beans.Account {
 		account.BudgetID = defaultBudget.ID
 	}
 
-	require.Nil(f.tb, f.ds.AccountRepository().Create(context.Background(), account))
+	if account.Type == "" {
+		account.Type = beans.AccountChecking
+	}
+
+	require.Nil(f.tb, f.ds.AccountRepository().Create(context.Background(), nil, account))
 
 	return account
 }
@@ -182,7 +187,7 @@ func (f *Factory) Payee(payee beans.Payee) beans.Payee {
 		payee.BudgetID = defaultBudget.ID
 	}
 
-	require.Nil(f.tb, f.ds.PayeeRepository().Create(context.Background(), payee))
+	require.Nil(f.tb, f.ds.PayeeRepository().Create(context.Background(), nil, payee))
 
 	return payee
 }
@@ -200,6 +205,10 @@ func (f *Factory) Transaction(budgetID beans.ID, transaction beans.Transaction)
 		transaction.Date = beans.NewDate(RandomTime())
 	}
 
+	if transaction.Status == "" {
+		transaction.Status = beans.TransactionUncleared
+	}
+
 	if transaction.Amount.Empty() {
 		coefficient := rand.Int63n(10)
 		exponent := rand.Int31n(2)
@@ -211,6 +220,7 @@ func (f *Factory) Transaction(budgetID beans.ID, transaction beans.Transaction)
 
 	require.NoError(f.tb, f.ds.TransactionRepository().Create(
 		context.Background(),
+		nil,
 		[]beans.Transaction{transaction},
 	))
 
@@ -225,12 +235,14 @@ func (f *Factory) Transfer(budgetID beans.ID, accountA beans.Account, accountB b
 		AccountID: accountA.ID,
 		Amount:    amount,
 		Date:      date,
+		Status:    beans.TransactionUncleared,
 	}
 	transactionB := beans.Transaction{
 		ID:        beans.NewID(),
 		AccountID: accountB.ID,
 		Amount:    beans.Arithmetic.Negate(amount),
 		Date:      date,
+		Status:    beans.TransactionUncleared,
 	}
 
 	transactionA.TransferID = transactionB.ID
@@ -238,6 +250,7 @@ func (f *Factory) Transfer(budgetID beans.ID, accountA beans.Account, accountB b
 
 	require.NoError(f.tb, f.ds.TransactionRepository().Create(
 		context.Background(),
+		nil,
 		[]beans.Transaction{transactionA, transactionB},
 	))
 
@@ -297,7 +310,7 @@ func (f *Factory) MakeMonthCategory(monthID beans.ID, categoryID beans.ID, amoun
 
 func (f *Factory) MakePayee(name string, budgetID beans.ID) beans.Payee {
 	payee := beans.Payee{ID: beans.NewID(), BudgetID: budgetID, Name: beans.Name(name)}
-	err := f.ds.PayeeRepository().Create(context.Background(), payee)
+	err := f.ds.PayeeRepository().Create(context.Background(), nil, payee)
 	require.Nil(f.tb, err)
 	return payee
 }
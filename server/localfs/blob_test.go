@@ -0,0 +1,80 @@
+package localfs_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/localfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("can put and get", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "blobs")
+		s, err := localfs.NewBlobStore(dir)
+		require.Nil(t, err)
+
+		require.Nil(t, s.Put(ctx, "key", strings.NewReader("contents")))
+
+		r, err := s.Get(ctx, "key")
+		require.Nil(t, err)
+		defer func() { _ = r.Close() }()
+		b, err := io.ReadAll(r)
+		require.Nil(t, err)
+		assert.Equal(t, "contents", string(b))
+
+		// only the blob is left in the directory
+		entries, err := os.ReadDir(dir)
+		require.Nil(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("can replace", func(t *testing.T) {
+		s, err := localfs.NewBlobStore(t.TempDir())
+		require.Nil(t, err)
+
+		require.Nil(t, s.Put(ctx, "key", strings.NewReader("old")))
+		require.Nil(t, s.Put(ctx, "key", strings.NewReader("new")))
+
+		r, err := s.Get(ctx, "key")
+		require.Nil(t, err)
+		defer func() { _ = r.Close() }()
+		b, err := io.ReadAll(r)
+		require.Nil(t, err)
+		assert.Equal(t, "new", string(b))
+	})
+
+	t.Run("can delete", func(t *testing.T) {
+		s, err := localfs.NewBlobStore(t.TempDir())
+		require.Nil(t, err)
+
+		require.Nil(t, s.Put(ctx, "key", strings.NewReader("contents")))
+		require.Nil(t, s.Delete(ctx, "key"))
+
+		_, err = s.Get(ctx, "key")
+		assert.ErrorIs(t, err, beans.ErrorNotFound)
+
+		// deleting again does nothing
+		require.Nil(t, s.Delete(ctx, "key"))
+	})
+
+	t.Run("cannot use key outside directory", func(t *testing.T) {
+		s, err := localfs.NewBlobStore(t.TempDir())
+		require.Nil(t, err)
+
+		for _, key := range []string{"", "../key", "a/b", `a\b`, ".hidden"} {
+			assert.NotNil(t, s.Put(ctx, key, strings.NewReader("contents")), key)
+			_, err := s.Get(ctx, key)
+			assert.NotNil(t, err, key)
+			assert.NotNil(t, s.Delete(ctx, key), key)
+		}
+	})
+}
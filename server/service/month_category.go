@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 )
@@ -11,22 +13,38 @@ type monthCategoryService struct{ service }
 var _ beans.MonthCategoryService = (*monthCategoryService)(nil)
 
 func (s *monthCategoryService) GetForMonth(ctx context.Context, month beans.Month) ([]beans.MonthCategoryWithDetails, error) {
-	monthCategories, err := s.ds.MonthCategoryRepository().GetForMonth(ctx, month)
+	categories, err := s.ds.CategoryRepository().GetForBudget(ctx, month.BudgetID)
 	if err != nil {
 		return nil, err
 	}
+	categoriesByID := make(map[beans.ID]beans.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
 
-	previousAssigned, err := s.ds.MonthCategoryRepository().GetAssignedByCategory(ctx, month.BudgetID, month.Date.FirstDay())
+	groups, err := s.ds.CategoryRepository().GetGroupsForBudget(ctx, month.BudgetID)
 	if err != nil {
 		return nil, err
 	}
+	hiddenGroups := make(map[beans.ID]bool)
+	for _, group := range groups {
+		hiddenGroups[group.ID] = group.Hidden
+	}
 
-	previousActivity, err := s.ds.TransactionRepository().GetActivityByCategory(ctx, month.BudgetID, beans.Date{}, month.Date.FirstDay().Previous())
-	if err != nil {
-		return nil, err
+	// a month that has not been created has nothing assigned
+	monthCategories := make([]beans.MonthCategory, len(categories))
+	if month.ID.Empty() {
+		for i, category := range categories {
+			monthCategories[i] = beans.MonthCategory{CategoryID: category.ID, Amount: beans.NewAmount(0, 0)}
+		}
+	} else {
+		monthCategories, err = s.ds.MonthCategoryRepository().GetForMonth(ctx, month)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	activity, err := s.ds.TransactionRepository().GetActivityByCategory(ctx, month.BudgetID, month.Date.FirstDay(), month.Date.LastDay())
+	snapshot, err := s.GetSnapshot(ctx, month.BudgetID, month.Date)
 	if err != nil {
 		return nil, err
 	}
@@ -34,15 +52,11 @@ func (s *monthCategoryService) GetForMonth(ctx context.Context, month beans.Mont
 	res := make([]beans.MonthCategoryWithDetails, len(monthCategories))
 	for i, v := range monthCategories {
 		// find activity
-		activity := activity[v.CategoryID].OrZero()
+		activity := snapshot.Categories[v.CategoryID].Activity.OrZero()
 
 		// calculate available
-		available, err := beans.Arithmetic.Add(
-			previousAssigned[v.CategoryID].OrZero(),
-			previousActivity[v.CategoryID].OrZero(),
-			v.Amount,
-			activity,
-		)
+		balance := snapshot.Categories[v.CategoryID].CarriedIn.OrZero()
+		available, err := beans.Arithmetic.Add(balance, v.Amount, activity)
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +69,184 @@ func (s *monthCategoryService) GetForMonth(ctx context.Context, month beans.Mont
 			Activity:   activity,
 			Available:  available,
 		}
+
+		// hidden categories and groups are not budgeted, so their goals are not tracked
+		category := categoriesByID[v.CategoryID]
+		if goal, ok := category.Goal.Value(); ok && !category.Hidden && !hiddenGroups[category.GroupID] {
+			progress, err := goal.Progress(month.Date, balance, v.Amount)
+			if err != nil {
+				return nil, err
+			}
+			res[i].Goal = beans.OptionalWrap(progress)
+		}
 	}
 
 	return res, nil
 }
+
+func (s *monthCategoryService) GetCarriedIn(ctx context.Context, month beans.Month) (beans.CarriedIn, error) {
+	snapshot, err := s.GetSnapshot(ctx, month.BudgetID, month.Date)
+	if err != nil {
+		return beans.CarriedIn{}, err
+	}
+
+	balances := make(map[beans.ID]beans.Amount, len(snapshot.Categories))
+	for categoryID, category := range snapshot.Categories {
+		balances[categoryID] = category.CarriedIn
+	}
+
+	return beans.CarriedIn{Balances: balances, Overspent: snapshot.Overspent}, nil
+}
+
+// Ends a month, getting the balance of each category going into the next
+// month and the cash overspending to take out of the next month's budgetable
+// amount.
+//
+// Overspent categories start the next month at zero. Overspending up to what
+// the category spent on credit accounts is credit overspending, and is taken
+// out of those accounts' payment categories as debt. The rest is cash
+// overspending. Credit activity is empty unless credit overspending is debt.
+// Categories to keep the balance of are never reset.
+func endMonth(
+	balances map[beans.ID]beans.Amount,
+	assigned map[beans.ID]beans.Amount,
+	activity map[beans.ID]beans.Amount,
+	creditActivity map[beans.ID]map[beans.ID]beans.Amount,
+	keepBalance map[beans.ID]bool,
+) (map[beans.ID]beans.Amount, beans.Amount, error) {
+	zero := beans.NewAmount(0, 0)
+
+	ending, err := sumByCategory(balances, assigned, activity)
+	if err != nil {
+		return nil, zero, err
+	}
+
+	cashOverspent := zero
+	debt := make(map[beans.ID]beans.Amount)
+	for categoryID, balance := range ending {
+		if keepBalance[categoryID] || balance.Compare(zero) >= 0 {
+			continue
+		}
+
+		overspent := beans.Arithmetic.Negate(balance)
+		ending[categoryID] = zero
+
+		// spread across credit accounts in a stable order
+		paymentCategoryIDs := make([]beans.ID, 0, len(creditActivity[categoryID]))
+		for paymentCategoryID := range creditActivity[categoryID] {
+			paymentCategoryIDs = append(paymentCategoryIDs, paymentCategoryID)
+		}
+		slices.SortFunc(paymentCategoryIDs, func(a, b beans.ID) int { return strings.Compare(a.String(), b.String()) })
+
+		for _, paymentCategoryID := range paymentCategoryIDs {
+			spent := beans.Arithmetic.Negate(creditActivity[categoryID][paymentCategoryID])
+			if spent.Compare(zero) <= 0 || overspent.Compare(zero) <= 0 {
+				continue
+			}
+
+			owed := spent
+			if overspent.Compare(spent) < 0 {
+				owed = overspent
+			}
+
+			if overspent, err = beans.Arithmetic.Add(overspent, beans.Arithmetic.Negate(owed)); err != nil {
+				return nil, zero, err
+			}
+			if debt[paymentCategoryID], err = beans.Arithmetic.Add(debt[paymentCategoryID].OrZero(), owed); err != nil {
+				return nil, zero, err
+			}
+		}
+
+		if cashOverspent, err = beans.Arithmetic.Add(cashOverspent, overspent); err != nil {
+			return nil, zero, err
+		}
+	}
+
+	for paymentCategoryID, owed := range debt {
+		if ending[paymentCategoryID], err = beans.Arithmetic.Add(ending[paymentCategoryID].OrZero(), beans.Arithmetic.Negate(owed)); err != nil {
+			return nil, zero, err
+		}
+	}
+
+	return ending, cashOverspent, nil
+}
+
+// Sums the amounts of each category.
+func sumByCategory(amounts ...map[beans.ID]beans.Amount) (map[beans.ID]beans.Amount, error) {
+	var err error
+
+	sums := make(map[beans.ID]beans.Amount)
+	for _, amounts := range amounts {
+		for categoryID, amount := range amounts {
+			if sums[categoryID], err = beans.Arithmetic.Add(sums[categoryID].OrZero(), amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sums, nil
+}
+
+// Gets the balance of each category before the month, summing everything
+// assigned and spent.
+func (s *monthCategoryService) getBalancesBefore(ctx context.Context, budgetID beans.ID, date beans.MonthDate) (map[beans.ID]beans.Amount, error) {
+	assigned, err := s.ds.MonthCategoryRepository().GetAssignedByCategory(ctx, budgetID, date.FirstDay())
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.GetActivityByCategory(ctx, budgetID, beans.Date{}, date.FirstDay().Previous())
+	if err != nil {
+		return nil, err
+	}
+
+	return sumByCategory(assigned, activity)
+}
+
+// Gets the income and credit card payment categories. Their balances carry
+// forward no matter the overspending rollover.
+func (s *monthCategoryService) getKeepBalanceCategories(ctx context.Context, budgetID beans.ID) (map[beans.ID]bool, error) {
+	groups, err := s.ds.CategoryRepository().GetGroupsForBudget(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	keepGroup := make(map[beans.ID]bool, len(groups))
+	for _, group := range groups {
+		keepGroup[group.ID] = group.IsIncome || group.IsCreditCardPayments
+	}
+
+	categories, err := s.ds.CategoryRepository().GetForBudget(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	keepBalance := make(map[beans.ID]bool)
+	for _, category := range categories {
+		if keepGroup[category.GroupID] {
+			keepBalance[category.ID] = true
+		}
+	}
+
+	return keepBalance, nil
+}
+
+func (s *monthCategoryService) GetActivityByCategory(ctx context.Context, budgetID beans.ID, from beans.Date, to beans.Date) (map[beans.ID]beans.Amount, error) {
+	activity, err := s.ds.TransactionRepository().GetActivityByCategory(ctx, budgetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentActivity, err := s.ds.TransactionRepository().GetPaymentActivityByCategory(ctx, budgetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for categoryID, amount := range paymentActivity {
+		total, err := beans.Arithmetic.Add(activity[categoryID].OrZero(), amount)
+		if err != nil {
+			return nil, err
+		}
+		activity[categoryID] = total
+	}
+
+	return activity, nil
+}
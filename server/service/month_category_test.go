@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/internal/testutils"
@@ -11,7 +12,7 @@ import (
 )
 
 func TestMonthCategory(t *testing.T) {
-	services, factory, _, _ := makeServices(t)
+	services, factory, ds, _ := makeServices(t)
 	ctx := context.Background()
 
 	t.Run("GetForMonth", func(t *testing.T) {
@@ -181,4 +182,152 @@ func TestMonthCategory(t *testing.T) {
 		})
 	})
 
+	t.Run("snapshots", func(t *testing.T) {
+
+		t.Run("are kept for the month and the months before it", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			april := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+			june := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-06-01")})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: april.ID, CategoryID: category.ID, Amount: beans.NewAmount(3, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{
+				CategoryID: category.ID,
+				Date:       testutils.NewDate(t, "2022-05-10"),
+				Amount:     beans.NewAmount(-1, 0),
+			})
+
+			_, err := services.MonthCategory.GetForMonth(ctx, june)
+			require.NoError(t, err)
+
+			snapshots, err := ds.MonthSnapshotRepository().GetForBudget(ctx, budget.ID)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 3)
+
+			may := snapshots[1]
+			assert.Equal(t, "2022-05-01", may.Date.String())
+			assert.Equal(t, beans.MonthCategorySnapshot{
+				CarriedIn: beans.NewAmount(3, 0),
+				Assigned:  beans.NewAmount(0, 0),
+				Activity:  beans.NewAmount(-1, 0),
+			}, may.Categories[category.ID])
+		})
+
+		t.Run("are rebuilt after a write", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			april := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+			may := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-05-01")})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: april.ID, CategoryID: category.ID, Amount: beans.NewAmount(3, 0)})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: may.ID, CategoryID: category.ID})
+
+			res, err := services.MonthCategory.GetForMonth(ctx, may)
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(3, 0), res[0].Available)
+
+			// spend $1 in April
+			factory.Transaction(budget.ID, beans.Transaction{
+				CategoryID: category.ID,
+				Date:       testutils.NewDate(t, "2022-04-10"),
+				Amount:     beans.NewAmount(-1, 0),
+			})
+
+			res, err = services.MonthCategory.GetForMonth(ctx, may)
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(2, 0), res[0].Available)
+
+			mismatches, err := services.MonthCategory.CheckSnapshots(ctx, budget.ID)
+			require.NoError(t, err)
+			assert.Empty(t, mismatches)
+		})
+
+		t.Run("match a full recompute when overspending is reset", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			budget.OverspendingRollover = beans.OverspendingReset
+			require.NoError(t, ds.BudgetRepository().Update(ctx, budget))
+
+			april := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+			june := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-06-01")})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: april.ID, CategoryID: category.ID, Amount: beans.NewAmount(3, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{
+				CategoryID: category.ID,
+				Date:       testutils.NewDate(t, "2022-04-10"),
+				Amount:     beans.NewAmount(-5, 0),
+			})
+
+			// build May first, then build June from May's snapshot
+			_, err := services.MonthCategory.GetSnapshot(ctx, budget.ID, testutils.NewMonthDate(t, "2022-05-01"))
+			require.NoError(t, err)
+			carriedIn, err := services.MonthCategory.GetCarriedIn(ctx, june)
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(0, 0), carriedIn.Balances[category.ID])
+
+			mismatches, err := services.MonthCategory.CheckSnapshots(ctx, budget.ID)
+			require.NoError(t, err)
+			assert.Empty(t, mismatches)
+		})
+
+		t.Run("are summed and not kept far in the future", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			april := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+			march := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2100-03-01")})
+			june := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2100-06-01")})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: april.ID, CategoryID: category.ID, Amount: beans.NewAmount(3, 0)})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: march.ID, CategoryID: category.ID, Amount: beans.NewAmount(4, 0)})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: june.ID, CategoryID: category.ID, Amount: beans.NewAmount(2, 0)})
+			factory.Transaction(budget.ID, beans.Transaction{
+				CategoryID: category.ID,
+				Date:       testutils.NewDate(t, "2100-05-10"),
+				Amount:     beans.NewAmount(-1, 0),
+			})
+			factory.Transaction(budget.ID, beans.Transaction{
+				CategoryID: category.ID,
+				Date:       testutils.NewDate(t, "2100-06-10"),
+				Amount:     beans.NewAmount(-5, 0),
+			})
+
+			res, err := services.MonthCategory.GetForMonth(ctx, june)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, beans.NewAmount(-5, 0), res[0].Activity)
+			assert.Equal(t, beans.NewAmount(3, 0), res[0].Available)
+
+			// only months up to a year from now are stored
+			snapshots, err := ds.MonthSnapshotRepository().GetForBudget(ctx, budget.ID)
+			require.NoError(t, err)
+			limit := time.Now().AddDate(1, 1, 0)
+			for _, snapshot := range snapshots {
+				assert.True(t, snapshot.Date.Time().Before(limit))
+			}
+		})
+
+		t.Run("check finds differences", func(t *testing.T) {
+			budget, _ := factory.MakeBudgetAndUser()
+			april := factory.Month(beans.Month{BudgetID: budget.ID, Date: testutils.NewMonthDate(t, "2022-04-01")})
+			category := factory.Category(beans.Category{BudgetID: budget.ID})
+			factory.MonthCategory(budget.ID, beans.MonthCategory{MonthID: april.ID, CategoryID: category.ID, Amount: beans.NewAmount(3, 0)})
+
+			version, err := ds.MonthSnapshotRepository().GetVersion(ctx, budget.ID)
+			require.NoError(t, err)
+			require.NoError(t, ds.MonthSnapshotRepository().Create(ctx, nil, budget.ID, version, []beans.MonthSnapshot{{
+				BudgetID:  budget.ID,
+				Date:      april.Date,
+				Overspent: beans.NewAmount(0, 0),
+				Categories: map[beans.ID]beans.MonthCategorySnapshot{
+					category.ID: {CarriedIn: beans.NewAmount(0, 0), Assigned: beans.NewAmount(4, 0), Activity: beans.NewAmount(0, 0)},
+				},
+			}}))
+
+			mismatches, err := services.MonthCategory.CheckSnapshots(ctx, budget.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.MonthSnapshotMismatch{{
+				Date:       april.Date,
+				CategoryID: category.ID,
+				Field:      "assigned",
+				Snapshot:   beans.NewAmount(4, 0),
+				Recomputed: beans.NewAmount(3, 0),
+			}}, mismatches)
+		})
+	})
 }
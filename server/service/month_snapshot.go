@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+func (s *monthCategoryService) GetSnapshot(ctx context.Context, budgetID beans.ID, date beans.MonthDate) (beans.MonthSnapshot, error) {
+	// months are only built one at a time up to a limit, so a month far in
+	// the future does not build and store every month before it
+	current := beans.NewMonthDate(beans.NewDate(time.Now()))
+	limit := beans.NewMonthDate(beans.NewDate(current.FirstDay().AddDate(0, beans.MaxMonthRange, 0)))
+	if date.Time().After(limit.Time()) {
+		return s.getSnapshotAfterLimit(ctx, budgetID, limit, date)
+	}
+
+	snapshot, err := s.ds.MonthSnapshotRepository().Get(ctx, budgetID, date)
+	if err == nil || !errors.Is(err, beans.ErrorNotFound) {
+		return snapshot, err
+	}
+
+	// read the version first so nothing written while building is missed
+	version, err := s.ds.MonthSnapshotRepository().GetVersion(ctx, budgetID)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	budget, err := s.ds.BudgetRepository().Get(ctx, budgetID)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+	months, err := s.ds.MonthRepository().GetForBudget(ctx, budgetID)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	from := beans.Optional[beans.MonthSnapshot]{}
+	latest, err := s.ds.MonthSnapshotRepository().GetLatestBefore(ctx, budgetID, date)
+	if err == nil {
+		from = beans.OptionalWrap(latest)
+	} else if !errors.Is(err, beans.ErrorNotFound) {
+		return beans.MonthSnapshot{}, err
+	}
+
+	snapshots, err := s.buildSnapshots(ctx, budget, months, from, date)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	// months before the budget's first month are not kept, as building
+	// from them would end months that the budget does not have
+	first, ok := firstMonth(months)
+	if ok && !date.Time().Before(first.Time()) {
+		err = beans.ExecTxNil(ctx, s.ds.TxManager(), func(tx beans.Tx) error {
+			return s.ds.MonthSnapshotRepository().Create(ctx, tx, budgetID, version, snapshots)
+		})
+		if err != nil {
+			return beans.MonthSnapshot{}, err
+		}
+	}
+
+	return snapshots[len(snapshots)-1], nil
+}
+
+// Gets the snapshot of a month after the limit without storing it. Everything
+// between the limit and the month is summed as if it were one month.
+func (s *monthCategoryService) getSnapshotAfterLimit(ctx context.Context, budgetID beans.ID, limit beans.MonthDate, date beans.MonthDate) (beans.MonthSnapshot, error) {
+	last, err := s.GetSnapshot(ctx, budgetID, limit)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	budget, err := s.ds.BudgetRepository().Get(ctx, budgetID)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+	keepBalance, err := s.getKeepBalance(ctx, budget)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	balances, overspent, err := s.endSnapshot(ctx, budget, keepBalance, last)
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	if begin := limit.Next(); begin.Time().Before(date.Time()) {
+		assigned, err := s.getAssignedBetween(ctx, budgetID, begin.FirstDay(), date.FirstDay())
+		if err != nil {
+			return beans.MonthSnapshot{}, err
+		}
+		activity, err := s.GetActivityByCategory(ctx, budgetID, begin.FirstDay(), date.FirstDay().Previous())
+		if err != nil {
+			return beans.MonthSnapshot{}, err
+		}
+
+		if balances, overspent, err = s.endPeriod(ctx, budget, keepBalance, balances, assigned, activity, begin.FirstDay(), date.FirstDay().Previous()); err != nil {
+			return beans.MonthSnapshot{}, err
+		}
+	}
+
+	assigned, err := s.getAssignedBetween(ctx, budgetID, date.FirstDay(), date.Next().FirstDay())
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+	activity, err := s.GetActivityByCategory(ctx, budgetID, date.FirstDay(), date.LastDay())
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	return newSnapshot(budgetID, date, balances, overspent, assigned, activity), nil
+}
+
+// Gets the amount assigned to each category in months from the beginning
+// up to, but not including, the end.
+func (s *monthCategoryService) getAssignedBetween(ctx context.Context, budgetID beans.ID, begin beans.Date, end beans.Date) (map[beans.ID]beans.Amount, error) {
+	before, err := s.ds.MonthCategoryRepository().GetAssignedByCategory(ctx, budgetID, begin)
+	if err != nil {
+		return nil, err
+	}
+	through, err := s.ds.MonthCategoryRepository().GetAssignedByCategory(ctx, budgetID, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for categoryID, amount := range before {
+		before[categoryID] = beans.Arithmetic.Negate(amount)
+	}
+	return sumByCategory(through, before)
+}
+
+func (s *monthCategoryService) CheckSnapshots(ctx context.Context, budgetID beans.ID) ([]beans.MonthSnapshotMismatch, error) {
+	stored, err := s.ds.MonthSnapshotRepository().GetForBudget(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	mismatches := []beans.MonthSnapshotMismatch{}
+	if len(stored) == 0 {
+		return mismatches, nil
+	}
+
+	budget, err := s.ds.BudgetRepository().Get(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	months, err := s.ds.MonthRepository().GetForBudget(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	recomputed, err := s.buildSnapshots(ctx, budget, months, beans.Optional[beans.MonthSnapshot]{}, stored[len(stored)-1].Date)
+	if err != nil {
+		return nil, err
+	}
+	recomputedByDate := make(map[string]beans.MonthSnapshot, len(recomputed))
+	for _, snapshot := range recomputed {
+		recomputedByDate[snapshot.Date.String()] = snapshot
+	}
+
+	for _, snapshot := range stored {
+		expected := recomputedByDate[snapshot.Date.String()]
+
+		if snapshot.Overspent.Compare(expected.Overspent.OrZero()) != 0 {
+			mismatches = append(mismatches, beans.MonthSnapshotMismatch{
+				Date:       snapshot.Date,
+				Field:      "overspent",
+				Snapshot:   snapshot.Overspent,
+				Recomputed: expected.Overspent.OrZero(),
+			})
+		}
+
+		categoryIDs := make(map[beans.ID]bool)
+		for categoryID := range snapshot.Categories {
+			categoryIDs[categoryID] = true
+		}
+		for categoryID := range expected.Categories {
+			categoryIDs[categoryID] = true
+		}
+
+		for categoryID := range categoryIDs {
+			actual, want := snapshot.Categories[categoryID], expected.Categories[categoryID]
+			for _, field := range []struct {
+				name   string
+				actual beans.Amount
+				want   beans.Amount
+			}{
+				{"carried_in", actual.CarriedIn.OrZero(), want.CarriedIn.OrZero()},
+				{"assigned", actual.Assigned.OrZero(), want.Assigned.OrZero()},
+				{"activity", actual.Activity.OrZero(), want.Activity.OrZero()},
+			} {
+				if field.actual.Compare(field.want) != 0 {
+					mismatches = append(mismatches, beans.MonthSnapshotMismatch{
+						Date:       snapshot.Date,
+						CategoryID: categoryID,
+						Field:      field.name,
+						Snapshot:   field.actual,
+						Recomputed: field.want,
+					})
+				}
+			}
+		}
+	}
+
+	return mismatches, nil
+}
+
+// Builds the snapshot of each month after the snapshot to start from
+// through the month, following the budget's overspending rollover. Without
+// a snapshot to start from, building starts at the budget's first month
+// with everything before it carried in.
+func (s *monthCategoryService) buildSnapshots(
+	ctx context.Context,
+	budget beans.Budget,
+	months []beans.Month,
+	from beans.Optional[beans.MonthSnapshot],
+	to beans.MonthDate,
+) ([]beans.MonthSnapshot, error) {
+	monthsByDate := make(map[string]beans.Month, len(months))
+	for _, m := range months {
+		monthsByDate[m.Date.String()] = m
+	}
+
+	keepBalance, err := s.getKeepBalance(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	var date beans.MonthDate
+	var balances map[beans.ID]beans.Amount
+	var overspent beans.Amount
+	if snapshot, ok := from.Value(); ok {
+		if balances, overspent, err = s.endSnapshot(ctx, budget, keepBalance, snapshot); err != nil {
+			return nil, err
+		}
+		date = snapshot.Date.Next()
+	} else {
+		date = to
+		if first, ok := firstMonth(months); ok && first.Time().Before(to.Time()) {
+			date = first
+		}
+
+		if balances, err = s.getBalancesBefore(ctx, budget.ID, date); err != nil {
+			return nil, err
+		}
+		overspent = beans.NewAmount(0, 0)
+	}
+
+	snapshots := []beans.MonthSnapshot{}
+	for ; !date.Time().After(to.Time()); date = date.Next() {
+		assigned := make(map[beans.ID]beans.Amount)
+		if m, ok := monthsByDate[date.String()]; ok {
+			monthCategories, err := s.ds.MonthCategoryRepository().GetForMonth(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			for _, monthCategory := range monthCategories {
+				assigned[monthCategory.CategoryID] = monthCategory.Amount
+			}
+		}
+
+		activity, err := s.GetActivityByCategory(ctx, budget.ID, date.FirstDay(), date.LastDay())
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := newSnapshot(budget.ID, date, balances, overspent, assigned, activity)
+		snapshots = append(snapshots, snapshot)
+
+		if date.Time().Before(to.Time()) {
+			if balances, overspent, err = s.endSnapshot(ctx, budget, keepBalance, snapshot); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return snapshots, nil
+}
+
+// Gets the balances and cash overspending the month after the snapshot
+// starts with.
+func (s *monthCategoryService) endSnapshot(
+	ctx context.Context,
+	budget beans.Budget,
+	keepBalance map[beans.ID]bool,
+	snapshot beans.MonthSnapshot,
+) (map[beans.ID]beans.Amount, beans.Amount, error) {
+	balances := make(map[beans.ID]beans.Amount, len(snapshot.Categories))
+	assigned := make(map[beans.ID]beans.Amount, len(snapshot.Categories))
+	activity := make(map[beans.ID]beans.Amount, len(snapshot.Categories))
+	for categoryID, category := range snapshot.Categories {
+		balances[categoryID] = category.CarriedIn
+		assigned[categoryID] = category.Assigned
+		activity[categoryID] = category.Activity
+	}
+
+	return s.endPeriod(ctx, budget, keepBalance, balances, assigned, activity, snapshot.Date.FirstDay(), snapshot.Date.LastDay())
+}
+
+// Ends the period between the dates, as endMonth does, following the
+// budget's overspending rollover.
+func (s *monthCategoryService) endPeriod(
+	ctx context.Context,
+	budget beans.Budget,
+	keepBalance map[beans.ID]bool,
+	balances map[beans.ID]beans.Amount,
+	assigned map[beans.ID]beans.Amount,
+	activity map[beans.ID]beans.Amount,
+	begin beans.Date,
+	end beans.Date,
+) (map[beans.ID]beans.Amount, beans.Amount, error) {
+	switch budget.OverspendingRollover {
+	case beans.OverspendingReset:
+		return endMonth(balances, assigned, activity, nil, keepBalance)
+	case beans.OverspendingResetCreditAsDebt:
+		creditActivity, err := s.ds.TransactionRepository().GetCreditActivityByCategory(ctx, budget.ID, begin, end)
+		if err != nil {
+			return nil, beans.Amount{}, err
+		}
+		return endMonth(balances, assigned, activity, creditActivity, keepBalance)
+	default:
+		// carried forward overspending stays in the category
+		ending, err := sumByCategory(balances, assigned, activity)
+		return ending, beans.NewAmount(0, 0), err
+	}
+}
+
+// Builds a snapshot of every category with an amount. Amounts are
+// normalized to match snapshots read back from storage.
+func newSnapshot(
+	budgetID beans.ID,
+	date beans.MonthDate,
+	balances map[beans.ID]beans.Amount,
+	overspent beans.Amount,
+	assigned map[beans.ID]beans.Amount,
+	activity map[beans.ID]beans.Amount,
+) beans.MonthSnapshot {
+	categories := make(map[beans.ID]beans.MonthCategorySnapshot)
+	for _, amounts := range []map[beans.ID]beans.Amount{balances, assigned, activity} {
+		for categoryID := range amounts {
+			categories[categoryID] = beans.MonthCategorySnapshot{
+				CarriedIn: balances[categoryID].OrZero().Normalize(),
+				Assigned:  assigned[categoryID].OrZero().Normalize(),
+				Activity:  activity[categoryID].OrZero().Normalize(),
+			}
+		}
+	}
+
+	return beans.MonthSnapshot{
+		BudgetID:   budgetID,
+		Date:       date,
+		Overspent:  overspent.Normalize(),
+		Categories: categories,
+	}
+}
+
+// Gets the categories that are never reset, if the budget resets
+// overspending.
+func (s *monthCategoryService) getKeepBalance(ctx context.Context, budget beans.Budget) (map[beans.ID]bool, error) {
+	if budget.OverspendingRollover != beans.OverspendingReset && budget.OverspendingRollover != beans.OverspendingResetCreditAsDebt {
+		return nil, nil
+	}
+
+	return s.getKeepBalanceCategories(ctx, budget.ID)
+}
+
+func firstMonth(months []beans.Month) (beans.MonthDate, bool) {
+	if len(months) == 0 {
+		return beans.MonthDate{}, false
+	}
+
+	first := months[0].Date
+	for _, m := range months[1:] {
+		if m.Date.Time().Before(first.Time()) {
+			first = m.Date
+		}
+	}
+	return first, true
+}
@@ -57,6 +57,157 @@ func testAccount(t *testing.T, interactor Interactor) {
 			assert.Equal(t, beans.Name("New Account"), account.Name)
 			assert.Equal(t, true, account.OffBudget)
 		})
+
+		t.Run("can create with opening balance", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			accountID, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name:           beans.Name("Checking"),
+				OpeningBalance: beans.NewAmount(10025, -2),
+				OpeningDate:    testutils.NewDate(t, "2022-05-03"),
+			})
+			require.NoError(t, err)
+
+			// starting balance transaction was made
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, transactions, 1)
+
+			transaction := transactions[0]
+			assert.Equal(t, accountID, transaction.Account.ID)
+			assert.Equal(t, beans.NewAmount(10025, -2), transaction.Amount)
+			assert.Equal(t, testutils.NewDate(t, "2022-05-03"), transaction.Date)
+			assert.Equal(t, beans.NewTransactionNotes("Starting Balance"), transaction.Notes)
+			assert.Equal(t, beans.TransactionCleared, transaction.Status)
+			assert.Equal(t, beans.OptionalWrap(c.findIncomeCategory().ToRelated()), transaction.Category)
+
+			// balance is income to be budgeted
+			month, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-05-01"))
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(10025, -2), month.Income)
+			assert.Equal(t, beans.NewAmount(10025, -2), month.Budgetable)
+		})
+
+		t.Run("off budget opening balance has no category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name:           beans.Name("House"),
+				OffBudget:      true,
+				OpeningBalance: beans.NewAmount(2500055, -2),
+				OpeningDate:    testutils.NewDate(t, "2022-05-03"),
+			})
+			require.NoError(t, err)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, transactions, 1)
+			assert.Equal(t, beans.NewAmount(2500055, -2), transactions[0].Amount)
+			assert.True(t, transactions[0].Category.Empty())
+		})
+
+		t.Run("zero opening balance makes no transaction", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name:           beans.Name("Checking"),
+				OpeningBalance: beans.NewAmount(0, 0),
+			})
+			require.NoError(t, err)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 0)
+		})
+
+		t.Run("opening balance requires date", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name:           beans.Name("Checking"),
+				OpeningBalance: beans.NewAmount(5, 0),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Opening date is required.")
+
+			// account was not made
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, accounts, 0)
+		})
+
+		t.Run("defaults to checking", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			assert.Equal(t, beans.AccountChecking, account.Type)
+			assert.True(t, account.PaymentCategoryID.Empty())
+		})
+
+		t.Run("cannot create with invalid type", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name: beans.Name("New Account"),
+				Type: beans.AccountType("bank"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account type bank is not supported.")
+		})
+
+		t.Run("credit card gets payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			accountID, err := interactor.AccountCreate(t, c.ctx, beans.AccountCreate{
+				Name: beans.Name("Visa"),
+				Type: beans.AccountCreditCard,
+			})
+			require.NoError(t, err)
+
+			account, err := interactor.AccountGet(t, c.ctx, accountID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.AccountCreditCard, account.Type)
+			require.False(t, account.PaymentCategoryID.Empty())
+
+			// payment category is named after the account
+			category, err := interactor.CategoryGet(t, c.ctx, account.PaymentCategoryID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Visa"), category.Name)
+
+			group, err := interactor.CategoryGroupGet(t, c.ctx, category.GroupID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Credit Card Payments"), group.Name)
+			assert.True(t, group.IsCreditCardPayments)
+			assert.False(t, group.IsIncome)
+		})
+
+		t.Run("credit accounts share payment group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			card := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			credit := c.Account(AccountOpts{Type: beans.AccountLineOfCredit})
+
+			cardCategory, err := interactor.CategoryGet(t, c.ctx, card.PaymentCategoryID)
+			require.NoError(t, err)
+			creditCategory, err := interactor.CategoryGet(t, c.ctx, credit.PaymentCategoryID)
+			require.NoError(t, err)
+
+			assert.NotEqual(t, cardCategory.ID, creditCategory.ID)
+			assert.Equal(t, cardCategory.GroupID, creditCategory.GroupID)
+		})
+
+		t.Run("off budget credit card has no payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountCreditCard, OffBudget: true})
+
+			assert.True(t, account.PaymentCategoryID.Empty())
+		})
+
+		t.Run("other types have no payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountLoan})
+
+			assert.Equal(t, beans.AccountLoan, account.Type)
+			assert.True(t, account.PaymentCategoryID.Empty())
+		})
 	})
 
 	t.Run("get", func(t *testing.T) {
@@ -133,5 +284,763 @@ func testAccount(t *testing.T, interactor Interactor) {
 				assert.Equal(t, true, it.OffBudget)
 			})
 		})
+
+		t.Run("excludes closed accounts", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			open := c.Account(AccountOpts{})
+			closed := c.Account(AccountOpts{})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, closed.ID))
+
+			accounts, err := interactor.AccountListTransactable(t, c.ctx)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(accounts))
+			assert.Equal(t, open.ID, accounts[0].ID)
+		})
+	})
+
+	t.Run("update", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			err := interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{ID: account.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account name is required.")
+		})
+
+		t.Run("can rename", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			require.NoError(t, interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:   account.ID,
+				Name: "Checking",
+			}))
+
+			res, err := interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Checking"), res.Name)
+			assert.False(t, res.OffBudget)
+		})
+
+		t.Run("can rename with transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account})
+
+			require.NoError(t, interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:   account.ID,
+				Name: "Checking",
+			}))
+
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Checking"), res.Account.Name)
+		})
+
+		t.Run("can move off budget without transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			require.NoError(t, interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:        account.ID,
+				Name:      account.Name,
+				OffBudget: true,
+			}))
+
+			res, err := interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			assert.True(t, res.OffBudget)
+		})
+
+		t.Run("moving credit card on budget makes payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountCreditCard, OffBudget: true})
+
+			require.NoError(t, interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:   account.ID,
+				Name: account.Name,
+			}))
+
+			res, err := interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			require.False(t, res.PaymentCategoryID.Empty())
+
+			_, err = interactor.CategoryGet(t, c.ctx, res.PaymentCategoryID)
+			require.NoError(t, err)
+		})
+
+		t.Run("cannot move on or off budget with transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			onBudget := c.Account(AccountOpts{})
+			offBudget := c.Account(AccountOpts{OffBudget: true})
+			c.Transfer(TransferOpts{AccountA: onBudget, AccountB: offBudget, Amount: "5"})
+
+			err := interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:        onBudget.ID,
+				Name:      onBudget.Name,
+				OffBudget: true,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move an account with transactions on or off budget.")
+
+			err = interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{
+				ID:   offBudget.ID,
+				Name: offBudget.Name,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move an account with transactions on or off budget.")
+		})
+
+		t.Run("cannot update account from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			account := c2.Account(AccountOpts{})
+
+			err := interactor.AccountUpdate(t, c1.ctx, beans.AccountUpdateParams{ID: account.ID, Name: "Checking"})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("close", func(t *testing.T) {
+
+		t.Run("can close and reopen", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account, Amount: "5"})
+			c.Transaction(TransactionOpts{Account: account, Amount: "-5"})
+
+			require.NoError(t, interactor.AccountClose(t, c.ctx, account.ID))
+
+			res, err := interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			assert.True(t, res.Closed)
+
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, accounts, 1)
+			assert.True(t, accounts[0].Closed)
+
+			require.NoError(t, interactor.AccountReopen(t, c.ctx, account.ID))
+
+			res, err = interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			assert.False(t, res.Closed)
+		})
+
+		t.Run("balance must be zero", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account, Amount: "-10.25"})
+
+			err := interactor.AccountClose(t, c.ctx, account.ID)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account balance of -10.25 must be zero to close.")
+		})
+
+		t.Run("renaming keeps account closed", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, account.ID))
+
+			require.NoError(t, interactor.AccountUpdate(t, c.ctx, beans.AccountUpdateParams{ID: account.ID, Name: "Old card"}))
+
+			res, err := interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+			assert.True(t, res.Closed)
+		})
+
+		t.Run("cannot close or reopen account from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			account := c2.Account(AccountOpts{})
+
+			err := interactor.AccountClose(t, c1.ctx, account.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			err = interactor.AccountReopen(t, c1.ctx, account.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot add transactions to closed account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			closed := c.Account(AccountOpts{})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, closed.ID))
+
+			_, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: closed.ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-15"),
+				},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account is closed.")
+
+			_, err = interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-15"),
+				},
+				TransferAccountID: closed.ID,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transfer account is closed.")
+		})
+
+		t.Run("cannot edit transactions on closed account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account, Amount: "0", Date: "2024-01-15"})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, account.ID))
+
+			err := interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(0, 0),
+					Date:      testutils.NewDate(t, "2024-01-15"),
+					Notes:     beans.NewTransactionNotes("changed"),
+				},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account is closed.")
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+
+		t.Run("can delete empty account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			require.NoError(t, interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{ID: account.ID}))
+
+			_, err := interactor.AccountGet(t, c.ctx, account.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot delete account from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			account := c2.Account(AccountOpts{})
+
+			err := interactor.AccountDelete(t, c1.ctx, beans.AccountDeleteParams{ID: account.ID})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("account with transactions needs account to move to", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account})
+
+			err := interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{ID: account.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account has transactions. Choose an account to move them to.")
+
+			err = interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{ID: account.ID, MoveToAccountID: account.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move transactions to the account being deleted.")
+
+			// account is kept
+			_, err = interactor.AccountGet(t, c.ctx, account.ID)
+			require.NoError(t, err)
+		})
+
+		t.Run("cannot move to invalid account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account})
+
+			err := interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: makeUserAndBudget(t, interactor).Account(AccountOpts{}).ID,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Move To Account ID.")
+
+			err = interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: c.Account(AccountOpts{OffBudget: true}).ID,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move transactions between on-budget and off-budget accounts.")
+
+			closed := c.Account(AccountOpts{})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, closed.ID))
+			err = interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: closed.ID,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move transactions to a closed account.")
+		})
+
+		t.Run("moves transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			moveTo := c.Account(AccountOpts{})
+			other := c.Account(AccountOpts{})
+
+			transaction := c.Transaction(TransactionOpts{Account: account, Amount: "-5.25"})
+			split, _ := c.Split(SplitOpts{Account: account, Splits: []SplitOpt{{Amount: "-1"}, {Amount: "-2.5"}}})
+			transfer := c.Transfer(TransferOpts{AccountA: account, AccountB: other, Amount: "7"})
+
+			require.NoError(t, interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: moveTo.ID,
+			}))
+
+			_, err := interactor.AccountGet(t, c.ctx, account.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, moveTo.ID, res.Account.ID)
+
+			res, err = interactor.TransactionGet(t, c.ctx, split.ID)
+			require.NoError(t, err)
+			assert.Equal(t, moveTo.ID, res.Account.ID)
+
+			// the transfer is now with the moved to account
+			res, err = interactor.TransactionGet(t, c.ctx, transfer[1].ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.OptionalWrap(moveTo.ToRelated()), res.TransferAccount)
+
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			findAccountWithBalance(t, accounts, moveTo.ID, func(it beans.AccountWithBalance) {
+				assert.Equal(t, beans.NewAmount(-175, -2), it.Balance)
+			})
+
+			// the move is in history
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+			before, ok := history[1].Before.Value()
+			require.True(t, ok)
+			assert.Equal(t, account.ID, before.AccountID)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, moveTo.ID, after.AccountID)
+
+			// and so is the move of each split line
+			splitLines, err := interactor.TransactionGetSplits(t, c.ctx, split.ID)
+			require.NoError(t, err)
+			for _, line := range splitLines {
+				history, err := interactor.TransactionGetHistory(t, c.ctx, line.ID)
+				require.NoError(t, err)
+				require.Len(t, history, 2)
+				after, ok := history[1].After.Value()
+				require.True(t, ok)
+				assert.Equal(t, moveTo.ID, after.AccountID)
+			}
+		})
+
+		t.Run("moves scheduled transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			moveTo := c.Account(AccountOpts{})
+			other := c.Account(AccountOpts{})
+
+			schedule := func(accountID beans.ID, transferAccountID beans.ID) beans.ID {
+				id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+					Template: beans.TransactionCreateParams{
+						TransferAccountID: transferAccountID,
+						TransactionParams: beans.TransactionParams{
+							AccountID: accountID,
+							Amount:    beans.NewAmount(5, 0),
+							Date:      testutils.NewDate(t, "2022-01-01"),
+						},
+					},
+					Frequency: beans.ScheduleMonths,
+					Interval:  1,
+				})
+				require.NoError(t, err)
+				return id
+			}
+			onAccount := schedule(account.ID, beans.EmptyID())
+			toAccount := schedule(other.ID, account.ID)
+			betweenAccounts := schedule(moveTo.ID, account.ID)
+
+			// scheduled transactions alone need an account to move to
+			err := interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{ID: account.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account has scheduled transactions. Choose an account to move them to.")
+
+			require.NoError(t, interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: moveTo.ID,
+			}))
+
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, onAccount)
+			require.NoError(t, err)
+			assert.Equal(t, moveTo.ID, scheduled.Template.AccountID)
+
+			scheduled, err = interactor.ScheduledTransactionGet(t, c.ctx, toAccount)
+			require.NoError(t, err)
+			assert.Equal(t, moveTo.ID, scheduled.Template.TransferAccountID)
+
+			// it would transfer to itself
+			_, err = interactor.ScheduledTransactionGet(t, c.ctx, betweenAccounts)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("moves payment category to other credit account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			card := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			moveTo := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+
+			month := c.Month(MonthOpts{Date: "2022-05-01"})
+			c.setAssigned(month, beans.Category{ID: card.PaymentCategoryID}, "10")
+			c.setAssigned(month, beans.Category{ID: moveTo.PaymentCategoryID}, "5")
+
+			// the payment category needs a credit account to move to
+			err := interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{ID: card.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account has a payment category. Choose a credit account to move it to.")
+
+			err = interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              card.ID,
+				MoveToAccountID: c.Account(AccountOpts{}).ID,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move a payment category to an account that is not a credit account.")
+
+			require.NoError(t, interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              card.ID,
+				MoveToAccountID: moveTo.ID,
+			}))
+
+			_, err = interactor.CategoryGet(t, c.ctx, card.PaymentCategoryID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+			require.NoError(t, err)
+			findMonthCategory(t, res.Categories, moveTo.PaymentCategoryID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(15, 0), it.Amount)
+			})
+		})
+
+		t.Run("deletes transfers with moved to account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			moveTo := c.Account(AccountOpts{})
+
+			c.Transaction(TransactionOpts{Account: moveTo, Amount: "20.15"})
+			transfer := c.Transfer(TransferOpts{AccountA: account, AccountB: moveTo, Amount: "7"})
+
+			require.NoError(t, interactor.AccountDelete(t, c.ctx, beans.AccountDeleteParams{
+				ID:              account.ID,
+				MoveToAccountID: moveTo.ID,
+			}))
+
+			_, err := interactor.TransactionGet(t, c.ctx, transfer[0].ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+			_, err = interactor.TransactionGet(t, c.ctx, transfer[1].ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			// moved to account is left with only its own transaction
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, accounts, 1)
+			assert.Equal(t, beans.NewAmount(2015, -2), accounts[0].Balance)
+		})
+	})
+
+	t.Run("balances", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "From is required. To is required.")
+
+			_, err = interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				Interval: "year",
+				From:     testutils.NewDate(t, "2022-05-01"),
+				To:       testutils.NewDate(t, "2022-05-31"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Interval year is not supported.")
+
+			_, err = interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				From: testutils.NewDate(t, "2022-05-31"),
+				To:   testutils.NewDate(t, "2022-05-01"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "From must not be after To.")
+
+			_, err = interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				Interval: beans.BalanceDaily,
+				From:     testutils.NewDate(t, "2022-01-01"),
+				To:       testutils.NewDate(t, "2023-01-01"),
+			})
+			require.NoError(t, err)
+
+			_, err = interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				Interval: beans.BalanceDaily,
+				From:     testutils.NewDate(t, "2022-01-01"),
+				To:       testutils.NewDate(t, "2023-01-02"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Range must be at most 366 balances.")
+		})
+
+		t.Run("cannot get for account in other budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			account := c2.Account(AccountOpts{})
+
+			_, err := interactor.AccountGetBalances(t, c1.ctx, beans.AccountBalanceParams{
+				AccountID: account.ID,
+				From:      testutils.NewDate(t, "2022-05-01"),
+				To:        testutils.NewDate(t, "2022-05-31"),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("monthly by default", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-03-10", Amount: "10"})
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-04-05", Amount: "-1.25"})
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-06-20", Amount: "3.5"})
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-07-01", Amount: "100"})
+
+			res, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				AccountID: account.ID,
+				From:      testutils.NewDate(t, "2022-04-15"),
+				To:        testutils.NewDate(t, "2022-06-30"),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, []beans.AccountBalance{
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-04-01"), Balance: beans.NewAmount(875, -2)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-01"), Balance: beans.NewAmount(875, -2)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-06-01"), Balance: beans.NewAmount(1225, -2)},
+			}, res)
+		})
+
+		t.Run("weekly starting on monday", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-05-08", Amount: "-2.5"})
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-05-09", Amount: "1"})
+
+			res, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				AccountID: account.ID,
+				Interval:  beans.BalanceWeekly,
+				From:      testutils.NewDate(t, "2022-05-04"),
+				To:        testutils.NewDate(t, "2022-05-17"),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, []beans.AccountBalance{
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-02"), Balance: beans.NewAmount(-25, -1)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-09"), Balance: beans.NewAmount(-15, -1)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-16"), Balance: beans.NewAmount(-15, -1)},
+			}, res)
+		})
+
+		t.Run("daily", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			c.Transaction(TransactionOpts{Account: account, Date: "2022-05-02", Amount: "5.05"})
+
+			res, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				AccountID: account.ID,
+				Interval:  beans.BalanceDaily,
+				From:      testutils.NewDate(t, "2022-05-01"),
+				To:        testutils.NewDate(t, "2022-05-03"),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, []beans.AccountBalance{
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-01"), Balance: beans.NewAmount(0, 0)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-02"), Balance: beans.NewAmount(505, -2)},
+				{AccountID: account.ID, Date: testutils.NewDate(t, "2022-05-03"), Balance: beans.NewAmount(505, -2)},
+			}, res)
+		})
+
+		t.Run("excludes split parents", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			c.Split(SplitOpts{Account: account, Date: "2022-05-02", Splits: []SplitOpt{
+				{Amount: "-4"},
+				{Amount: "-6.5"},
+			}})
+
+			res, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				AccountID: account.ID,
+				From:      testutils.NewDate(t, "2022-05-01"),
+				To:        testutils.NewDate(t, "2022-05-31"),
+			})
+			require.NoError(t, err)
+
+			require.Len(t, res, 1)
+			assert.Equal(t, beans.NewAmount(-105, -1), res[0].Balance)
+		})
+
+		t.Run("can get for all accounts", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			checking := c.Account(AccountOpts{})
+			savings := c.Account(AccountOpts{})
+			c.Account(AccountOpts{})
+
+			// not in this budget
+			makeUserAndBudget(t, interactor).Transaction(TransactionOpts{Date: "2022-05-02"})
+
+			c.Transaction(TransactionOpts{Account: checking, Date: "2022-05-02", Amount: "7.77"})
+			c.Transaction(TransactionOpts{Account: savings, Date: "2022-06-02", Amount: "-1.11"})
+
+			res, err := interactor.AccountGetBalances(t, c.ctx, beans.AccountBalanceParams{
+				From: testutils.NewDate(t, "2022-05-01"),
+				To:   testutils.NewDate(t, "2022-06-30"),
+			})
+			require.NoError(t, err)
+			assert.Len(t, res, 6)
+
+			balancesOf := func(accountID beans.ID) []beans.Amount {
+				balances := []beans.Amount{}
+				for _, b := range res {
+					if b.AccountID == accountID {
+						balances = append(balances, b.Balance)
+					}
+				}
+				return balances
+			}
+
+			assert.Equal(t, []beans.Amount{beans.NewAmount(777, -2), beans.NewAmount(777, -2)}, balancesOf(checking.ID))
+			assert.Equal(t, []beans.Amount{beans.NewAmount(0, 0), beans.NewAmount(-111, -2)}, balancesOf(savings.ID))
+		})
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Balance is required.")
+		})
+
+		t.Run("cannot reconcile account from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: c2.Account(AccountOpts{}).ID,
+				Balance:   beans.NewAmount(0, 0),
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("reconciles cleared transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			uncleared := c.Transaction(TransactionOpts{Account: account, Amount: "5", Date: "2024-01-15"})
+			cleared := c.Transaction(TransactionOpts{Account: account, Amount: "-3.25", Date: "2024-01-15", Status: beans.TransactionCleared})
+
+			adjustmentID, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: account.ID,
+				Balance:   beans.NewAmount(-325, -2),
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
+			require.NoError(t, err)
+			assert.True(t, adjustmentID.Empty())
+
+			res, err := interactor.TransactionGet(t, c.ctx, uncleared.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionUncleared, res.Status)
+
+			res, err = interactor.TransactionGet(t, c.ctx, cleared.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionReconciled, res.Status)
+
+			// the reconciled transaction has history of it
+			history, err := interactor.TransactionGetHistory(t, c.ctx, cleared.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+			before, ok := history[1].Before.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.TransactionCleared, before.Status)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.TransactionReconciled, after.Status)
+
+			history, err = interactor.TransactionGetHistory(t, c.ctx, uncleared.ID)
+			require.NoError(t, err)
+			assert.Len(t, history, 1)
+		})
+
+		t.Run("only reconciles up to statement date", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			onStatement := c.Transaction(TransactionOpts{Account: account, Amount: "3", Date: "2024-01-31", Status: beans.TransactionCleared})
+			afterStatement := c.Transaction(TransactionOpts{Account: account, Amount: "4", Date: "2024-02-01", Status: beans.TransactionCleared})
+
+			adjustmentID, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: account.ID,
+				Balance:   beans.NewAmount(3, 0),
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
+			require.NoError(t, err)
+			assert.True(t, adjustmentID.Empty())
+
+			res, err := interactor.TransactionGet(t, c.ctx, onStatement.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionReconciled, res.Status)
+
+			res, err = interactor.TransactionGet(t, c.ctx, afterStatement.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionCleared, res.Status)
+		})
+
+		t.Run("cannot reconcile with a difference", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			cleared := c.Transaction(TransactionOpts{Account: account, Amount: "3", Date: "2024-01-15", Status: beans.TransactionCleared})
+
+			_, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: account.ID,
+				Balance:   beans.NewAmount(5, 0),
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cleared balance of 3 does not match statement balance of 5.")
+
+			res, err := interactor.TransactionGet(t, c.ctx, cleared.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionCleared, res.Status)
+		})
+
+		t.Run("can make adjustment", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account, Amount: "3", Date: "2024-01-15", Status: beans.TransactionCleared})
+			c.Transaction(TransactionOpts{Account: account, Amount: "7", Date: "2024-01-15"})
+
+			adjustmentID, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID:        account.ID,
+				Balance:          beans.NewAmount(525, -2),
+				Date:             testutils.NewDate(t, "2024-01-31"),
+				CreateAdjustment: true,
+			})
+			require.NoError(t, err)
+
+			adjustment, err := interactor.TransactionGet(t, c.ctx, adjustmentID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(225, -2), adjustment.Amount)
+			assert.Equal(t, testutils.NewDate(t, "2024-01-31"), adjustment.Date)
+			assert.Equal(t, beans.TransactionReconciled, adjustment.Status)
+			assert.Equal(t, account.ToRelated(), adjustment.Account)
+
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			findAccountWithBalance(t, accounts, account.ID, func(it beans.AccountWithBalance) {
+				assert.Equal(t, beans.NewAmount(525, -2), it.ClearedBalance)
+				assert.Equal(t, beans.NewAmount(7, 0), it.UnclearedBalance)
+			})
+		})
 	})
 }
@@ -0,0 +1,201 @@
+package specification
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttachment(t *testing.T, interactor Interactor) {
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), []byte("image data")...)
+	pdf := []byte("%PDF-1.7\ninvoice")
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Run("can attach and download", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			attachment, file, err := interactor.AttachmentGet(t, c.ctx, transaction.ID, id)
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.Attachment{
+				ID:            id,
+				TransactionID: transaction.ID,
+				FileName:      "receipt.png",
+				MimeType:      "image/png",
+				Size:          int64(len(png)),
+			}, attachment)
+			assert.Equal(t, png, file)
+		})
+
+		t.Run("detects type from contents", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "invoice.png", pdf)
+			require.NoError(t, err)
+
+			attachment, _, err := interactor.AttachmentGet(t, c.ctx, transaction.ID, id)
+			require.NoError(t, err)
+			assert.Equal(t, "application/pdf", attachment.MimeType)
+		})
+
+		t.Run("cannot attach other types", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			_, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "notes.pdf", []byte("just text"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "File type text/plain is not allowed.")
+		})
+
+		t.Run("cannot attach empty file", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			_, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "empty.png", []byte{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "File is required.")
+		})
+
+		t.Run("cannot attach large file", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			file := append(bytes.Clone(png), bytes.Repeat([]byte{0}, beans.MaxAttachmentSize)...)
+			_, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "large.png", file)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "File must be at most 10 MB.")
+		})
+
+		t.Run("cannot attach to other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			transaction := c2.Transaction(TransactionOpts{})
+
+			_, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot attach to split line", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			_, splits := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "1"}}})
+
+			_, err := interactor.AttachmentCreate(t, c.ctx, splits[0].ID, "receipt.png", png)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("get all", func(t *testing.T) {
+
+		t.Run("gets in order added", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+			other := c.Transaction(TransactionOpts{})
+
+			id1, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+			id2, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "invoice.pdf", pdf)
+			require.NoError(t, err)
+			_, err = interactor.AttachmentCreate(t, c.ctx, other.ID, "other.pdf", pdf)
+			require.NoError(t, err)
+
+			attachments, err := interactor.AttachmentGetAll(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.Attachment{
+				{ID: id1, TransactionID: transaction.ID, FileName: "receipt.png", MimeType: "image/png", Size: int64(len(png))},
+				{ID: id2, TransactionID: transaction.ID, FileName: "invoice.pdf", MimeType: "application/pdf", Size: int64(len(pdf))},
+			}, attachments)
+		})
+
+		t.Run("cannot get for other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			transaction := c2.Transaction(TransactionOpts{})
+
+			_, err := interactor.AttachmentGetAll(t, c.ctx, transaction.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("get", func(t *testing.T) {
+
+		t.Run("cannot get through other transaction", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+			other := c.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			_, _, err = interactor.AttachmentGet(t, c.ctx, other.ID, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot get from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			transaction := c2.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c2.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			_, _, err = interactor.AttachmentGet(t, c.ctx, transaction.ID, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+
+		t.Run("can delete", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			require.NoError(t, interactor.AttachmentDelete(t, c.ctx, transaction.ID, id))
+
+			_, _, err = interactor.AttachmentGet(t, c.ctx, transaction.ID, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			attachments, err := interactor.AttachmentGetAll(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Empty(t, attachments)
+		})
+
+		t.Run("cannot delete from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			transaction := c2.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c2.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			err = interactor.AttachmentDelete(t, c.ctx, transaction.ID, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			_, _, err = interactor.AttachmentGet(t, c2.ctx, transaction.ID, id)
+			require.NoError(t, err)
+		})
+
+		t.Run("deleted with transaction", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			id, err := interactor.AttachmentCreate(t, c.ctx, transaction.ID, "receipt.png", png)
+			require.NoError(t, err)
+
+			require.NoError(t, interactor.TransactionDelete(t, c.ctx, []beans.ID{transaction.ID}))
+
+			_, _, err = interactor.AttachmentGet(t, c.ctx, transaction.ID, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+}
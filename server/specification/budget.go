@@ -91,4 +91,64 @@ func testBudget(t *testing.T, interactor Interactor) {
 			require.Equal(t, beans.Name("New Budget"), budget.Name)
 		})
 	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Run("new budget carries overspending forward", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			assert.Equal(t, beans.OverspendingCarryForward, c.budget.OverspendingRollover)
+		})
+
+		t.Run("can update", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			err := interactor.BudgetUpdate(t, c.ctx, beans.BudgetUpdateParams{
+				ID:                   c.budget.ID,
+				Name:                 "Renamed",
+				OverspendingRollover: beans.OverspendingResetCreditAsDebt,
+			})
+			require.NoError(t, err)
+
+			budget, err := interactor.BudgetGet(t, c.ctx, c.budget.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Renamed"), budget.Name)
+			assert.Equal(t, beans.OverspendingResetCreditAsDebt, budget.OverspendingRollover)
+		})
+
+		t.Run("cannot update with invalid params", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			err := interactor.BudgetUpdate(t, c.ctx, beans.BudgetUpdateParams{
+				ID:                   c.budget.ID,
+				Name:                 "",
+				OverspendingRollover: beans.OverspendingReset,
+			})
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+
+			err = interactor.BudgetUpdate(t, c.ctx, beans.BudgetUpdateParams{
+				ID:   c.budget.ID,
+				Name: "Budget",
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Overspending rollover is required.")
+
+			err = interactor.BudgetUpdate(t, c.ctx, beans.BudgetUpdateParams{
+				ID:                   c.budget.ID,
+				Name:                 "Budget",
+				OverspendingRollover: "never",
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Overspending rollover never is not supported.")
+		})
+
+		t.Run("cannot update budget of another user", func(t *testing.T) {
+			c1 := makeUser(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			err := interactor.BudgetUpdate(t, c1.ctx, beans.BudgetUpdateParams{
+				ID:                   c2.budget.ID,
+				Name:                 "Mine",
+				OverspendingRollover: beans.OverspendingReset,
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
 }
@@ -45,9 +45,7 @@ func testCategory(t *testing.T, interactor Interactor) {
 			res, err := interactor.CategoryGroupGet(t, c.ctx, group.ID)
 			require.NoError(t, err)
 
-			assert.Equal(t, res.Categories, []beans.RelatedCategory{
-				{ID: category.ID, Name: category.Name},
-			})
+			assert.Equal(t, []beans.Category{category}, res.Categories)
 		})
 
 		t.Run("cannot get non-existent group", func(t *testing.T) {
@@ -92,6 +90,16 @@ func testCategory(t *testing.T, interactor Interactor) {
 			testutils.AssertErrorCode(t, err, beans.EINVALID)
 		})
 
+		t.Run("cannot create in credit card payments group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			paymentCategory, err := interactor.CategoryGet(t, c.ctx, account.PaymentCategoryID)
+			require.NoError(t, err)
+
+			_, err = interactor.CategoryCreate(t, c.ctx, paymentCategory.GroupID, "Electric")
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot add categories to the credit card payments group.")
+		})
+
 		t.Run("can create and get", func(t *testing.T) {
 			c := makeUserAndBudget(t, interactor)
 			group := c.CategoryGroup(CategoryGroupOpts{})
@@ -145,21 +153,21 @@ func testCategory(t *testing.T, interactor Interactor) {
 				assert.Equal(t, group1.ID, it.ID)
 				assert.Equal(t, group1.Name, it.Name)
 				assert.Equal(t, false, it.IsIncome)
-				assert.Equal(t, []beans.RelatedCategory{{ID: category1.ID, Name: category1.Name}}, it.Categories)
+				assert.Equal(t, []beans.Category{category1}, it.Categories)
 			})
 
 			findCategoryGroup(t, res, group2.ID, func(it beans.CategoryGroupWithCategories) {
 				assert.Equal(t, group2.ID, it.ID)
 				assert.Equal(t, group2.Name, it.Name)
 				assert.Equal(t, false, it.IsIncome)
-				assert.Equal(t, []beans.RelatedCategory{{ID: category2.ID, Name: category2.Name}}, it.Categories)
+				assert.Equal(t, []beans.Category{category2}, it.Categories)
 			})
 
 			findCategoryGroup(t, res, group3.ID, func(it beans.CategoryGroupWithCategories) {
 				assert.Equal(t, group3.ID, it.ID)
 				assert.Equal(t, group3.Name, it.Name)
 				assert.Equal(t, false, it.IsIncome)
-				assert.Equal(t, []beans.RelatedCategory{}, it.Categories)
+				assert.Equal(t, []beans.Category{}, it.Categories)
 			})
 		})
 
@@ -176,4 +184,508 @@ func testCategory(t *testing.T, interactor Interactor) {
 			assert.Equal(t, true, res[0].IsIncome)
 		})
 	})
+
+	t.Run("update category", func(t *testing.T) {
+		t.Run("cannot update with invalid name", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: category.GroupID,
+				Name:    "",
+			})
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+		})
+
+		t.Run("can rename, hide and sort", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:        category.ID,
+				GroupID:   category.GroupID,
+				Name:      "Water",
+				SortOrder: 4,
+				Hidden:    true,
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.CategoryGet(t, c.ctx, category.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Water"), res.Name)
+			assert.Equal(t, category.GroupID, res.GroupID)
+			assert.Equal(t, 4, res.SortOrder)
+			assert.Equal(t, true, res.Hidden)
+		})
+
+		t.Run("categories are listed in sort order", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			group := c.CategoryGroup(CategoryGroupOpts{})
+			category1 := c.Category(CategoryOpts{Group: group})
+			category2 := c.Category(CategoryOpts{Group: group})
+
+			// new categories go to the end
+			res, err := interactor.CategoryGroupGet(t, c.ctx, group.ID)
+			require.NoError(t, err)
+			require.Len(t, res.Categories, 2)
+			assert.Equal(t, category1.ID, res.Categories[0].ID)
+			assert.Equal(t, category2.ID, res.Categories[1].ID)
+
+			// move the first category to the end
+			err = interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:        category1.ID,
+				GroupID:   group.ID,
+				Name:      category1.Name,
+				SortOrder: category2.SortOrder + 1,
+			})
+			require.NoError(t, err)
+
+			all, err := interactor.CategoryGetAll(t, c.ctx)
+			require.NoError(t, err)
+			findCategoryGroup(t, all, group.ID, func(it beans.CategoryGroupWithCategories) {
+				require.Len(t, it.Categories, 2)
+				assert.Equal(t, category2.ID, it.Categories[0].ID)
+				assert.Equal(t, category1.ID, it.Categories[1].ID)
+			})
+		})
+
+		t.Run("can move to another group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+			group := c.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: group.ID,
+				Name:    category.Name,
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.CategoryGroupGet(t, c.ctx, group.ID)
+			require.NoError(t, err)
+			require.Len(t, res.Categories, 1)
+			assert.Equal(t, category.ID, res.Categories[0].ID)
+		})
+
+		t.Run("cannot move to group from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+			group := c2.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: group.ID,
+				Name:    category.Name,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Group ID.")
+		})
+
+		t.Run("cannot move into income group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+			income := c.findIncomeCategory()
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: income.GroupID,
+				Name:    category.Name,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move categories into or out of the income or credit card payments groups.")
+		})
+
+		t.Run("cannot move out of credit card payments group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			paymentCategory, err := interactor.CategoryGet(t, c.ctx, account.PaymentCategoryID)
+			require.NoError(t, err)
+			group := c.CategoryGroup(CategoryGroupOpts{})
+
+			err = interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      paymentCategory.ID,
+				GroupID: group.ID,
+				Name:    paymentCategory.Name,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move categories into or out of the income or credit card payments groups.")
+		})
+
+		t.Run("cannot hide income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			income := c.findIncomeCategory()
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      income.ID,
+				GroupID: income.GroupID,
+				Name:    income.Name,
+				Hidden:  true,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot hide the income category.")
+		})
+
+		t.Run("hidden category keeps its history", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-05-01"})
+			category := c.Category(CategoryOpts{})
+			c.setAssigned(month, category, "12.34")
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: category.GroupID,
+				Name:    category.Name,
+				Hidden:  true,
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+			require.NoError(t, err)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(1234, -2), it.Amount)
+			})
+		})
+
+		t.Run("cannot update category from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			category := c2.Category(CategoryOpts{})
+
+			err := interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      category.ID,
+				GroupID: category.GroupID,
+				Name:    "Water",
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("update group", func(t *testing.T) {
+		t.Run("cannot update with invalid name", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			group := c.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{ID: group.ID, Name: ""})
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+		})
+
+		t.Run("can rename, hide and sort", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			group := c.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{
+				ID:        group.ID,
+				Name:      "Bills",
+				SortOrder: 7,
+				Hidden:    true,
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.CategoryGroupGet(t, c.ctx, group.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Bills"), res.Name)
+			assert.Equal(t, 7, res.SortOrder)
+			assert.Equal(t, true, res.Hidden)
+		})
+
+		t.Run("groups are listed in sort order", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			group1 := c.CategoryGroup(CategoryGroupOpts{})
+			group2 := c.CategoryGroup(CategoryGroupOpts{})
+
+			// move the first group to the end
+			err := interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{
+				ID:        group1.ID,
+				Name:      group1.Name,
+				SortOrder: group2.SortOrder + 1,
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.CategoryGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, res, 3)
+			assert.Equal(t, true, res[0].IsIncome)
+			assert.Equal(t, group2.ID, res[1].ID)
+			assert.Equal(t, group1.ID, res[2].ID)
+		})
+
+		t.Run("cannot hide income group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			income := c.findIncomeCategory()
+
+			err := interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{
+				ID:     income.GroupID,
+				Name:   "Income",
+				Hidden: true,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot hide the income group.")
+		})
+
+		t.Run("cannot update group from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			group := c2.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{ID: group.ID, Name: "Bills"})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("delete category", func(t *testing.T) {
+		t.Run("requires replacement", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Replacement Category ID is required.")
+		})
+
+		t.Run("cannot replace with itself", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: category.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot replace a category with itself.")
+		})
+
+		t.Run("cannot replace with category from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+			replacement := c2.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: replacement.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Replacement Category ID.")
+		})
+
+		t.Run("cannot replace with income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: c.findIncomeCategory().ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot replace with an income or credit card payment category.")
+		})
+
+		t.Run("cannot delete income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			replacement := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: c.findIncomeCategory().ID, ReplacementID: replacement.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot delete the income category.")
+		})
+
+		t.Run("cannot delete payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			replacement := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: account.PaymentCategoryID, ReplacementID: replacement.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot delete a credit card payment category.")
+		})
+
+		t.Run("cannot delete category from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			category := c2.Category(CategoryOpts{})
+			replacement := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: replacement.ID})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("moves transactions and assigned amounts to replacement", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+			category := c.Category(CategoryOpts{})
+			replacement := c.Category(CategoryOpts{})
+
+			c.setAssigned(april, category, "10.25")
+			c.setAssigned(may, category, "3.10")
+			c.setAssigned(may, replacement, "1.15")
+
+			transaction := c.Transaction(TransactionOpts{Category: category, Date: "2022-05-10", Amount: "-2.05"})
+			parent, _ := c.Split(SplitOpts{
+				Date: "2022-05-11",
+				Splits: []SplitOpt{
+					{Amount: "-1.5", Category: category},
+					{Amount: "-4", Category: replacement},
+				},
+			})
+
+			err := interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: replacement.ID})
+			require.NoError(t, err)
+
+			// category is gone
+			_, err = interactor.CategoryGet(t, c.ctx, category.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			// transactions were moved
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.OptionalWrap(replacement.ToRelated()), res.Category)
+
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+			require.NoError(t, err)
+			require.Len(t, splits, 2)
+			assert.Equal(t, replacement.ID, splits[0].Category.ID)
+			assert.Equal(t, replacement.ID, splits[1].Category.ID)
+
+			// the move is in history
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, replacement.ID, after.CategoryID)
+
+			// no balance was lost
+			month, err := interactor.MonthGetOrCreate(t, c.ctx, may.Date)
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(month.Categories)) // income + replacement
+			findMonthCategory(t, month.Categories, replacement.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(425, -2), it.Amount)
+				assert.Equal(t, beans.NewAmount(-755, -2), it.Activity)
+				assert.Equal(t, beans.NewAmount(695, -2), it.Available)
+			})
+		})
+
+		t.Run("moves rules to replacement", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+			replacement := c.Category(CategoryOpts{})
+
+			ruleID, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Coffee",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("coffee")},
+				RuleActions:    beans.RuleActions{CategoryID: category.ID},
+			})
+			require.NoError(t, err)
+
+			err = interactor.CategoryDelete(t, c.ctx, beans.CategoryDeleteParams{ID: category.ID, ReplacementID: replacement.ID})
+			require.NoError(t, err)
+
+			rule, err := interactor.RuleGet(t, c.ctx, ruleID)
+			require.NoError(t, err)
+			assert.Equal(t, replacement.ID, rule.CategoryID)
+		})
+	})
+
+	t.Run("delete group", func(t *testing.T) {
+		t.Run("can delete empty group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			group := c.CategoryGroup(CategoryGroupOpts{})
+
+			require.NoError(t, interactor.CategoryGroupDelete(t, c.ctx, group.ID))
+
+			_, err := interactor.CategoryGroupGet(t, c.ctx, group.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot delete group with categories", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategoryGroupDelete(t, c.ctx, category.GroupID)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Group has categories. Move or delete them first.")
+		})
+
+		t.Run("cannot delete income group", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			err := interactor.CategoryGroupDelete(t, c.ctx, c.findIncomeCategory().GroupID)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot delete the income group.")
+		})
+
+		t.Run("cannot delete group from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			group := c2.CategoryGroup(CategoryGroupOpts{})
+
+			err := interactor.CategoryGroupDelete(t, c.ctx, group.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("goal", func(t *testing.T) {
+		t.Run("can set and get", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			goal := beans.CategoryGoal{
+				Type:        beans.GoalTargetBalanceByDate,
+				Amount:      beans.NewAmount(12025, -2),
+				TargetMonth: testutils.NewMonthDate(t, "2022-09-01"),
+			}
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, category.ID, beans.OptionalWrap(goal)))
+
+			res, err := interactor.CategoryGet(t, c.ctx, category.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.OptionalWrap(goal), res.Goal)
+		})
+
+		t.Run("target month only kept for target balance by date", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategorySetGoal(t, c.ctx, category.ID, beans.OptionalWrap(beans.CategoryGoal{
+				Type:        beans.GoalWeekly,
+				Amount:      beans.NewAmount(1025, -2),
+				TargetMonth: testutils.NewMonthDate(t, "2022-09-01"),
+			}))
+			require.NoError(t, err)
+
+			res, err := interactor.CategoryGet(t, c.ctx, category.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.OptionalWrap(beans.CategoryGoal{
+				Type:   beans.GoalWeekly,
+				Amount: beans.NewAmount(1025, -2),
+			}), res.Goal)
+		})
+
+		t.Run("can remove", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			goal := beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(1025, -2)}
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, category.ID, beans.OptionalWrap(goal)))
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, category.ID, beans.Optional[beans.CategoryGoal]{}))
+
+			res, err := interactor.CategoryGet(t, c.ctx, category.ID)
+			require.NoError(t, err)
+			assert.True(t, res.Goal.Empty())
+		})
+
+		t.Run("cannot set invalid goal", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.CategorySetGoal(t, c.ctx, category.ID, beans.OptionalWrap(beans.CategoryGoal{
+				Type:   beans.GoalTargetBalanceByDate,
+				Amount: beans.NewAmount(1025, -2),
+			}))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Target month is required.")
+		})
+
+		t.Run("cannot set on income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			err := interactor.CategorySetGoal(t, c.ctx, c.findIncomeCategory().ID, beans.OptionalWrap(beans.CategoryGoal{
+				Type:   beans.GoalMonthly,
+				Amount: beans.NewAmount(1025, -2),
+			}))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot set a goal on the income category.")
+		})
+
+		t.Run("cannot set on category from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			category := c2.Category(CategoryOpts{})
+
+			err := interactor.CategorySetGoal(t, c.ctx, category.ID, beans.OptionalWrap(beans.CategoryGoal{
+				Type:   beans.GoalMonthly,
+				Amount: beans.NewAmount(1025, -2),
+			}))
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
 }
@@ -1,7 +1,9 @@
 package contractadapter
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"testing"
 
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -80,6 +82,99 @@ func (i *contractsAdapter) AccountGet(t *testing.T, ctx specification.Context, i
 	return i.contracts.Account.Get(context.Background(), auth, id)
 }
 
+func (i *contractsAdapter) AccountUpdate(t *testing.T, ctx specification.Context, params beans.AccountUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Account.Update(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) AccountClose(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Account.Close(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) AccountReopen(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Account.Reopen(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) AccountDelete(t *testing.T, ctx specification.Context, params beans.AccountDeleteParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Account.Delete(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) AccountReconcile(t *testing.T, ctx specification.Context, params beans.AccountReconcileParams) (beans.ID, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return i.contracts.Account.Reconcile(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) AccountGetBalances(t *testing.T, ctx specification.Context, params beans.AccountBalanceParams) ([]beans.AccountBalance, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Account.GetBalances(context.Background(), auth, params)
+}
+
+// Attachment
+
+func (i *contractsAdapter) AttachmentCreate(t *testing.T, ctx specification.Context, transactionID beans.ID, fileName beans.Name, file []byte) (beans.ID, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	return i.contracts.Attachment.Create(context.Background(), auth, beans.AttachmentParams{
+		TransactionID: transactionID,
+		FileName:      fileName,
+		File:          bytes.NewReader(file),
+	})
+}
+
+func (i *contractsAdapter) AttachmentGetAll(t *testing.T, ctx specification.Context, transactionID beans.ID) ([]beans.Attachment, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Attachment.GetAll(context.Background(), auth, transactionID)
+}
+
+func (i *contractsAdapter) AttachmentGet(t *testing.T, ctx specification.Context, transactionID beans.ID, id beans.ID) (beans.Attachment, []byte, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.Attachment{}, nil, err
+	}
+	attachment, file, err := i.contracts.Attachment.Get(context.Background(), auth, transactionID, id)
+	if err != nil {
+		return beans.Attachment{}, nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	b, err := io.ReadAll(file)
+	return attachment, b, err
+}
+
+func (i *contractsAdapter) AttachmentDelete(t *testing.T, ctx specification.Context, transactionID beans.ID, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Attachment.Delete(context.Background(), auth, transactionID, id)
+}
+
 // Budget
 
 func (i *contractsAdapter) BudgetCreate(t *testing.T, ctx specification.Context, name beans.Name) (beans.ID, error) {
@@ -110,6 +205,14 @@ func (i *contractsAdapter) BudgetGetAll(t *testing.T, ctx specification.Context)
 	return i.contracts.Budget.GetAll(context.Background(), auth)
 }
 
+func (i *contractsAdapter) BudgetUpdate(t *testing.T, ctx specification.Context, params beans.BudgetUpdateParams) error {
+	auth, err := i.authContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Budget.Update(context.Background(), auth, params)
+}
+
 // Category
 
 func (i *contractsAdapter) CategoryCreate(t *testing.T, ctx specification.Context, groupID beans.ID, name beans.Name) (beans.ID, error) {
@@ -160,6 +263,96 @@ func (i *contractsAdapter) CategoryGetAll(t *testing.T, ctx specification.Contex
 	return i.contracts.Category.GetAll(context.Background(), auth)
 }
 
+func (i *contractsAdapter) CategoryUpdate(t *testing.T, ctx specification.Context, params beans.CategoryUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Category.UpdateCategory(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) CategoryDelete(t *testing.T, ctx specification.Context, params beans.CategoryDeleteParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Category.DeleteCategory(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) CategorySetGoal(t *testing.T, ctx specification.Context, id beans.ID, goal beans.Optional[beans.CategoryGoal]) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Category.SetGoal(context.Background(), auth, id, goal)
+}
+
+func (i *contractsAdapter) CategoryGroupUpdate(t *testing.T, ctx specification.Context, params beans.CategoryGroupUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Category.UpdateGroup(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) CategoryGroupDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Category.DeleteGroup(context.Background(), auth, id)
+}
+
+// Import
+
+func (i *contractsAdapter) ImportCSVPreview(t *testing.T, ctx specification.Context, params beans.ImportCSVParams) ([]beans.ImportPreviewRow, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Import.PreviewCSV(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ImportCSV(t *testing.T, ctx specification.Context, params beans.ImportCSVParams) (beans.ImportResult, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+	return i.contracts.Import.ImportCSV(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ImportOFXPreview(t *testing.T, ctx specification.Context, params beans.ImportOFXParams) ([]beans.ImportPreviewRow, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Import.PreviewOFX(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ImportOFX(t *testing.T, ctx specification.Context, params beans.ImportOFXParams) (beans.ImportResult, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+	return i.contracts.Import.ImportOFX(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ImportQIF(t *testing.T, ctx specification.Context, params beans.ImportQIFParams) (beans.ImportResult, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+	return i.contracts.Import.ImportQIF(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ExportQIF(t *testing.T, ctx specification.Context) ([]beans.ExportedFile, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Import.ExportQIF(context.Background(), auth)
+}
+
 // Month
 
 func (i *contractsAdapter) MonthGetOrCreate(t *testing.T, ctx specification.Context, date beans.MonthDate) (beans.MonthWithDetails, error) {
@@ -170,6 +363,14 @@ func (i *contractsAdapter) MonthGetOrCreate(t *testing.T, ctx specification.Cont
 	return i.contracts.Month.GetOrCreate(context.Background(), auth, date)
 }
 
+func (i *contractsAdapter) MonthGetRange(t *testing.T, ctx specification.Context, params beans.MonthRangeParams) ([]beans.MonthWithDetails, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Month.GetRange(context.Background(), auth, params)
+}
+
 func (i *contractsAdapter) MonthUpdate(t *testing.T, ctx specification.Context, id beans.ID, carryover beans.Amount) error {
 	auth, err := i.budgetAuthContext(t, ctx)
 	if err != nil {
@@ -186,6 +387,38 @@ func (i *contractsAdapter) MonthSetCategoryAmount(t *testing.T, ctx specificatio
 	return i.contracts.Month.SetCategoryAmount(context.Background(), auth, id, categoryID, amount)
 }
 
+func (i *contractsAdapter) MonthQuickBudget(t *testing.T, ctx specification.Context, params beans.QuickBudgetParams) (beans.MonthWithDetails, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+	return i.contracts.Month.QuickBudget(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) MonthMoveMoney(t *testing.T, ctx specification.Context, params beans.MoveMoneyParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Month.MoveMoney(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) MonthGetMovements(t *testing.T, ctx specification.Context, monthID beans.ID) ([]beans.MoneyMovement, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Month.GetMovements(context.Background(), auth, monthID)
+}
+
+func (i *contractsAdapter) MonthCheckSnapshots(t *testing.T, ctx specification.Context) ([]beans.MonthSnapshotMismatch, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Month.CheckSnapshots(context.Background(), auth)
+}
+
 // Payee
 
 func (i *contractsAdapter) PayeeCreate(t *testing.T, ctx specification.Context, name beans.Name) (beans.ID, error) {
@@ -212,6 +445,148 @@ func (i *contractsAdapter) PayeeGet(t *testing.T, ctx specification.Context, id
 	return i.contracts.Payee.Get(context.Background(), auth, id)
 }
 
+// Rule
+
+func (i *contractsAdapter) RuleCreate(t *testing.T, ctx specification.Context, params beans.RuleParams) (beans.ID, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	return i.contracts.Rule.Create(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) RuleUpdate(t *testing.T, ctx specification.Context, params beans.RuleUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Rule.Update(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) RuleDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Rule.Delete(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) RuleGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.Rule, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.Rule{}, err
+	}
+	return i.contracts.Rule.Get(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) RuleGetAll(t *testing.T, ctx specification.Context) ([]beans.Rule, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Rule.GetAll(context.Background(), auth)
+}
+
+func (i *contractsAdapter) RuleRun(t *testing.T, ctx specification.Context, dryRun bool) ([]beans.RuleChange, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Rule.Run(context.Background(), auth, dryRun)
+}
+
+// Scheduled transaction
+
+func (i *contractsAdapter) ScheduledTransactionCreate(t *testing.T, ctx specification.Context, params beans.ScheduledTransactionParams) (beans.ID, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	return i.contracts.Scheduled.Create(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ScheduledTransactionUpdate(t *testing.T, ctx specification.Context, params beans.ScheduledTransactionUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Scheduled.Update(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) ScheduledTransactionDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Scheduled.Delete(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) ScheduledTransactionGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.ScheduledTransaction, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	return i.contracts.Scheduled.Get(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) ScheduledTransactionGetAll(t *testing.T, ctx specification.Context) ([]beans.ScheduledTransaction, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Scheduled.GetAll(context.Background(), auth)
+}
+
+func (i *contractsAdapter) ScheduledTransactionPostDue(t *testing.T, ctx specification.Context, date beans.Date) (beans.PostDueResult, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.PostDueResult{}, err
+	}
+	return i.contracts.Scheduled.PostDue(context.Background(), auth, date)
+}
+
+// Tag
+
+func (i *contractsAdapter) TagCreate(t *testing.T, ctx specification.Context, name beans.Name) (beans.ID, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.EmptyID(), err
+	}
+	return i.contracts.Tag.Create(context.Background(), auth, name)
+}
+
+func (i *contractsAdapter) TagUpdate(t *testing.T, ctx specification.Context, id beans.ID, name beans.Name) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Tag.Update(context.Background(), auth, id, name)
+}
+
+func (i *contractsAdapter) TagDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Tag.Delete(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) TagGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.Tag, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.Tag{}, err
+	}
+	return i.contracts.Tag.Get(context.Background(), auth, id)
+}
+
+func (i *contractsAdapter) TagGetAll(t *testing.T, ctx specification.Context) ([]beans.Tag, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Tag.GetAll(context.Background(), auth)
+}
+
 // Transaction
 
 func (i *contractsAdapter) TransactionCreate(t *testing.T, ctx specification.Context, params beans.TransactionCreateParams) (beans.ID, error) {
@@ -238,6 +613,14 @@ func (i *contractsAdapter) TransactionUpdate(t *testing.T, ctx specification.Con
 	return i.contracts.Transaction.Update(context.Background(), auth, params)
 }
 
+func (i *contractsAdapter) TransactionBulkUpdate(t *testing.T, ctx specification.Context, params beans.TransactionBulkUpdateParams) error {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return err
+	}
+	return i.contracts.Transaction.BulkUpdate(context.Background(), auth, params)
+}
+
 func (i *contractsAdapter) TransactionDelete(t *testing.T, ctx specification.Context, ids []beans.ID) error {
 	auth, err := i.budgetAuthContext(t, ctx)
 	if err != nil {
@@ -251,7 +634,24 @@ func (i *contractsAdapter) TransactionGetAll(t *testing.T, ctx specification.Con
 	if err != nil {
 		return nil, err
 	}
-	return i.contracts.Transaction.GetAll(context.Background(), auth)
+	page, err := i.contracts.Transaction.GetAll(context.Background(), auth, beans.TransactionListParams{})
+	return page.Transactions, err
+}
+
+func (i *contractsAdapter) TransactionList(t *testing.T, ctx specification.Context, params beans.TransactionListParams) (beans.TransactionPage, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return beans.TransactionPage{}, err
+	}
+	return i.contracts.Transaction.GetAll(context.Background(), auth, params)
+}
+
+func (i *contractsAdapter) TransactionSearch(t *testing.T, ctx specification.Context, params beans.TransactionSearchParams) ([]beans.TransactionWithRelations, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Transaction.Search(context.Background(), auth, params)
 }
 
 func (i *contractsAdapter) TransactionGetSplits(t *testing.T, ctx specification.Context, id beans.ID) ([]beans.Split, error) {
@@ -262,6 +662,14 @@ func (i *contractsAdapter) TransactionGetSplits(t *testing.T, ctx specification.
 	return i.contracts.Transaction.GetSplits(context.Background(), auth, id)
 }
 
+func (i *contractsAdapter) TransactionGetHistory(t *testing.T, ctx specification.Context, id beans.ID) ([]beans.TransactionHistory, error) {
+	auth, err := i.budgetAuthContext(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.contracts.Transaction.GetHistory(context.Background(), auth, id)
+}
+
 // User
 
 func (i *contractsAdapter) UserRegister(t *testing.T, ctx specification.Context, username beans.Username, password beans.Password) error {
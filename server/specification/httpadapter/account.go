@@ -2,6 +2,7 @@ package httpadapter
 
 import (
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -16,7 +17,11 @@ func (a *httpAdapter) AccountCreate(t *testing.T, ctx specification.Context, par
 		Path:   "/api/v1/accounts",
 		Body: mustEncode(t, request.CreateAccount{
 			Name:      params.Name,
+			Type:      params.Type,
 			OffBudget: params.OffBudget,
+
+			OpeningBalance: params.OpeningBalance,
+			OpeningDate:    params.OpeningDate,
 		}),
 		Context: ctx,
 	})
@@ -66,3 +71,94 @@ func (a *httpAdapter) AccountGet(t *testing.T, ctx specification.Context, id bea
 
 	return mapAccount(resp.Data), nil
 }
+
+func (a *httpAdapter) AccountUpdate(t *testing.T, ctx specification.Context, params beans.AccountUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/v1/accounts/%s", params.ID),
+		Body: mustEncode(t, request.UpdateAccount{
+			Name:      params.Name,
+			OffBudget: params.OffBudget,
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) AccountClose(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    fmt.Sprintf("/api/v1/accounts/%s/close", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) AccountReopen(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    fmt.Sprintf("/api/v1/accounts/%s/reopen", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) AccountDelete(t *testing.T, ctx specification.Context, params beans.AccountDeleteParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/v1/accounts/%s", params.ID),
+		Body: mustEncode(t, request.DeleteAccount{
+			MoveToAccountID: params.MoveToAccountID,
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) AccountReconcile(t *testing.T, ctx specification.Context, params beans.AccountReconcileParams) (beans.ID, error) {
+	r := a.Request(t, HTTPRequest{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/v1/accounts/%s/reconcile", params.AccountID),
+		Body: mustEncode(t, request.ReconcileAccount{
+			Balance:          params.Balance,
+			Date:             params.Date,
+			CreateAdjustment: params.CreateAdjustment,
+		}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ReconcileAccountResponse](t, r.Response)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return resp.Data.AdjustmentID, nil
+}
+
+func (a *httpAdapter) AccountGetBalances(t *testing.T, ctx specification.Context, params beans.AccountBalanceParams) ([]beans.AccountBalance, error) {
+	query := url.Values{}
+	if params.Interval != "" {
+		query.Set("interval", string(params.Interval))
+	}
+	if !params.From.Empty() {
+		query.Set("from", params.From.String())
+	}
+	if !params.To.Empty() {
+		query.Set("to", params.To.String())
+	}
+
+	path := "/api/v1/accounts/balances?"
+	if !params.AccountID.Empty() {
+		path = fmt.Sprintf("/api/v1/accounts/%s/balances?", params.AccountID)
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    path + query.Encode(),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListAccountBalancesResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapAccountBalance), nil
+}
@@ -40,10 +40,11 @@ var httpStatusToCode = map[int]string{
 // Request helpers
 
 type HTTPRequest struct {
-	Method  string
-	Path    string
-	Body    any
-	Context specification.Context
+	Method      string
+	Path        string
+	Body        any
+	ContentType string
+	Context     specification.Context
 }
 
 type HTTPResponse struct {
@@ -56,6 +57,8 @@ func (a *httpAdapter) Request(t *testing.T, req HTTPRequest) *HTTPResponse {
 	switch rawBody := req.Body.(type) {
 	case string:
 		body = bytes.NewReader([]byte(rawBody))
+	case []byte:
+		body = bytes.NewReader(rawBody)
 	case nil:
 		body = nil
 	default:
@@ -70,6 +73,10 @@ func (a *httpAdapter) Request(t *testing.T, req HTTPRequest) *HTTPResponse {
 	)
 	require.Nil(t, err)
 
+	if req.ContentType != "" {
+		httpRequest.Header.Set("Content-Type", req.ContentType)
+	}
+
 	// attach session id cookie
 	if len(req.Context.SessionID) != 0 {
 		httpRequest.Header.Add("Authorization", string(req.Context.SessionID))
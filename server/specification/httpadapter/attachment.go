@@ -0,0 +1,95 @@
+package httpadapter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/bradenrayhorn/beans/server/specification"
+	"github.com/stretchr/testify/require"
+)
+
+func (a *httpAdapter) AttachmentCreate(t *testing.T, ctx specification.Context, transactionID beans.ID, fileName beans.Name, file []byte) (beans.ID, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", string(fileName))
+	require.NoError(t, err)
+	_, err = part.Write(file)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	r := a.Request(t, HTTPRequest{
+		Method:      "POST",
+		Path:        fmt.Sprintf("/api/v1/transactions/%s/attachments", transactionID),
+		Body:        body.Bytes(),
+		ContentType: writer.FormDataContentType(),
+		Context:     ctx,
+	})
+	resp, err := MustParseResponse[response.CreateAttachmentResponse](t, r.Response)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return resp.Data.ID, nil
+}
+
+func (a *httpAdapter) AttachmentGetAll(t *testing.T, ctx specification.Context, transactionID beans.ID) ([]beans.Attachment, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/transactions/%s/attachments", transactionID),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListAttachmentsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, func(it response.Attachment) beans.Attachment {
+		return beans.Attachment{
+			ID:            it.ID,
+			TransactionID: transactionID,
+			FileName:      it.FileName,
+			MimeType:      it.MimeType,
+			Size:          it.Size,
+		}
+	}), nil
+}
+
+func (a *httpAdapter) AttachmentGet(t *testing.T, ctx specification.Context, transactionID beans.ID, id beans.ID) (beans.Attachment, []byte, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/transactions/%s/attachments/%s", transactionID, id),
+		Context: ctx,
+	})
+	if err := getErrorFromResponse(t, r.Response); err != nil {
+		return beans.Attachment{}, nil, err
+	}
+	defer func() { _ = r.Body.Close() }()
+
+	file, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition"))
+	require.NoError(t, err)
+
+	return beans.Attachment{
+		ID:            id,
+		TransactionID: transactionID,
+		FileName:      beans.Name(params["filename"]),
+		MimeType:      r.Header.Get("Content-Type"),
+		Size:          r.ContentLength,
+	}, file, nil
+}
+
+func (a *httpAdapter) AttachmentDelete(t *testing.T, ctx specification.Context, transactionID beans.ID, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "DELETE",
+		Path:    fmt.Sprintf("/api/v1/transactions/%s/attachments/%s", transactionID, id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
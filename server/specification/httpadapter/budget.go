@@ -50,3 +50,20 @@ func (a *httpAdapter) BudgetGetAll(t *testing.T, ctx specification.Context) ([]b
 
 	return mapAll(resp.Data, mapBudget), nil
 }
+
+func (a *httpAdapter) BudgetUpdate(t *testing.T, ctx specification.Context, params beans.BudgetUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/v1/budgets/%s", params.ID),
+		Body: mustEncode(t, map[string]any{
+			"name":                 params.Name,
+			"overspendingRollover": params.OverspendingRollover,
+		}),
+		Context: ctx,
+	})
+	if err := getErrorFromResponse(t, r.Response); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
 	"github.com/bradenrayhorn/beans/server/http/response"
 	"github.com/bradenrayhorn/beans/server/specification"
 )
@@ -78,3 +79,77 @@ func (a *httpAdapter) CategoryGetAll(t *testing.T, ctx specification.Context) ([
 
 	return mapAll(resp.Data, mapCategoryGroupWithCategories), nil
 }
+
+func (a *httpAdapter) CategoryUpdate(t *testing.T, ctx specification.Context, params beans.CategoryUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/v1/categories/%s", params.ID),
+		Body: mustEncode(t, request.UpdateCategory{
+			GroupID:   params.GroupID,
+			Name:      params.Name,
+			SortOrder: params.SortOrder,
+			Hidden:    params.Hidden,
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) CategoryDelete(t *testing.T, ctx specification.Context, params beans.CategoryDeleteParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/v1/categories/%s", params.ID),
+		Body: mustEncode(t, request.DeleteCategory{
+			ReplacementID: params.ReplacementID,
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) CategorySetGoal(t *testing.T, ctx specification.Context, id beans.ID, goal beans.Optional[beans.CategoryGoal]) error {
+	g, ok := goal.Value()
+	if !ok {
+		r := a.Request(t, HTTPRequest{
+			Method:  "DELETE",
+			Path:    fmt.Sprintf("/api/v1/categories/%s/goal", id),
+			Context: ctx,
+		})
+		return getErrorFromResponse(t, r.Response)
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/v1/categories/%s/goal", id),
+		Body: mustEncode(t, request.SetCategoryGoal{
+			Type:        g.Type,
+			Amount:      g.Amount,
+			TargetMonth: g.TargetMonth.FirstDay(),
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) CategoryGroupUpdate(t *testing.T, ctx specification.Context, params beans.CategoryGroupUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/v1/categories/groups/%s", params.ID),
+		Body: mustEncode(t, request.UpdateCategoryGroup{
+			Name:      params.Name,
+			SortOrder: params.SortOrder,
+			Hidden:    params.Hidden,
+		}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) CategoryGroupDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "DELETE",
+		Path:    fmt.Sprintf("/api/v1/categories/groups/%s", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
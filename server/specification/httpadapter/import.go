@@ -0,0 +1,127 @@
+package httpadapter
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/bradenrayhorn/beans/server/specification"
+)
+
+func importCSVRequest(params beans.ImportCSVParams) request.ImportCSV {
+	return request.ImportCSV{
+		AccountID:  params.AccountID,
+		File:       params.File,
+		HasHeader:  params.HasHeader,
+		DateFormat: params.DateFormat,
+		Columns:    request.CSVColumns(params.Columns),
+	}
+}
+
+func (a *httpAdapter) ImportCSVPreview(t *testing.T, ctx specification.Context, params beans.ImportCSVParams) ([]beans.ImportPreviewRow, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/transactions/import/csv/preview",
+		Body:    mustEncode(t, importCSVRequest(params)),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.PreviewImportResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapImportPreviewRow), nil
+}
+
+func (a *httpAdapter) ImportCSV(t *testing.T, ctx specification.Context, params beans.ImportCSVParams) (beans.ImportResult, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/transactions/import/csv",
+		Body:    mustEncode(t, importCSVRequest(params)),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ImportResponse](t, r.Response)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return beans.ImportResult{
+		TransactionIDs: resp.Data.TransactionIDs,
+		Duplicates:     resp.Data.Duplicates,
+	}, nil
+}
+
+func (a *httpAdapter) ImportOFXPreview(t *testing.T, ctx specification.Context, params beans.ImportOFXParams) ([]beans.ImportPreviewRow, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/transactions/import/ofx/preview",
+		Body:    mustEncode(t, request.ImportOFX{AccountID: params.AccountID, File: params.File}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.PreviewImportResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapImportPreviewRow), nil
+}
+
+func (a *httpAdapter) ImportOFX(t *testing.T, ctx specification.Context, params beans.ImportOFXParams) (beans.ImportResult, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/transactions/import/ofx",
+		Body:    mustEncode(t, request.ImportOFX{AccountID: params.AccountID, File: params.File}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ImportResponse](t, r.Response)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return beans.ImportResult{
+		TransactionIDs: resp.Data.TransactionIDs,
+		Duplicates:     resp.Data.Duplicates,
+	}, nil
+}
+
+func (a *httpAdapter) ImportQIF(t *testing.T, ctx specification.Context, params beans.ImportQIFParams) (beans.ImportResult, error) {
+	r := a.Request(t, HTTPRequest{
+		Method: "POST",
+		Path:   "/api/v1/transactions/import/qif",
+		Body: mustEncode(t, request.ImportQIF{
+			AccountID:  params.AccountID,
+			File:       params.File,
+			DateFormat: params.DateFormat,
+		}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ImportResponse](t, r.Response)
+	if err != nil {
+		return beans.ImportResult{}, err
+	}
+
+	return beans.ImportResult{
+		TransactionIDs: resp.Data.TransactionIDs,
+		Duplicates:     resp.Data.Duplicates,
+	}, nil
+}
+
+func (a *httpAdapter) ExportQIF(t *testing.T, ctx specification.Context) ([]beans.ExportedFile, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/transactions/export/qif",
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ExportResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, func(f response.ExportedFile) beans.ExportedFile {
+		return beans.ExportedFile{
+			Account: beans.RelatedAccount{ID: f.Account.ID, Name: f.Account.Name, OffBudget: f.Account.OffBudget},
+			File:    f.File,
+		}
+	}), nil
+}
@@ -2,6 +2,7 @@ package httpadapter
 
 import (
 	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -24,6 +25,31 @@ func (a *httpAdapter) MonthGetOrCreate(t *testing.T, ctx specification.Context,
 	return mapMonthWithDetails(resp.Data), nil
 }
 
+func (a *httpAdapter) MonthGetRange(t *testing.T, ctx specification.Context, params beans.MonthRangeParams) ([]beans.MonthWithDetails, error) {
+	query := url.Values{}
+	if !params.From.Empty() {
+		query.Set("from", params.From.String())
+	}
+	if !params.To.Empty() {
+		query.Set("to", params.To.String())
+	}
+	if params.CreateFuture {
+		query.Set("create_future", "true")
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/months?" + query.Encode(),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListMonthsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapMonthWithDetails), nil
+}
+
 func (a *httpAdapter) MonthUpdate(t *testing.T, ctx specification.Context, id beans.ID, carryover beans.Amount) error {
 	r := a.Request(t, HTTPRequest{
 		Method: "PUT",
@@ -57,3 +83,68 @@ func (a *httpAdapter) MonthSetCategoryAmount(t *testing.T, ctx specification.Con
 
 	return nil
 }
+
+func (a *httpAdapter) MonthQuickBudget(t *testing.T, ctx specification.Context, params beans.QuickBudgetParams) (beans.MonthWithDetails, error) {
+	r := a.Request(t, HTTPRequest{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/v1/months/%s/quick-budget", params.MonthID),
+		Body: mustEncode(t, request.QuickBudgetMonth{
+			Type:        params.Type,
+			Months:      params.Months,
+			CategoryIDs: params.CategoryIDs,
+		}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetMonthResponse](t, r.Response)
+	if err != nil {
+		return beans.MonthWithDetails{}, err
+	}
+
+	return mapMonthWithDetails(resp.Data), nil
+}
+
+func (a *httpAdapter) MonthMoveMoney(t *testing.T, ctx specification.Context, params beans.MoveMoneyParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/v1/months/%s/move-money", params.MonthID),
+		Body: mustEncode(t, request.MoveMoney{
+			FromCategoryID: params.FromCategoryID,
+			ToCategoryID:   params.ToCategoryID,
+			Amount:         params.Amount,
+		}),
+		Context: ctx,
+	})
+	if err := getErrorFromResponse(t, r.Response); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *httpAdapter) MonthGetMovements(t *testing.T, ctx specification.Context, monthID beans.ID) ([]beans.MoneyMovement, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/months/%s/movements", monthID),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetMoneyMovementsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapMoneyMovement), nil
+}
+
+func (a *httpAdapter) MonthCheckSnapshots(t *testing.T, ctx specification.Context) ([]beans.MonthSnapshotMismatch, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/months/snapshots/check",
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.CheckMonthSnapshotsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapMonthSnapshotMismatch), nil
+}
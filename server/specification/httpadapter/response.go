@@ -19,13 +19,34 @@ func mapAll[T any, K any](objs []T, mapper func(T) K) []K {
 // account
 
 func mapAccount(t response.Account) beans.Account {
-	return beans.Account{ID: t.ID, Name: beans.Name(t.Name), OffBudget: t.OffBudget}
+	return beans.Account{
+		ID:                t.ID,
+		Name:              beans.Name(t.Name),
+		Type:              t.Type,
+		OffBudget:         t.OffBudget,
+		PaymentCategoryID: t.PaymentCategoryID,
+		Closed:            t.Closed,
+	}
+}
+
+func mapAccountBalance(t response.AccountBalance) beans.AccountBalance {
+	return beans.AccountBalance{AccountID: t.AccountID, Date: t.Date, Balance: t.Balance}
 }
 
 func mapListAccount(t response.ListAccount) beans.AccountWithBalance {
 	return beans.AccountWithBalance{
-		Account: beans.Account{ID: t.ID, Name: beans.Name(t.Name), OffBudget: t.OffBudget},
+		Account: beans.Account{
+			ID:                t.ID,
+			Name:              beans.Name(t.Name),
+			Type:              t.Type,
+			OffBudget:         t.OffBudget,
+			PaymentCategoryID: t.PaymentCategoryID,
+			Closed:            t.Closed,
+		},
 		Balance: t.Balance,
+
+		ClearedBalance:   t.ClearedBalance,
+		UnclearedBalance: t.UnclearedBalance,
 	}
 }
 
@@ -33,26 +54,37 @@ func mapListAccount(t response.ListAccount) beans.AccountWithBalance {
 
 func mapBudget(t response.Budget) beans.Budget {
 	return beans.Budget{
-		ID:   t.ID,
-		Name: beans.Name(t.Name),
+		ID:                   t.ID,
+		Name:                 beans.Name(t.Name),
+		OverspendingRollover: t.OverspendingRollover,
 	}
 }
 
 // category
 
 func mapCategory(t response.Category) beans.Category {
-	return beans.Category{
-		ID:      t.ID,
-		Name:    beans.Name(t.Name),
-		GroupID: t.GroupID,
+	category := beans.Category{
+		ID:        t.ID,
+		Name:      beans.Name(t.Name),
+		GroupID:   t.GroupID,
+		SortOrder: t.SortOrder,
+		Hidden:    t.Hidden,
+	}
+	if t.Goal != nil {
+		category.Goal = beans.OptionalWrap(mapCategoryGoal(*t.Goal))
 	}
+	return category
 }
 
-func mapRelatedCategory(t response.AssociatedCategory) beans.RelatedCategory {
-	return beans.RelatedCategory{
-		ID:   t.ID,
-		Name: beans.Name(t.Name),
+func mapCategoryGoal(t response.CategoryGoal) beans.CategoryGoal {
+	goal := beans.CategoryGoal{
+		Type:   t.Type,
+		Amount: t.Amount,
+	}
+	if !t.TargetMonth.Empty() {
+		goal.TargetMonth = beans.NewMonthDate(t.TargetMonth)
 	}
+	return goal
 }
 
 func mapCategoryGroupWithCategories(t response.CategoryGroup) beans.CategoryGroupWithCategories {
@@ -61,21 +93,55 @@ func mapCategoryGroupWithCategories(t response.CategoryGroup) beans.CategoryGrou
 			ID:       t.ID,
 			Name:     beans.Name(t.Name),
 			IsIncome: t.IsIncome,
+
+			IsCreditCardPayments: t.IsCreditCardPayments,
+			SortOrder:            t.SortOrder,
+			Hidden:               t.Hidden,
+		},
+		Categories: mapAll(t.Categories, mapCategory),
+	}
+}
+
+// import
+
+func mapImportPreviewRow(t response.ImportPreviewRow) beans.ImportPreviewRow {
+	row := beans.ImportPreviewRow{
+		ImportRow: beans.ImportRow{
+			Date:      t.Date,
+			Amount:    t.Amount,
+			PayeeName: t.PayeeName,
+			Notes:     t.Notes,
+			ImportID:  t.ImportID,
 		},
-		Categories: mapAll(t.Categories, mapRelatedCategory),
+		Duplicate: t.Duplicate,
+	}
+
+	if t.Payee != nil {
+		row.Payee = beans.OptionalWrap(beans.RelatedPayee{ID: t.Payee.ID, Name: t.Payee.Name})
 	}
+
+	return row
 }
 
 // month
 
 func mapMonthCategory(t response.MonthCategory) beans.MonthCategoryWithDetails {
-	return beans.MonthCategoryWithDetails{
+	monthCategory := beans.MonthCategoryWithDetails{
 		ID:         t.ID,
 		CategoryID: t.CategoryID,
 		Amount:     t.Assigned,
 		Activity:   t.Activity,
 		Available:  t.Available,
 	}
+	if t.Goal != nil {
+		monthCategory.Goal = beans.OptionalWrap(beans.CategoryGoalProgress{
+			Goal:        mapCategoryGoal(t.Goal.Goal),
+			Target:      t.Goal.Target,
+			Percent:     t.Goal.Percent,
+			Underfunded: t.Goal.Underfunded,
+		})
+	}
+	return monthCategory
 }
 
 func mapMonthWithDetails(t response.Month) beans.MonthWithDetails {
@@ -89,10 +155,32 @@ func mapMonthWithDetails(t response.Month) beans.MonthWithDetails {
 		Income:      t.Income,
 		Assigned:    t.Assigned,
 		Budgetable:  t.Budgetable,
+		Underfunded: t.Underfunded,
+		Overspent:   t.Overspent,
 		Categories:  mapAll(t.Categories, mapMonthCategory),
 	}
 }
 
+func mapMoneyMovement(t response.MoneyMovement) beans.MoneyMovement {
+	return beans.MoneyMovement{
+		ID:             t.ID,
+		FromCategoryID: t.FromCategoryID,
+		ToCategoryID:   t.ToCategoryID,
+		Amount:         t.Amount,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+func mapMonthSnapshotMismatch(t response.MonthSnapshotMismatch) beans.MonthSnapshotMismatch {
+	return beans.MonthSnapshotMismatch{
+		Date:       t.Date,
+		CategoryID: t.CategoryID,
+		Field:      t.Field,
+		Snapshot:   t.Snapshot,
+		Recomputed: t.Recomputed,
+	}
+}
+
 // payee
 
 func mapPayee(t response.Payee) beans.Payee {
@@ -107,6 +195,7 @@ func mapTransactionWithRelations(t response.Transaction) beans.TransactionWithRe
 		Amount:  t.Amount,
 		Date:    t.Date,
 		Notes:   t.Notes,
+		Status:  t.Status,
 		Variant: t.Variant,
 		Account: beans.RelatedAccount{
 			ID:        t.Account.ID,
@@ -129,6 +218,8 @@ func mapTransactionWithRelations(t response.Transaction) beans.TransactionWithRe
 		})
 	}
 
+	transaction.Tags = mapRelatedTags(t.Tags)
+
 	return transaction
 }
 
@@ -138,5 +229,112 @@ func mapSplit(t response.Split) beans.Split {
 		Amount:   t.Amount,
 		Category: beans.RelatedCategory(t.Category),
 		Notes:    t.Notes,
+		Tags:     mapRelatedTags(t.Tags),
+	}
+}
+
+func mapTransactionHistory(t response.TransactionHistory) beans.TransactionHistory {
+	return beans.TransactionHistory{
+		ID:            t.ID,
+		TransactionID: t.TransactionID,
+		SplitID:       t.SplitID,
+		User:          beans.UserPublic{ID: t.User.ID, Username: beans.Username(t.User.Username)},
+		Action:        t.Action,
+		CreatedAt:     t.CreatedAt,
+		Before:        mapTransactionHistoryValues(t.Before),
+		After:         mapTransactionHistoryValues(t.After),
+	}
+}
+
+func mapTransactionHistoryValues(t *response.TransactionHistoryValues) beans.Optional[beans.TransactionHistoryValues] {
+	if t == nil {
+		return beans.Optional[beans.TransactionHistoryValues]{}
+	}
+
+	return beans.OptionalWrap(beans.TransactionHistoryValues{
+		AccountID:  t.AccountID,
+		CategoryID: t.CategoryID,
+		PayeeID:    t.PayeeID,
+		Amount:     t.Amount,
+		Date:       t.Date,
+		Notes:      t.Notes,
+		Status:     t.Status,
+	})
+}
+
+// tag
+
+func mapTag(t response.Tag) beans.Tag {
+	return beans.Tag{ID: t.ID, Name: t.Name}
+}
+
+// Tags are always listed in responses, but are nil when empty in beans.
+func mapRelatedTags(tags []response.AssociatedTag) []beans.RelatedTag {
+	var related []beans.RelatedTag
+	for _, tag := range tags {
+		related = append(related, beans.RelatedTag{ID: tag.ID, Name: tag.Name})
+	}
+	return related
+}
+
+// rule
+
+func mapRule(t response.Rule) beans.Rule {
+	return beans.Rule{
+		ID:   t.ID,
+		Name: t.Name,
+		RuleConditions: beans.RuleConditions{
+			PayeeContains: t.PayeeContains,
+			MinAmount:     t.MinAmount,
+			MaxAmount:     t.MaxAmount,
+		},
+		RuleActions: beans.RuleActions{
+			PayeeID:     t.PayeeID,
+			CategoryID:  t.CategoryID,
+			AppendNotes: t.AppendNotes,
+		},
+	}
+}
+
+func mapRuleChange(t response.RuleChange) beans.RuleChange {
+	return beans.RuleChange{
+		Transaction: mapTransactionWithRelations(t.Transaction),
+		PayeeID:     t.PayeeID,
+		CategoryID:  t.CategoryID,
+		Notes:       t.Notes,
+	}
+}
+
+// scheduled transaction
+
+func mapScheduledTransaction(t response.ScheduledTransaction) beans.ScheduledTransaction {
+	var splits []beans.SplitParams
+	for _, split := range t.Splits {
+		splits = append(splits, beans.SplitParams{
+			Amount:     split.Amount,
+			CategoryID: split.CategoryID,
+			Notes:      split.Notes,
+		})
+	}
+
+	return beans.ScheduledTransaction{
+		ID: t.ID,
+		Template: beans.TransactionCreateParams{
+			TransferAccountID: t.TransferAccountID,
+			TransactionParams: beans.TransactionParams{
+				AccountID:  t.AccountID,
+				CategoryID: t.CategoryID,
+				PayeeID:    t.PayeeID,
+				Amount:     t.Amount,
+				Notes:      t.Notes,
+				Splits:     splits,
+			},
+		},
+		Rule: beans.ScheduleRule{
+			Frequency: t.Frequency,
+			Interval:  t.Interval,
+			Start:     t.StartDate,
+		},
+		NextDate: t.NextDate,
 	}
 }
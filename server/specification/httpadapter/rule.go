@@ -0,0 +1,99 @@
+package httpadapter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/bradenrayhorn/beans/server/specification"
+)
+
+func ruleRequest(params beans.RuleParams) request.Rule {
+	return request.Rule{
+		Name:          params.Name,
+		PayeeContains: params.PayeeContains,
+		MinAmount:     params.MinAmount,
+		MaxAmount:     params.MaxAmount,
+		PayeeID:       params.PayeeID,
+		CategoryID:    params.CategoryID,
+		AppendNotes:   params.AppendNotes,
+	}
+}
+
+func (a *httpAdapter) RuleCreate(t *testing.T, ctx specification.Context, params beans.RuleParams) (beans.ID, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/rules",
+		Body:    mustEncode(t, ruleRequest(params)),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.CreateRuleResponse](t, r.Response)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return resp.Data.ID, nil
+}
+
+func (a *httpAdapter) RuleUpdate(t *testing.T, ctx specification.Context, params beans.RuleUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "PUT",
+		Path:    fmt.Sprintf("/api/v1/rules/%s", params.ID),
+		Body:    mustEncode(t, ruleRequest(params.RuleParams)),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) RuleDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "DELETE",
+		Path:    fmt.Sprintf("/api/v1/rules/%s", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) RuleGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.Rule, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/rules/%s", id),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetRuleResponse](t, r.Response)
+	if err != nil {
+		return beans.Rule{}, err
+	}
+
+	return mapRule(resp.Data), nil
+}
+
+func (a *httpAdapter) RuleGetAll(t *testing.T, ctx specification.Context) ([]beans.Rule, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/rules",
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListRulesResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapRule), nil
+}
+
+func (a *httpAdapter) RuleRun(t *testing.T, ctx specification.Context, dryRun bool) ([]beans.RuleChange, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/rules/run",
+		Body:    mustEncode(t, request.RunRules{DryRun: dryRun}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.RunRulesResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapRuleChange), nil
+}
@@ -0,0 +1,121 @@
+package httpadapter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/bradenrayhorn/beans/server/specification"
+)
+
+func scheduledTransactionRequest(params beans.ScheduledTransactionParams) request.ScheduledTransaction {
+	return request.ScheduledTransaction{
+		CreateTransaction: request.CreateTransaction{
+			AccountID:         params.Template.AccountID,
+			CategoryID:        params.Template.CategoryID,
+			PayeeID:           params.Template.PayeeID,
+			Amount:            params.Template.Amount,
+			Date:              params.Template.Date,
+			Notes:             params.Template.Notes,
+			Status:            params.Template.Status,
+			TransferAccountID: params.Template.TransferAccountID,
+			TagIDs:            params.Template.TagIDs,
+			Splits: mapAll(params.Template.Splits, func(p beans.SplitParams) request.Split {
+				return request.Split{
+					Amount:     p.Amount,
+					CategoryID: p.CategoryID,
+					Notes:      p.Notes,
+					TagIDs:     p.TagIDs,
+				}
+			}),
+		},
+		Frequency: params.Frequency,
+		Interval:  params.Interval,
+	}
+}
+
+func (a *httpAdapter) ScheduledTransactionCreate(t *testing.T, ctx specification.Context, params beans.ScheduledTransactionParams) (beans.ID, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/scheduled-transactions",
+		Body:    mustEncode(t, scheduledTransactionRequest(params)),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.CreateScheduledTransactionResponse](t, r.Response)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return resp.Data.ID, nil
+}
+
+func (a *httpAdapter) ScheduledTransactionUpdate(t *testing.T, ctx specification.Context, params beans.ScheduledTransactionUpdateParams) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "PUT",
+		Path:    fmt.Sprintf("/api/v1/scheduled-transactions/%s", params.ID),
+		Body:    mustEncode(t, scheduledTransactionRequest(params.ScheduledTransactionParams)),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) ScheduledTransactionDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "DELETE",
+		Path:    fmt.Sprintf("/api/v1/scheduled-transactions/%s", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) ScheduledTransactionGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.ScheduledTransaction, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/scheduled-transactions/%s", id),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetScheduledTransactionResponse](t, r.Response)
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+
+	return mapScheduledTransaction(resp.Data), nil
+}
+
+func (a *httpAdapter) ScheduledTransactionGetAll(t *testing.T, ctx specification.Context) ([]beans.ScheduledTransaction, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/scheduled-transactions",
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListScheduledTransactionsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapScheduledTransaction), nil
+}
+
+func (a *httpAdapter) ScheduledTransactionPostDue(t *testing.T, ctx specification.Context, date beans.Date) (beans.PostDueResult, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/scheduled-transactions/post-due",
+		Body:    mustEncode(t, request.PostScheduledTransactions{Date: date}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.PostScheduledTransactionsResponse](t, r.Response)
+	if err != nil {
+		return beans.PostDueResult{}, err
+	}
+
+	failed := make([]beans.PostDueFailure, len(resp.Data.Failed))
+	for i, failure := range resp.Data.Failed {
+		failed[i] = beans.PostDueFailure{
+			ScheduledTransactionID: failure.ScheduledTransactionID,
+			Error:                  failure.Error,
+		}
+	}
+
+	return beans.PostDueResult{TransactionIDs: resp.Data.TransactionIDs, Failed: failed}, nil
+}
@@ -0,0 +1,72 @@
+package httpadapter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/http/request"
+	"github.com/bradenrayhorn/beans/server/http/response"
+	"github.com/bradenrayhorn/beans/server/specification"
+)
+
+func (a *httpAdapter) TagCreate(t *testing.T, ctx specification.Context, name beans.Name) (beans.ID, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/tags",
+		Body:    mustEncode(t, request.Tag{Name: name}),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.CreateTagResponse](t, r.Response)
+	if err != nil {
+		return beans.ID{}, err
+	}
+	return resp.Data.ID, nil
+}
+
+func (a *httpAdapter) TagUpdate(t *testing.T, ctx specification.Context, id beans.ID, name beans.Name) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "PUT",
+		Path:    fmt.Sprintf("/api/v1/tags/%s", id),
+		Body:    mustEncode(t, request.Tag{Name: name}),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) TagDelete(t *testing.T, ctx specification.Context, id beans.ID) error {
+	r := a.Request(t, HTTPRequest{
+		Method:  "DELETE",
+		Path:    fmt.Sprintf("/api/v1/tags/%s", id),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
+func (a *httpAdapter) TagGet(t *testing.T, ctx specification.Context, id beans.ID) (beans.Tag, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/tags/%s", id),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetTagResponse](t, r.Response)
+	if err != nil {
+		return beans.Tag{}, err
+	}
+
+	return mapTag(resp.Data), nil
+}
+
+func (a *httpAdapter) TagGetAll(t *testing.T, ctx specification.Context) ([]beans.Tag, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/tags",
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListTagsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapTag), nil
+}
@@ -2,6 +2,8 @@ package httpadapter
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -21,12 +23,15 @@ func (a *httpAdapter) TransactionCreate(t *testing.T, ctx specification.Context,
 			Amount:            params.Amount,
 			Date:              params.Date,
 			Notes:             params.Notes,
+			Status:            params.Status,
 			TransferAccountID: params.TransferAccountID,
+			TagIDs:            params.TagIDs,
 			Splits: mapAll(params.Splits, func(p beans.SplitParams) request.Split {
 				return request.Split{
 					Amount:     p.Amount,
 					CategoryID: p.CategoryID,
 					Notes:      p.Notes,
+					TagIDs:     p.TagIDs,
 				}
 			}),
 		}),
@@ -64,11 +69,14 @@ func (a *httpAdapter) TransactionUpdate(t *testing.T, ctx specification.Context,
 			Amount:     params.Amount,
 			Date:       params.Date,
 			Notes:      params.Notes,
+			Status:     params.Status,
+			TagIDs:     params.TagIDs,
 			Splits: mapAll(params.Splits, func(p beans.SplitParams) request.Split {
 				return request.Split{
 					Amount:     p.Amount,
 					CategoryID: p.CategoryID,
 					Notes:      p.Notes,
+					TagIDs:     p.TagIDs,
 				}
 			}),
 		}),
@@ -77,6 +85,38 @@ func (a *httpAdapter) TransactionUpdate(t *testing.T, ctx specification.Context,
 	return getErrorFromResponse(t, r.Response)
 }
 
+func (a *httpAdapter) TransactionBulkUpdate(t *testing.T, ctx specification.Context, params beans.TransactionBulkUpdateParams) error {
+	body := map[string]any{"ids": params.IDs}
+
+	// an empty ID is sent as an empty string to remove the value
+	setID := func(key string, value beans.Optional[beans.ID]) {
+		if id, ok := value.Value(); ok {
+			if id.Empty() {
+				body[key] = ""
+			} else {
+				body[key] = id
+			}
+		}
+	}
+	setID("account_id", params.AccountID)
+	setID("category_id", params.CategoryID)
+	setID("payee_id", params.PayeeID)
+	if !params.Date.Empty() {
+		body["date"] = params.Date
+	}
+	if !params.Notes.Empty() {
+		body["notes"] = params.Notes
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method:  "POST",
+		Path:    "/api/v1/transactions/bulk-update",
+		Body:    mustEncode(t, body),
+		Context: ctx,
+	})
+	return getErrorFromResponse(t, r.Response)
+}
+
 func (a *httpAdapter) TransactionDelete(t *testing.T, ctx specification.Context, ids []beans.ID) error {
 	r := a.Request(t, HTTPRequest{
 		Method:  "POST",
@@ -101,6 +141,87 @@ func (a *httpAdapter) TransactionGetAll(t *testing.T, ctx specification.Context)
 	return mapAll(resp.Data, mapTransactionWithRelations), nil
 }
 
+func (a *httpAdapter) TransactionList(t *testing.T, ctx specification.Context, params beans.TransactionListParams) (beans.TransactionPage, error) {
+	query := url.Values{}
+	set := func(key string, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	setID := func(key string, id beans.ID) {
+		if !id.Empty() {
+			query.Set(key, id.String())
+		}
+	}
+	setID("account_id", params.AccountID)
+	setID("category_id", params.CategoryID)
+	setID("payee_id", params.PayeeID)
+	setID("tag_id", params.TagID)
+	if !params.From.Empty() {
+		query.Set("from", params.From.String())
+	}
+	if !params.To.Empty() {
+		query.Set("to", params.To.String())
+	}
+	set("min_amount", params.MinAmount.String())
+	set("max_amount", params.MaxAmount.String())
+	set("variant", string(params.Variant))
+	set("sort", string(params.Sort))
+	set("cursor", params.Cursor.String())
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/transactions?" + query.Encode(),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.ListTransactionsResponse](t, r.Response)
+	if err != nil {
+		return beans.TransactionPage{}, err
+	}
+
+	cursor, err := beans.ParseTransactionCursor(resp.NextCursor.String())
+	if err != nil {
+		return beans.TransactionPage{}, err
+	}
+
+	return beans.TransactionPage{
+		Transactions: mapAll(resp.Data, mapTransactionWithRelations),
+		NextCursor:   cursor,
+	}, nil
+}
+
+func (a *httpAdapter) TransactionSearch(t *testing.T, ctx specification.Context, params beans.TransactionSearchParams) ([]beans.TransactionWithRelations, error) {
+	query := url.Values{}
+	query.Set("q", params.Query.String())
+	if !params.AccountID.Empty() {
+		query.Set("account_id", params.AccountID.String())
+	}
+	if !params.From.Empty() {
+		query.Set("from", params.From.String())
+	}
+	if !params.To.Empty() {
+		query.Set("to", params.To.String())
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    "/api/v1/transactions/search?" + query.Encode(),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.SearchTransactionsResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapTransactionWithRelations), nil
+}
+
 func (a *httpAdapter) TransactionGetSplits(t *testing.T, ctx specification.Context, id beans.ID) ([]beans.Split, error) {
 	r := a.Request(t, HTTPRequest{
 		Method:  "GET",
@@ -114,3 +235,17 @@ func (a *httpAdapter) TransactionGetSplits(t *testing.T, ctx specification.Conte
 
 	return mapAll(resp.Data, mapSplit), nil
 }
+
+func (a *httpAdapter) TransactionGetHistory(t *testing.T, ctx specification.Context, id beans.ID) ([]beans.TransactionHistory, error) {
+	r := a.Request(t, HTTPRequest{
+		Method:  "GET",
+		Path:    fmt.Sprintf("/api/v1/transactions/%s/history", id),
+		Context: ctx,
+	})
+	resp, err := MustParseResponse[response.GetTransactionHistoryResponse](t, r.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAll(resp.Data, mapTransactionHistory), nil
+}
@@ -0,0 +1,782 @@
+package specification
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImport(t *testing.T, interactor Interactor) {
+
+	csvParams := func(account beans.Account, file string) beans.ImportCSVParams {
+		return beans.ImportCSVParams{
+			AccountID: account.ID,
+			File:      file,
+			CSVFormat: beans.CSVFormat{
+				HasHeader:  true,
+				DateFormat: beans.DateFormatMDYSlash,
+				Columns: beans.CSVColumns{
+					Date:   beans.OptionalWrap(0),
+					Payee:  beans.OptionalWrap(1),
+					Amount: beans.OptionalWrap(2),
+					Notes:  beans.OptionalWrap(3),
+				},
+			},
+		}
+	}
+
+	t.Run("csv preview", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportCSVPreview(t, c.ctx, beans.ImportCSVParams{})
+			testutils.AssertError(t, err, "Account ID is required. Date format is required. Date column is required.")
+		})
+
+		t.Run("requires amount or debit and credit columns", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			params := csvParams(c.Account(AccountOpts{}), "")
+			params.Columns.Amount = beans.Optional[int]{}
+
+			_, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+		})
+
+		t.Run("cannot use account from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			params := csvParams(c2.Account(AccountOpts{}), "date,payee,amount,notes\n")
+
+			_, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Account ID")
+		})
+
+		t.Run("cannot import to closed account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			require.NoError(t, interactor.AccountClose(t, c.ctx, account.ID))
+
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,,-5,\n")
+
+			_, err := interactor.ImportCSV(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account is closed.")
+		})
+
+		t.Run("reports invalid rows", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			params := csvParams(c.Account(AccountOpts{}), "date,payee,amount,notes\n01/02/2024,Store,5,\nbad,Store,5,\n")
+
+			_, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Row 3: invalid date "bad".`)
+		})
+
+		t.Run("can preview", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payeeID, err := interactor.PayeeCreate(t, c.ctx, "Grocery Store")
+			require.NoError(t, err)
+
+			params := csvParams(account, "date,payee,amount,notes\n"+
+				"01/02/2024,grocery store,-12.50,weekly shop\n"+
+				"1/3/2024,New Place,\"1,000.00\",\n")
+
+			rows, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+
+			assert.Equal(t, beans.ImportPreviewRow{
+				ImportRow: beans.ImportRow{
+					Date:      testutils.NewDate(t, "2024-01-02"),
+					Amount:    beans.NewAmount(-1250, -2),
+					PayeeName: "grocery store",
+					Notes:     beans.NewTransactionNotes("weekly shop"),
+				},
+				Payee: beans.OptionalWrap(beans.RelatedPayee{ID: payeeID, Name: "Grocery Store"}),
+			}, rows[0])
+
+			assert.Equal(t, beans.ImportPreviewRow{
+				ImportRow: beans.ImportRow{
+					Date:      testutils.NewDate(t, "2024-01-03"),
+					Amount:    beans.NewAmount(100000, -2),
+					PayeeName: "New Place",
+				},
+			}, rows[1])
+		})
+
+		t.Run("flags duplicates", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payee := c.Payee(PayeeOpts{})
+			c.Transaction(TransactionOpts{Account: account, Payee: payee, Amount: "-5", Date: "2024-01-02"})
+
+			// the same transaction twice in the file only matches once
+			params := csvParams(account, "date,payee,amount,notes\n"+
+				"01/02/2024,"+string(payee.Name)+",-5.00,\n"+
+				"01/02/2024,"+string(payee.Name)+",-5.00,\n"+
+				"01/03/2024,"+string(payee.Name)+",-5.00,\n")
+
+			rows, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, rows, 3)
+
+			assert.True(t, rows[0].Duplicate)
+			assert.False(t, rows[1].Duplicate)
+			assert.False(t, rows[2].Duplicate)
+		})
+
+		t.Run("does not flag new payee as transaction without payee", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account, Amount: "-5", Date: "2024-01-02"})
+
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,New Place,-5,\n01/02/2024,,-5,\n")
+
+			rows, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+
+			assert.False(t, rows[0].Duplicate)
+			assert.True(t, rows[1].Duplicate)
+		})
+
+		t.Run("does not flag transactions from other accounts", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Amount: "-5", Date: "2024-01-02"})
+
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,,-5,\n")
+
+			rows, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+
+			assert.False(t, rows[0].Duplicate)
+		})
+	})
+
+	t.Run("csv import", func(t *testing.T) {
+
+		t.Run("can import", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payeeID, err := interactor.PayeeCreate(t, c.ctx, "Grocery Store")
+			require.NoError(t, err)
+
+			params := csvParams(account, "date,payee,amount,notes\n"+
+				"01/02/2024,Grocery Store,-12.50,weekly shop\n"+
+				"01/03/2024,New Place,-3,\n"+
+				"01/04/2024,new place,-4,\n")
+
+			result, err := interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 3)
+			assert.Equal(t, 0, result.Duplicates)
+
+			// the existing payee is used
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(-125, -1), transaction.Amount)
+			assert.Equal(t, testutils.NewDate(t, "2024-01-02"), transaction.Date)
+			assert.Equal(t, beans.NewTransactionNotes("weekly shop"), transaction.Notes)
+			assert.Equal(t, beans.RelatedAccount{ID: account.ID, Name: account.Name}, transaction.Account)
+			assert.Equal(t, beans.OptionalWrap(beans.RelatedPayee{ID: payeeID, Name: "Grocery Store"}), transaction.Payee)
+			assert.True(t, transaction.Category.Empty())
+
+			// a single new payee is created for both rows
+			transactionB, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[1])
+			require.NoError(t, err)
+			transactionC, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[2])
+			require.NoError(t, err)
+
+			payeeB, ok := transactionB.Payee.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.Name("New Place"), payeeB.Name)
+			assert.Equal(t, transactionB.Payee, transactionC.Payee)
+
+			payees, err := interactor.PayeeGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, payees, 2)
+		})
+
+		t.Run("can import debit and credit columns", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := csvParams(account, "2024-01-02,Store,12.50,\n2024-01-03,Employer,,105\n")
+			params.HasHeader = false
+			params.DateFormat = beans.DateFormatYMD
+			params.Columns = beans.CSVColumns{
+				Date:   beans.OptionalWrap(0),
+				Payee:  beans.OptionalWrap(1),
+				Debit:  beans.OptionalWrap(2),
+				Credit: beans.OptionalWrap(3),
+			}
+
+			result, err := interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 2)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(-125, -1), transaction.Amount)
+
+			transaction, err = interactor.TransactionGet(t, c.ctx, result.TransactionIDs[1])
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(105, 0), transaction.Amount)
+		})
+
+		t.Run("skips duplicates", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,Store,-5,\n01/03/2024,Store,-6,\n")
+
+			result, err := interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 2)
+
+			// importing the same file again does nothing
+			result, err = interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 0)
+			assert.Equal(t, 2, result.Duplicates)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 2)
+		})
+
+		t.Run("skips duplicates when a rule changed the payee", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payee := c.Payee(PayeeOpts{})
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Coffee",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("coffee")},
+				RuleActions:    beans.RuleActions{PayeeID: payee.ID},
+			})
+			require.NoError(t, err)
+
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,ACME COFFEE #123,-4.25,\n01/03/2024,Store,-6,\n")
+
+			result, err := interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 2)
+
+			preview, err := interactor.ImportCSVPreview(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, preview, 2)
+			assert.True(t, preview[0].Duplicate)
+			assert.True(t, preview[1].Duplicate)
+
+			result, err = interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 0)
+			assert.Equal(t, 2, result.Duplicates)
+		})
+
+		t.Run("records history", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,Store,-5,\n")
+
+			result, err := interactor.ImportCSV(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+		})
+
+		t.Run("imports nothing if a row is invalid", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := csvParams(account, "date,payee,amount,notes\n01/02/2024,Store,-5,\n01/03/2024,Store,-6.001,\n")
+
+			_, err := interactor.ImportCSV(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Row 3: Amount must have at most 2 decimal points.")
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 0)
+		})
+	})
+
+	ofx := func(transactions string) string {
+		return "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>" +
+			transactions +
+			"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+	}
+
+	t.Run("ofx preview", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account ID is required.")
+		})
+
+		t.Run("cannot use account from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{
+				AccountID: c2.Account(AccountOpts{}).ID,
+				File:      ofx(""),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Account ID")
+		})
+
+		t.Run("rejects invalid file", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				File:      "date,amount",
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "File is not a valid OFX file.")
+		})
+
+		t.Run("can preview", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payeeID, err := interactor.PayeeCreate(t, c.ctx, "Grocery Store")
+			require.NoError(t, err)
+
+			rows, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{
+				AccountID: account.ID,
+				File: ofx("<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240102120000<TRNAMT>-12.50<FITID>1001<NAME>GROCERY STORE<MEMO>weekly shop</STMTTRN>" +
+					"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240103<TRNAMT>-3<FITID>1002<NAME>New Place</STMTTRN>"),
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+
+			assert.Equal(t, beans.ImportPreviewRow{
+				ImportRow: beans.ImportRow{
+					Date:      testutils.NewDate(t, "2024-01-02"),
+					Amount:    beans.NewAmount(-1250, -2),
+					PayeeName: "GROCERY STORE",
+					Notes:     beans.NewTransactionNotes("weekly shop"),
+					ImportID:  beans.NewNullString("1001"),
+				},
+				Payee: beans.OptionalWrap(beans.RelatedPayee{ID: payeeID, Name: "Grocery Store"}),
+			}, rows[0])
+
+			assert.Equal(t, beans.ImportPreviewRow{
+				ImportRow: beans.ImportRow{
+					Date:      testutils.NewDate(t, "2024-01-03"),
+					Amount:    beans.NewAmount(-3, 0),
+					PayeeName: "New Place",
+					ImportID:  beans.NewNullString("1002"),
+				},
+			}, rows[1])
+		})
+
+		t.Run("flags duplicates by fitid only", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			_, err := interactor.ImportOFX(t, c.ctx, beans.ImportOFXParams{
+				AccountID: account.ID,
+				File:      ofx("<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1001</STMTTRN>"),
+			})
+			require.NoError(t, err)
+
+			rows, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{
+				AccountID: account.ID,
+				File: ofx("<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1001</STMTTRN>" +
+					"<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1002</STMTTRN>" +
+					"<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1002</STMTTRN>"),
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 3)
+
+			assert.True(t, rows[0].Duplicate)
+			assert.False(t, rows[1].Duplicate)
+			assert.True(t, rows[2].Duplicate)
+		})
+
+		t.Run("does not flag fitid from other accounts", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			file := ofx("<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1001</STMTTRN>")
+			_, err := interactor.ImportOFX(t, c.ctx, beans.ImportOFXParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				File:      file,
+			})
+			require.NoError(t, err)
+
+			rows, err := interactor.ImportOFXPreview(t, c.ctx, beans.ImportOFXParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				File:      file,
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+
+			assert.False(t, rows[0].Duplicate)
+		})
+	})
+
+	t.Run("ofx import", func(t *testing.T) {
+
+		t.Run("can import", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			result, err := interactor.ImportOFX(t, c.ctx, beans.ImportOFXParams{
+				AccountID: account.ID,
+				File: `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+	<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+		<STMTTRN>
+			<TRNTYPE>CREDIT</TRNTYPE>
+			<DTPOSTED>20240105000000.000[-5:EST]</DTPOSTED>
+			<TRNAMT>1525.00</TRNAMT>
+			<FITID>2001</FITID>
+			<NAME>Employer &amp; Co</NAME>
+			<MEMO>Payroll</MEMO>
+		</STMTTRN>
+		<STMTTRN>
+			<TRNTYPE>DEBIT</TRNTYPE>
+			<DTPOSTED>20240106</DTPOSTED>
+			<TRNAMT>-20.00</TRNAMT>
+			<FITID>2002</FITID>
+			<NAME>Employer &amp; Co</NAME>
+		</STMTTRN>
+	</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 2)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(1525, 0), transaction.Amount)
+			assert.Equal(t, testutils.NewDate(t, "2024-01-05"), transaction.Date)
+			assert.Equal(t, beans.NewTransactionNotes("Payroll"), transaction.Notes)
+			assert.Equal(t, beans.RelatedAccount{ID: account.ID, Name: account.Name}, transaction.Account)
+
+			// the payee is created once
+			payee, ok := transaction.Payee.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.Name("Employer & Co"), payee.Name)
+
+			transaction, err = interactor.TransactionGet(t, c.ctx, result.TransactionIDs[1])
+			require.NoError(t, err)
+			assert.Equal(t, beans.OptionalWrap(payee), transaction.Payee)
+
+			payees, err := interactor.PayeeGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, payees, 1)
+		})
+
+		t.Run("reimporting does not duplicate", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			params := beans.ImportOFXParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				File: ofx("<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1001<NAME>Store</STMTTRN>" +
+					"<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1002<NAME>Store</STMTTRN>"),
+			}
+
+			result, err := interactor.ImportOFX(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 2)
+
+			result, err = interactor.ImportOFX(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 0)
+			assert.Equal(t, 2, result.Duplicates)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 2)
+		})
+
+		t.Run("reports invalid transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportOFX(t, c.ctx, beans.ImportOFXParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				File: ofx("<STMTTRN><DTPOSTED>20240102<TRNAMT>-5<FITID>1001</STMTTRN>" +
+					"<STMTTRN><DTPOSTED>20240102<TRNAMT>abc<FITID>1002</STMTTRN>"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Transaction 2: invalid amount "abc".`)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 0)
+		})
+	})
+
+	qifParams := func(account beans.Account, file string) beans.ImportQIFParams {
+		return beans.ImportQIFParams{
+			AccountID:  account.ID,
+			File:       file,
+			DateFormat: beans.DateFormatMDYSlash,
+		}
+	}
+
+	t.Run("qif import", func(t *testing.T) {
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportQIF(t, c.ctx, beans.ImportQIFParams{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Account ID is required. Date format is required.")
+		})
+
+		t.Run("cannot use account from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportQIF(t, c.ctx, qifParams(c2.Account(AccountOpts{}), "!Type:Bank\n"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Account ID")
+		})
+
+		t.Run("can import with category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, "!Type:Bank\n"+
+				"D1/ 2'24\nT-12.50\nPGrocery Store\nMweekly shop\nLParent:"+string(category.Name)+"\n^\n"))
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.NewAmount(-125, -1), transaction.Amount)
+			assert.Equal(t, testutils.NewDate(t, "2024-01-02"), transaction.Date)
+			assert.Equal(t, beans.NewTransactionNotes("weekly shop"), transaction.Notes)
+			assert.Equal(t, beans.TransactionStandard, transaction.Variant)
+			assert.Equal(t, beans.OptionalWrap(category.ToRelated()), transaction.Category)
+
+			payee, ok := transaction.Payee.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.Name("Grocery Store"), payee.Name)
+		})
+
+		t.Run("can import split", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			categoryA := c.Category(CategoryOpts{})
+			categoryB := c.Category(CategoryOpts{})
+
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, "!Type:Bank\n"+
+				"D01/02/2024\nT-30.00\nPStore\nL--Split--\n"+
+				"S"+string(categoryA.Name)+"\nEfood\n$-21.00\n"+
+				"S"+string(categoryB.Name)+"\n$-9.00\n^\n"))
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionSplit, transaction.Variant)
+			assert.True(t, transaction.Category.Empty())
+			assert.False(t, transaction.Payee.Empty())
+
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, splits, 2)
+
+			for _, split := range splits {
+				if split.Category.ID == categoryA.ID {
+					assert.Equal(t, beans.NewAmount(-21, 0), split.Amount)
+					assert.Equal(t, beans.NewTransactionNotes("food"), split.Notes)
+				} else {
+					assert.Equal(t, categoryB.ID, split.Category.ID)
+					assert.Equal(t, beans.NewAmount(-9, 0), split.Amount)
+				}
+			}
+		})
+
+		t.Run("can import transfer", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			savings := c.Account(AccountOpts{})
+
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, "!Type:Bank\n"+
+				"D01/02/2024\nT-100\nPTransfer\nL["+string(savings.Name)+"]\n^\n"))
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionTransfer, transaction.Variant)
+			assert.Equal(t, beans.OptionalWrap(savings.ToRelated()), transaction.TransferAccount)
+			assert.True(t, transaction.Payee.Empty())
+
+			// importing the other side of the transfer does not duplicate it
+			result, err = interactor.ImportQIF(t, c.ctx, qifParams(savings, "!Type:Bank\n"+
+				"D01/02/2024\nT100\nPTransfer\nL["+string(account.Name)+"]\n^\n"))
+			require.NoError(t, err)
+			assert.Len(t, result.TransactionIDs, 0)
+			assert.Equal(t, 1, result.Duplicates)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 2)
+		})
+
+		t.Run("treats transfer to same account as opening balance", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, "!Type:Bank\n"+
+				"D01/01/2024\nT500\nPOpening Balance\nL["+string(account.Name)+"]\n^\n"))
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionStandard, transaction.Variant)
+		})
+
+		t.Run("ignores categories on off-budget account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{OffBudget: true})
+
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, "!Type:Oth A\n"+
+				"D01/01/2024\nT500\nLUnknown\n^\n"))
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionOffBudget, transaction.Variant)
+			assert.True(t, transaction.Category.Empty())
+		})
+
+		t.Run("reports unknown category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportQIF(t, c.ctx, qifParams(c.Account(AccountOpts{}), "!Type:Bank\n"+
+				"D01/01/2024\nT5\n^\nD01/01/2024\nT5\nLMissing\n^\n"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 2: Category Missing does not exist.")
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, transactions, 0)
+		})
+
+		t.Run("reports unknown transfer account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ImportQIF(t, c.ctx, qifParams(c.Account(AccountOpts{}), "!Type:Bank\n"+
+				"D01/01/2024\nT5\nL[Missing]\n^\n"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 1: Account Missing does not exist.")
+		})
+
+		t.Run("reports splits that do not sum", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			category := c.Category(CategoryOpts{})
+
+			_, err := interactor.ImportQIF(t, c.ctx, qifParams(c.Account(AccountOpts{}), "!Type:Bank\n"+
+				"D01/01/2024\nT5\nS"+string(category.Name)+"\n$4\n^\n"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 1: Splits must sum to transaction.")
+		})
+	})
+
+	t.Run("qif export", func(t *testing.T) {
+
+		t.Run("exports one file per account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			checking := c.Account(AccountOpts{})
+			savings := c.Account(AccountOpts{})
+			empty := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+			payee := c.Payee(PayeeOpts{})
+
+			c.Transaction(TransactionOpts{Account: checking, Category: category, Payee: payee, Amount: "-12.5", Date: "2024-01-03", Notes: "lunch"})
+			c.Transfer(TransferOpts{AccountA: checking, AccountB: savings, Amount: "100", Date: "2024-01-02"})
+			c.Split(SplitOpts{Account: savings, Date: "2024-01-04", Splits: []SplitOpt{
+				{Amount: "5", Category: category, Notes: "part"},
+			}})
+
+			files, err := interactor.ExportQIF(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, files, 3)
+
+			byAccount := make(map[beans.ID]string)
+			for _, file := range files {
+				byAccount[file.Account.ID] = file.File
+			}
+
+			assert.Equal(t, "!Type:Bank\n"+
+				"D01/02/2024\nT100.00\nL["+string(savings.Name)+"]\n^\n"+
+				"D01/03/2024\nT-12.50\nP"+string(payee.Name)+"\nMlunch\nL"+string(category.Name)+"\n^\n",
+				byAccount[checking.ID])
+
+			assert.Equal(t, "!Type:Bank\n"+
+				"D01/02/2024\nT-100.00\nL["+string(checking.Name)+"]\n^\n"+
+				"D01/04/2024\nT5.00\nS"+string(category.Name)+"\nEpart\n$5.00\n^\n",
+				byAccount[savings.ID])
+
+			assert.Equal(t, "!Type:Bank\n", byAccount[empty.ID])
+		})
+
+		t.Run("exports credit card with its type", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			card := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			category := c.Category(CategoryOpts{})
+			c.Split(SplitOpts{Account: card, Date: "2024-01-04", Splits: []SplitOpt{
+				{Amount: "-5", Category: category, Notes: "b"},
+			}})
+
+			files, err := interactor.ExportQIF(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			assert.Equal(t, "!Type:CCard\n"+
+				"D01/04/2024\nT-5.00\n"+
+				"S"+string(category.Name)+"\nEb\n$-5.00\n^\n",
+				files[0].File)
+		})
+
+		t.Run("exported file can be imported", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+			c.Transaction(TransactionOpts{Account: account, Category: category, Amount: "-7", Date: "2024-01-03"})
+
+			files, err := interactor.ExportQIF(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			// everything is already there
+			result, err := interactor.ImportQIF(t, c.ctx, qifParams(account, files[0].File))
+			require.NoError(t, err)
+			assert.Equal(t, 1, result.Duplicates)
+		})
+	})
+}
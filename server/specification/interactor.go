@@ -15,38 +15,96 @@ type Interactor interface {
 	AccountList(t *testing.T, ctx Context) ([]beans.AccountWithBalance, error)
 	AccountListTransactable(t *testing.T, ctx Context) ([]beans.Account, error)
 	AccountGet(t *testing.T, ctx Context, id beans.ID) (beans.Account, error)
+	AccountUpdate(t *testing.T, ctx Context, params beans.AccountUpdateParams) error
+	AccountClose(t *testing.T, ctx Context, id beans.ID) error
+	AccountReopen(t *testing.T, ctx Context, id beans.ID) error
+	AccountDelete(t *testing.T, ctx Context, params beans.AccountDeleteParams) error
+	AccountReconcile(t *testing.T, ctx Context, params beans.AccountReconcileParams) (beans.ID, error)
+	AccountGetBalances(t *testing.T, ctx Context, params beans.AccountBalanceParams) ([]beans.AccountBalance, error)
+
+	// Attachment
+	AttachmentCreate(t *testing.T, ctx Context, transactionID beans.ID, fileName beans.Name, file []byte) (beans.ID, error)
+	AttachmentGetAll(t *testing.T, ctx Context, transactionID beans.ID) ([]beans.Attachment, error)
+	AttachmentGet(t *testing.T, ctx Context, transactionID beans.ID, id beans.ID) (beans.Attachment, []byte, error)
+	AttachmentDelete(t *testing.T, ctx Context, transactionID beans.ID, id beans.ID) error
 
 	// Budget
 	BudgetCreate(t *testing.T, ctx Context, name beans.Name) (beans.ID, error)
 	BudgetGet(t *testing.T, ctx Context, id beans.ID) (beans.Budget, error)
 	BudgetGetAll(t *testing.T, ctx Context) ([]beans.Budget, error)
+	BudgetUpdate(t *testing.T, ctx Context, params beans.BudgetUpdateParams) error
 
 	// Category
 	CategoryCreate(t *testing.T, ctx Context, groupID beans.ID, name beans.Name) (beans.ID, error)
 	CategoryGet(t *testing.T, ctx Context, id beans.ID) (beans.Category, error)
+	CategoryUpdate(t *testing.T, ctx Context, params beans.CategoryUpdateParams) error
+	CategoryDelete(t *testing.T, ctx Context, params beans.CategoryDeleteParams) error
+	CategorySetGoal(t *testing.T, ctx Context, id beans.ID, goal beans.Optional[beans.CategoryGoal]) error
 
 	CategoryGroupCreate(t *testing.T, ctx Context, name beans.Name) (beans.ID, error)
 	CategoryGroupGet(t *testing.T, ctx Context, id beans.ID) (beans.CategoryGroupWithCategories, error)
+	CategoryGroupUpdate(t *testing.T, ctx Context, params beans.CategoryGroupUpdateParams) error
+	CategoryGroupDelete(t *testing.T, ctx Context, id beans.ID) error
 
 	CategoryGetAll(t *testing.T, ctx Context) ([]beans.CategoryGroupWithCategories, error)
 
+	// Import
+	ImportCSVPreview(t *testing.T, ctx Context, params beans.ImportCSVParams) ([]beans.ImportPreviewRow, error)
+	ImportCSV(t *testing.T, ctx Context, params beans.ImportCSVParams) (beans.ImportResult, error)
+	ImportOFXPreview(t *testing.T, ctx Context, params beans.ImportOFXParams) ([]beans.ImportPreviewRow, error)
+	ImportOFX(t *testing.T, ctx Context, params beans.ImportOFXParams) (beans.ImportResult, error)
+	ImportQIF(t *testing.T, ctx Context, params beans.ImportQIFParams) (beans.ImportResult, error)
+	ExportQIF(t *testing.T, ctx Context) ([]beans.ExportedFile, error)
+
 	// Month
 	MonthGetOrCreate(t *testing.T, ctx Context, date beans.MonthDate) (beans.MonthWithDetails, error)
+	MonthGetRange(t *testing.T, ctx Context, params beans.MonthRangeParams) ([]beans.MonthWithDetails, error)
 	MonthUpdate(t *testing.T, ctx Context, monthID beans.ID, carryover beans.Amount) error
 	MonthSetCategoryAmount(t *testing.T, ctx Context, monthID beans.ID, categoryID beans.ID, amount beans.Amount) error
+	MonthQuickBudget(t *testing.T, ctx Context, params beans.QuickBudgetParams) (beans.MonthWithDetails, error)
+	MonthMoveMoney(t *testing.T, ctx Context, params beans.MoveMoneyParams) error
+	MonthGetMovements(t *testing.T, ctx Context, monthID beans.ID) ([]beans.MoneyMovement, error)
+	MonthCheckSnapshots(t *testing.T, ctx Context) ([]beans.MonthSnapshotMismatch, error)
 
 	// Payee
 	PayeeCreate(t *testing.T, ctx Context, name beans.Name) (beans.ID, error)
 	PayeeGetAll(t *testing.T, ctx Context) ([]beans.Payee, error)
 	PayeeGet(t *testing.T, ctx Context, id beans.ID) (beans.Payee, error)
 
+	// Rule
+	RuleCreate(t *testing.T, ctx Context, params beans.RuleParams) (beans.ID, error)
+	RuleUpdate(t *testing.T, ctx Context, params beans.RuleUpdateParams) error
+	RuleDelete(t *testing.T, ctx Context, id beans.ID) error
+	RuleGet(t *testing.T, ctx Context, id beans.ID) (beans.Rule, error)
+	RuleGetAll(t *testing.T, ctx Context) ([]beans.Rule, error)
+	RuleRun(t *testing.T, ctx Context, dryRun bool) ([]beans.RuleChange, error)
+
+	// Scheduled transaction
+	ScheduledTransactionCreate(t *testing.T, ctx Context, params beans.ScheduledTransactionParams) (beans.ID, error)
+	ScheduledTransactionUpdate(t *testing.T, ctx Context, params beans.ScheduledTransactionUpdateParams) error
+	ScheduledTransactionDelete(t *testing.T, ctx Context, id beans.ID) error
+	ScheduledTransactionGet(t *testing.T, ctx Context, id beans.ID) (beans.ScheduledTransaction, error)
+	ScheduledTransactionGetAll(t *testing.T, ctx Context) ([]beans.ScheduledTransaction, error)
+	ScheduledTransactionPostDue(t *testing.T, ctx Context, date beans.Date) (beans.PostDueResult, error)
+
+	// Tag
+	TagCreate(t *testing.T, ctx Context, name beans.Name) (beans.ID, error)
+	TagUpdate(t *testing.T, ctx Context, id beans.ID, name beans.Name) error
+	TagDelete(t *testing.T, ctx Context, id beans.ID) error
+	TagGet(t *testing.T, ctx Context, id beans.ID) (beans.Tag, error)
+	TagGetAll(t *testing.T, ctx Context) ([]beans.Tag, error)
+
 	// Transaction
 	TransactionCreate(t *testing.T, ctx Context, params beans.TransactionCreateParams) (beans.ID, error)
 	TransactionGet(t *testing.T, ctx Context, id beans.ID) (beans.TransactionWithRelations, error)
 	TransactionUpdate(t *testing.T, ctx Context, params beans.TransactionUpdateParams) error
+	TransactionBulkUpdate(t *testing.T, ctx Context, params beans.TransactionBulkUpdateParams) error
 	TransactionDelete(t *testing.T, ctx Context, ids []beans.ID) error
 	TransactionGetAll(t *testing.T, ctx Context) ([]beans.TransactionWithRelations, error)
+	TransactionList(t *testing.T, ctx Context, params beans.TransactionListParams) (beans.TransactionPage, error)
+	TransactionSearch(t *testing.T, ctx Context, params beans.TransactionSearchParams) ([]beans.TransactionWithRelations, error)
 	TransactionGetSplits(t *testing.T, ctx Context, id beans.ID) ([]beans.Split, error)
+	TransactionGetHistory(t *testing.T, ctx Context, id beans.ID) ([]beans.TransactionHistory, error)
 
 	// User
 	UserRegister(t *testing.T, ctx Context, username beans.Username, password beans.Password) error
@@ -62,6 +120,7 @@ type Context struct {
 }
 
 type AccountOpts struct {
+	Type      beans.AccountType
 	OffBudget bool
 }
 
@@ -84,6 +143,7 @@ type TransactionOpts struct {
 	Amount   string
 	Date     string
 	Notes    string
+	Status   beans.TransactionStatus
 }
 
 type TransferOpts struct {
@@ -160,6 +220,7 @@ func makeUserAndBudget(t *testing.T, interactor Interactor) *userAndBudget {
 func (u *userAndBudget) Account(opt AccountOpts) beans.Account {
 	params := beans.AccountCreate{
 		Name:      beans.Name(beans.NewID().String()),
+		Type:      opt.Type,
 		OffBudget: opt.OffBudget,
 	}
 
@@ -267,6 +328,8 @@ func (u *userAndBudget) Transaction(opt TransactionOpts) beans.TransactionWithRe
 		params.Notes = beans.NewTransactionNotes(opt.Notes)
 	}
 
+	params.Status = opt.Status
+
 	// create
 	id, err := u.interactor.TransactionCreate(u.t, u.ctx, beans.TransactionCreateParams{
 		TransactionParams: params,
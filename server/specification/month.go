@@ -2,6 +2,7 @@ package specification
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/internal/testutils"
@@ -28,6 +29,7 @@ func testMonth(t *testing.T, interactor Interactor) {
 			assert.Equal(t, beans.NewAmount(0, 0), month.Income)
 			assert.Equal(t, beans.NewAmount(0, 0), month.Assigned)
 			assert.Equal(t, beans.NewAmount(0, 0), month.Budgetable)
+			assert.Equal(t, beans.NewAmount(0, 0), month.Underfunded)
 
 			// only the income category should exist
 			assert.Equal(t, 1, len(month.Categories))
@@ -142,6 +144,331 @@ func testMonth(t *testing.T, interactor Interactor) {
 				assert.Equal(t, beans.NewAmount(68, -1), it.Available) // Have $6.8 (assigned + assigned in April)
 			})
 		})
+
+		t.Run("moves credit card spending to payment category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			card := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			checking := c.Account(AccountOpts{})
+			categoryBills := c.Category(CategoryOpts{})
+
+			// spent $10.25 in April and $3.10 in May on the card
+			c.Transaction(TransactionOpts{Account: card, Category: categoryBills, Date: "2022-04-10", Amount: "-10.25"})
+			c.Transaction(TransactionOpts{Account: card, Category: categoryBills, Date: "2022-05-10", Amount: "-3.1"})
+
+			// paid $5.50 towards the card in May
+			c.Transfer(TransferOpts{AccountA: card, AccountB: checking, Date: "2022-05-20", Amount: "5.5"})
+
+			// cash back as income is not moved
+			c.Transaction(TransactionOpts{Account: card, Category: c.findIncomeCategory(), Date: "2022-05-21", Amount: "1"})
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+			require.NoError(t, err)
+
+			findMonthCategory(t, res.Categories, categoryBills.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(-31, -1), it.Activity)
+				assert.Equal(t, beans.NewAmount(-1335, -2), it.Available)
+			})
+
+			findMonthCategory(t, res.Categories, card.PaymentCategoryID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Amount)
+				assert.Equal(t, beans.NewAmount(-24, -1), it.Activity)  // $3.10 spent - $5.50 paid
+				assert.Equal(t, beans.NewAmount(785, -2), it.Available) // $13.35 spent - $5.50 paid
+			})
+		})
+
+		t.Run("includes goal progress", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			groceries := c.Category(CategoryOpts{})
+			vacation := c.Category(CategoryOpts{})
+			hidden := c.Category(CategoryOpts{})
+			hiddenGroup := c.CategoryGroup(CategoryGroupOpts{})
+			inHiddenGroup := c.Category(CategoryOpts{Group: hiddenGroup})
+			noGoal := c.Category(CategoryOpts{})
+
+			monthly := beans.CategoryGoal{Type: beans.GoalMonthly, Amount: beans.NewAmount(5025, -2)}
+			byDate := beans.CategoryGoal{
+				Type:        beans.GoalTargetBalanceByDate,
+				Amount:      beans.NewAmount(30105, -2),
+				TargetMonth: testutils.NewMonthDate(t, "2022-07-01"),
+			}
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, groceries.ID, beans.OptionalWrap(monthly)))
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, vacation.ID, beans.OptionalWrap(byDate)))
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, hidden.ID, beans.OptionalWrap(monthly)))
+			require.NoError(t, interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      hidden.ID,
+				GroupID: hidden.GroupID,
+				Name:    hidden.Name,
+				Hidden:  true,
+			}))
+			require.NoError(t, interactor.CategorySetGoal(t, c.ctx, inHiddenGroup.ID, beans.OptionalWrap(monthly)))
+			require.NoError(t, interactor.CategoryGroupUpdate(t, c.ctx, beans.CategoryGroupUpdateParams{
+				ID:     hiddenGroup.ID,
+				Name:   hiddenGroup.Name,
+				Hidden: true,
+			}))
+
+			// vacation had $60.75 going into May, leaving $240.30 over three months
+			c.setAssigned(april, vacation, "60.75")
+			c.setAssigned(may, vacation, "30")
+			c.setAssigned(may, groceries, "20.50")
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, may.Date)
+			require.NoError(t, err)
+
+			findMonthCategory(t, res.Categories, groceries.ID, func(it beans.MonthCategoryWithDetails) {
+				progress, ok := it.Goal.Value()
+				require.True(t, ok)
+				assert.Equal(t, monthly, progress.Goal)
+				assert.Equal(t, beans.NewAmount(5025, -2), progress.Target)
+				assert.Equal(t, 40, progress.Percent)
+				assert.Equal(t, beans.NewAmount(2975, -2), progress.Underfunded)
+			})
+
+			findMonthCategory(t, res.Categories, vacation.ID, func(it beans.MonthCategoryWithDetails) {
+				progress, ok := it.Goal.Value()
+				require.True(t, ok)
+				assert.Equal(t, byDate, progress.Goal)
+				assert.Equal(t, beans.NewAmount(801, -1), progress.Target)
+				assert.Equal(t, 30, progress.Percent)
+				assert.Equal(t, beans.NewAmount(501, -1), progress.Underfunded)
+			})
+
+			findMonthCategory(t, res.Categories, hidden.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.True(t, it.Goal.Empty())
+			})
+
+			findMonthCategory(t, res.Categories, inHiddenGroup.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.True(t, it.Goal.Empty())
+			})
+
+			findMonthCategory(t, res.Categories, noGoal.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.True(t, it.Goal.Empty())
+			})
+
+			assert.Equal(t, beans.NewAmount(7985, -2), res.Underfunded)
+		})
+	})
+
+	t.Run("overspending rollover", func(t *testing.T) {
+
+		// Category is assigned $1 in April, and spends $2 on a credit card
+		// and $2.50 in cash, overspending by $3.50. Savings is assigned $3
+		// and spends nothing.
+		setup := func(t *testing.T, rollover beans.OverspendingRollover) (*userAndBudget, beans.Category, beans.Category, beans.Account) {
+			c := makeUserAndBudget(t, interactor)
+			require.NoError(t, interactor.BudgetUpdate(t, c.ctx, beans.BudgetUpdateParams{
+				ID:                   c.budget.ID,
+				Name:                 c.budget.Name,
+				OverspendingRollover: rollover,
+			}))
+
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+			savings := c.Category(CategoryOpts{})
+			card := c.Account(AccountOpts{Type: beans.AccountCreditCard})
+			cash := c.Account(AccountOpts{})
+
+			c.setAssigned(april, category, "1")
+			c.setAssigned(april, savings, "3")
+			c.Transaction(TransactionOpts{Account: card, Category: category, Amount: "-2", Date: "2022-04-10"})
+			c.Transaction(TransactionOpts{Account: cash, Category: category, Amount: "-2.5", Date: "2022-04-20"})
+
+			return c, category, savings, card
+		}
+
+		t.Run("carry forward keeps overspending in the category", func(t *testing.T) {
+			c, category, savings, card := setup(t, beans.OverspendingCarryForward)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-05-01"))
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(0, 0), res.Overspent)
+			assert.Equal(t, beans.NewAmount(0, 0), res.Budgetable)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(-35, -1), it.Available)
+			})
+			findMonthCategory(t, res.Categories, savings.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(3, 0), it.Available)
+			})
+			findMonthCategory(t, res.Categories, card.PaymentCategoryID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(2, 0), it.Available)
+			})
+		})
+
+		t.Run("reset takes overspending out of next month", func(t *testing.T) {
+			c, category, savings, card := setup(t, beans.OverspendingReset)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-05-01"))
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(35, -1), res.Overspent)
+			assert.Equal(t, beans.NewAmount(-35, -1), res.Budgetable)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Available)
+			})
+			findMonthCategory(t, res.Categories, savings.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(3, 0), it.Available)
+			})
+			findMonthCategory(t, res.Categories, card.PaymentCategoryID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(2, 0), it.Available)
+			})
+
+			// only taken out once
+			res, err = interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-06-01"))
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(0, 0), res.Overspent)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Available)
+			})
+		})
+
+		t.Run("reset with credit as debt takes credit overspending out of the card", func(t *testing.T) {
+			c, category, savings, card := setup(t, beans.OverspendingResetCreditAsDebt)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-05-01"))
+			require.NoError(t, err)
+
+			// $2 of the overspending was on the card
+			assert.Equal(t, beans.NewAmount(15, -1), res.Overspent)
+			assert.Equal(t, beans.NewAmount(-15, -1), res.Budgetable)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Available)
+			})
+			findMonthCategory(t, res.Categories, savings.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(3, 0), it.Available)
+			})
+			findMonthCategory(t, res.Categories, card.PaymentCategoryID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Available)
+			})
+		})
+
+		t.Run("overspending in months not viewed is still reset", func(t *testing.T) {
+			c, category, _, _ := setup(t, beans.OverspendingReset)
+
+			// overspend by another $1 in May without viewing it
+			c.Transaction(TransactionOpts{Category: category, Amount: "-1", Date: "2022-05-15"})
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-06-01"))
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(1, 0), res.Overspent)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Available)
+			})
+		})
+	})
+
+	t.Run("get range", func(t *testing.T) {
+
+		t.Run("from and to are required", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.MonthGetRange(t, c.ctx, beans.MonthRangeParams{})
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+		})
+
+		t.Run("from must not be after to", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.MonthGetRange(t, c.ctx, beans.MonthRangeParams{
+				From: testutils.NewMonthDate(t, "2022-05-01"),
+				To:   testutils.NewMonthDate(t, "2022-04-01"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "From must not be after To.")
+		})
+
+		t.Run("range is limited", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.MonthGetRange(t, c.ctx, beans.MonthRangeParams{
+				From: testutils.NewMonthDate(t, "2022-01-01"),
+				To:   testutils.NewMonthDate(t, "2023-01-01"),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Range must be at most 12 months.")
+		})
+
+		t.Run("matches getting each month", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			april := c.Month(MonthOpts{Date: "2022-04-01", Carryover: "6.7"})
+			may := c.Month(MonthOpts{Date: "2022-05-01", Carryover: "0.4"})
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+
+			c.setAssigned(april, category, "3.4")
+			c.setAssigned(may, category, "1.2")
+			c.Transaction(TransactionOpts{Account: account, Category: c.findIncomeCategory(), Amount: "9", Date: "2022-04-02"})
+			c.Transaction(TransactionOpts{Account: account, Category: category, Amount: "-5.5", Date: "2022-05-02"})
+
+			res, err := interactor.MonthGetRange(t, c.ctx, beans.MonthRangeParams{
+				From: april.Date,
+				To:   testutils.NewMonthDate(t, "2022-06-01"),
+			})
+			require.NoError(t, err)
+			require.Len(t, res, 3)
+
+			for _, month := range res {
+				expected, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+				require.NoError(t, err)
+				assert.Equal(t, expected, month)
+			}
+		})
+
+		t.Run("does not create future months", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+			c.setAssigned(april, category, "3")
+
+			params := beans.MonthRangeParams{
+				From: testutils.NewMonthDate(t, "2099-01-01"),
+				To:   testutils.NewMonthDate(t, "2099-02-01"),
+			}
+			res, err := interactor.MonthGetRange(t, c.ctx, params)
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+
+			for _, month := range res {
+				assert.Empty(t, month.ID)
+				assert.Equal(t, beans.NewAmount(0, 0), month.Assigned)
+				findMonthCategory(t, month.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+					assert.Empty(t, it.ID)
+					assert.Equal(t, beans.NewAmount(0, 0), it.Amount)
+					assert.Equal(t, beans.NewAmount(3, 0), it.Available)
+				})
+			}
+
+			// still not created
+			res, err = interactor.MonthGetRange(t, c.ctx, params)
+			require.NoError(t, err)
+			assert.Empty(t, res[0].ID)
+		})
+
+		t.Run("can create future months", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			res, err := interactor.MonthGetRange(t, c.ctx, beans.MonthRangeParams{
+				From:         testutils.NewMonthDate(t, "2099-01-01"),
+				To:           testutils.NewMonthDate(t, "2099-02-01"),
+				CreateFuture: true,
+			})
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+
+			for _, month := range res {
+				assert.NotEmpty(t, month.ID)
+				for _, category := range month.Categories {
+					assert.NotEmpty(t, category.ID)
+				}
+			}
+		})
 	})
 
 	t.Run("update", func(t *testing.T) {
@@ -329,4 +656,454 @@ func testMonth(t *testing.T, interactor Interactor) {
 			})
 		})
 	})
+
+	t.Run("quick budget", func(t *testing.T) {
+
+		t.Run("cannot quick budget with a month that does not exist", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: beans.NewID(),
+				Type:    beans.QuickBudgetReset,
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot quick budget with month from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			month := c2.Month(MonthOpts{Date: "2022-04-01"})
+
+			_, err := interactor.MonthQuickBudget(t, c1.ctx, beans.QuickBudgetParams{
+				MonthID: month.ID,
+				Type:    beans.QuickBudgetReset,
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("type is required", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+
+			_, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{MonthID: month.ID})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Type is required.")
+		})
+
+		t.Run("type must be supported", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+
+			_, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: month.ID,
+				Type:    "guess",
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Type guess is not supported.")
+		})
+
+		t.Run("average spent needs months in range", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+
+			for _, months := range []int{0, 13} {
+				_, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+					MonthID: month.ID,
+					Type:    beans.QuickBudgetAverageSpent,
+					Months:  months,
+				})
+				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Months must be between 1 and 12.")
+			}
+		})
+
+		t.Run("cannot quick budget category from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			month := c1.Month(MonthOpts{Date: "2022-04-01"})
+			category := c2.Category(CategoryOpts{})
+
+			_, err := interactor.MonthQuickBudget(t, c1.ctx, beans.QuickBudgetParams{
+				MonthID:     month.ID,
+				Type:        beans.QuickBudgetReset,
+				CategoryIDs: []beans.ID{category.ID},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Category ID.")
+		})
+
+		t.Run("cannot quick budget income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+
+			_, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID:     month.ID,
+				Type:        beans.QuickBudgetReset,
+				CategoryIDs: []beans.ID{c.findIncomeCategory().ID},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot assign to the income category.")
+		})
+
+		t.Run("can assign last month's assigned", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+			category3 := c.Category(CategoryOpts{})
+			hidden := c.Category(CategoryOpts{})
+			require.NoError(t, interactor.CategoryUpdate(t, c.ctx, beans.CategoryUpdateParams{
+				ID:      hidden.ID,
+				GroupID: hidden.GroupID,
+				Name:    hidden.Name,
+				Hidden:  true,
+			}))
+
+			c.setAssigned(april, category1, "12.34")
+			c.setAssigned(april, category2, "5.5")
+			c.setAssigned(april, hidden, "3")
+			c.setAssigned(may, category1, "1")
+			c.setAssigned(may, category3, "7")
+			c.setAssigned(may, hidden, "8")
+
+			res, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: may.ID,
+				Type:    beans.QuickBudgetAssignedLastMonth,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, may.ID, res.ID)
+			assert.Equal(t, beans.NewAmount(2584, -2), res.Assigned)
+
+			findMonthCategory(t, res.Categories, category1.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(1234, -2), it.Amount)
+			})
+			findMonthCategory(t, res.Categories, category2.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(55, -1), it.Amount)
+			})
+			findMonthCategory(t, res.Categories, category3.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Amount)
+			})
+			// hidden categories are left alone
+			findMonthCategory(t, res.Categories, hidden.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(8, 0), it.Amount)
+			})
+		})
+
+		t.Run("can assign to chosen categories", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+
+			c.setAssigned(april, category1, "12.34")
+			c.setAssigned(april, category2, "5.5")
+			c.setAssigned(may, category2, "1")
+
+			res, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID:     may.ID,
+				Type:        beans.QuickBudgetAssignedLastMonth,
+				CategoryIDs: []beans.ID{category1.ID},
+			})
+			require.NoError(t, err)
+
+			findMonthCategory(t, res.Categories, category1.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(1234, -2), it.Amount)
+			})
+			findMonthCategory(t, res.Categories, category2.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(1, 0), it.Amount)
+			})
+		})
+
+		t.Run("can assign last month's spending", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+
+			c.Transaction(TransactionOpts{Category: category1, Amount: "-20.5", Date: "2022-04-01"})
+			c.Transaction(TransactionOpts{Category: category1, Amount: "-1.25", Date: "2022-04-30"})
+			c.Transaction(TransactionOpts{Category: category1, Amount: "-100", Date: "2022-03-31"})
+			c.Transaction(TransactionOpts{Category: category1, Amount: "-100", Date: "2022-05-01"})
+			// a refund is not spending
+			c.Transaction(TransactionOpts{Category: category2, Amount: "5", Date: "2022-04-15"})
+			c.setAssigned(may, category2, "3")
+
+			res, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: may.ID,
+				Type:    beans.QuickBudgetSpentLastMonth,
+			})
+			require.NoError(t, err)
+
+			findMonthCategory(t, res.Categories, category1.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(2175, -2), it.Amount)
+			})
+			findMonthCategory(t, res.Categories, category2.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Amount)
+			})
+		})
+
+		t.Run("can assign average spending", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			category := c.Category(CategoryOpts{})
+
+			c.Transaction(TransactionOpts{Category: category, Amount: "-100", Date: "2022-01-31"})
+			c.Transaction(TransactionOpts{Category: category, Amount: "-10", Date: "2022-02-01"})
+			c.Transaction(TransactionOpts{Category: category, Amount: "-20", Date: "2022-03-15"})
+			c.Transaction(TransactionOpts{Category: category, Amount: "-0.01", Date: "2022-04-30"})
+
+			res, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: may.ID,
+				Type:    beans.QuickBudgetAverageSpent,
+				Months:  3,
+			})
+			require.NoError(t, err)
+
+			// $30.01 over three months, rounded up
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(1001, -2), it.Amount)
+			})
+		})
+
+		t.Run("can reset", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+
+			category := c.Category(CategoryOpts{})
+			c.setAssigned(may, category, "5")
+
+			res, err := interactor.MonthQuickBudget(t, c.ctx, beans.QuickBudgetParams{
+				MonthID: may.ID,
+				Type:    beans.QuickBudgetReset,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(0, 0), res.Assigned)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(0, 0), it.Amount)
+			})
+		})
+	})
+
+	t.Run("move money", func(t *testing.T) {
+
+		t.Run("cannot move with a month that does not exist", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:      beans.NewID(),
+				ToCategoryID: category.ID,
+				Amount:       beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot move with month from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			category := c1.Category(CategoryOpts{})
+			month := c2.Month(MonthOpts{Date: "2022-04-01"})
+
+			err := interactor.MonthMoveMoney(t, c1.ctx, beans.MoveMoneyParams{
+				MonthID:      month.ID,
+				ToCategoryID: category.ID,
+				Amount:       beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot move with category from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			month := c1.Month(MonthOpts{Date: "2022-04-01"})
+			category := c2.Category(CategoryOpts{})
+
+			err := interactor.MonthMoveMoney(t, c1.ctx, beans.MoveMoneyParams{
+				MonthID:        month.ID,
+				FromCategoryID: category.ID,
+				Amount:         beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot move with income category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:      month.ID,
+				ToCategoryID: c.findIncomeCategory().ID,
+				Amount:       beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move money to or from the income category.")
+		})
+
+		t.Run("cannot move to the same category", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:        month.ID,
+				FromCategoryID: category.ID,
+				ToCategoryID:   category.ID,
+				Amount:         beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move money to the same category.")
+
+			// both ready to assign
+			err = interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID: month.ID,
+				Amount:  beans.NewAmount(1, 0),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot move money to the same category.")
+		})
+
+		t.Run("amount must be positive", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+
+			for _, amount := range []beans.Amount{beans.NewEmptyAmount(), beans.NewAmount(0, 0), beans.NewAmount(-1, 0), beans.NewAmount(1, -3)} {
+				err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+					MonthID:      month.ID,
+					ToCategoryID: category.ID,
+					Amount:       amount,
+				})
+				testutils.AssertErrorCode(t, err, beans.EINVALID)
+			}
+		})
+
+		t.Run("can move between categories", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+			from := c.Category(CategoryOpts{})
+			to := c.Category(CategoryOpts{})
+
+			c.setAssigned(month, from, "10")
+			c.setAssigned(month, to, "1")
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:        month.ID,
+				FromCategoryID: from.ID,
+				ToCategoryID:   to.ID,
+				Amount:         beans.NewAmount(25, -1),
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(11, 0), res.Assigned)
+			findMonthCategory(t, res.Categories, from.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(75, -1), it.Amount)
+			})
+			findMonthCategory(t, res.Categories, to.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(35, -1), it.Amount)
+			})
+
+			movements, err := interactor.MonthGetMovements(t, c.ctx, month.ID)
+			require.NoError(t, err)
+			require.Len(t, movements, 1)
+			assert.Equal(t, from.ID, movements[0].FromCategoryID)
+			assert.Equal(t, to.ID, movements[0].ToCategoryID)
+			assert.Equal(t, beans.NewAmount(25, -1), movements[0].Amount)
+			assert.WithinDuration(t, time.Now(), movements[0].CreatedAt, time.Minute)
+		})
+
+		t.Run("can move to and from ready to assign", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			month := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:      month.ID,
+				ToCategoryID: category.ID,
+				Amount:       beans.NewAmount(5, 0),
+			})
+			require.NoError(t, err)
+
+			err = interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:        month.ID,
+				FromCategoryID: category.ID,
+				Amount:         beans.NewAmount(15, -1),
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.MonthGetOrCreate(t, c.ctx, month.Date)
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(-35, -1), res.Budgetable)
+			findMonthCategory(t, res.Categories, category.ID, func(it beans.MonthCategoryWithDetails) {
+				assert.Equal(t, beans.NewAmount(35, -1), it.Amount)
+			})
+
+			movements, err := interactor.MonthGetMovements(t, c.ctx, month.ID)
+			require.NoError(t, err)
+			require.Len(t, movements, 2)
+
+			assert.True(t, movements[0].FromCategoryID.Empty())
+			assert.Equal(t, category.ID, movements[0].ToCategoryID)
+			assert.Equal(t, beans.NewAmount(5, 0), movements[0].Amount)
+
+			assert.Equal(t, category.ID, movements[1].FromCategoryID)
+			assert.True(t, movements[1].ToCategoryID.Empty())
+			assert.Equal(t, beans.NewAmount(15, -1), movements[1].Amount)
+		})
+
+		t.Run("movements are kept by month", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			may := c.Month(MonthOpts{Date: "2022-05-01"})
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.MonthMoveMoney(t, c.ctx, beans.MoveMoneyParams{
+				MonthID:      april.ID,
+				ToCategoryID: category.ID,
+				Amount:       beans.NewAmount(5, 0),
+			})
+			require.NoError(t, err)
+
+			movements, err := interactor.MonthGetMovements(t, c.ctx, may.ID)
+			require.NoError(t, err)
+			assert.Len(t, movements, 0)
+		})
+
+		t.Run("cannot get movements for month from another budget", func(t *testing.T) {
+			c1 := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			month := c2.Month(MonthOpts{Date: "2022-04-01"})
+
+			_, err := interactor.MonthGetMovements(t, c1.ctx, month.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("check snapshots", func(t *testing.T) {
+
+		t.Run("snapshots match recompute", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			april := c.Month(MonthOpts{Date: "2022-04-01"})
+			category := c.Category(CategoryOpts{})
+			c.setAssigned(april, category, "10.25")
+			c.Transaction(TransactionOpts{Category: category, Amount: "-3", Date: "2022-04-10"})
+			c.Transaction(TransactionOpts{Category: category, Amount: "-1", Date: "2022-05-10"})
+
+			// reading a month stores its snapshots
+			_, err := interactor.MonthGetOrCreate(t, c.ctx, testutils.NewMonthDate(t, "2022-06-01"))
+			require.NoError(t, err)
+
+			mismatches, err := interactor.MonthCheckSnapshots(t, c.ctx)
+			require.NoError(t, err)
+			assert.Empty(t, mismatches)
+		})
+	})
 }
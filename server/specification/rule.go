@@ -0,0 +1,413 @@
+package specification
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule(t *testing.T, interactor Interactor) {
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Run("can create and get", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			payee := c.Payee(PayeeOpts{})
+			category := c.Category(CategoryOpts{})
+
+			id, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name: "Coffee",
+				RuleConditions: beans.RuleConditions{
+					PayeeContains: beans.NewNullString("coffee"),
+					MinAmount:     beans.NewAmount(-1025, -2),
+					MaxAmount:     beans.NewAmount(-1, 0),
+				},
+				RuleActions: beans.RuleActions{
+					PayeeID:     payee.ID,
+					CategoryID:  category.ID,
+					AppendNotes: beans.NewTransactionNotes("treat"),
+				},
+			})
+			require.NoError(t, err)
+
+			rule, err := interactor.RuleGet(t, c.ctx, id)
+			require.NoError(t, err)
+
+			assert.Equal(t, id, rule.ID)
+			assert.Equal(t, beans.Name("Coffee"), rule.Name)
+			assert.Equal(t, beans.NewNullString("coffee"), rule.PayeeContains)
+			assert.Equal(t, beans.NewAmount(-1025, -2), rule.MinAmount)
+			assert.Equal(t, beans.NewAmount(-1, 0), rule.MaxAmount)
+			assert.Equal(t, payee.ID, rule.PayeeID)
+			assert.Equal(t, category.ID, rule.CategoryID)
+			assert.Equal(t, beans.NewTransactionNotes("treat"), rule.AppendNotes)
+		})
+
+		t.Run("must have a condition and an action", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:        "Rule",
+				RuleActions: beans.RuleActions{AppendNotes: beans.NewTransactionNotes("hi")},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Rule must have a condition.")
+
+			_, err = interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("hi")},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Rule must have an action.")
+		})
+
+		t.Run("min cannot be more than max", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name: "Rule",
+				RuleConditions: beans.RuleConditions{
+					MinAmount: beans.NewAmount(5, 0),
+					MaxAmount: beans.NewAmount(4, 0),
+				},
+				RuleActions: beans.RuleActions{AppendNotes: beans.NewTransactionNotes("hi")},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Min amount must not be more than max amount.")
+		})
+
+		t.Run("cannot use payee or category from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			params := beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("hi")},
+				RuleActions:    beans.RuleActions{PayeeID: c2.Payee(PayeeOpts{}).ID},
+			}
+			_, err := interactor.RuleCreate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Payee ID")
+
+			params.RuleActions = beans.RuleActions{CategoryID: c2.Category(CategoryOpts{}).ID}
+			_, err = interactor.RuleCreate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Category ID")
+		})
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+
+		t.Run("can update", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			params := beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+				RuleActions:    beans.RuleActions{AppendNotes: beans.NewTransactionNotes("b")},
+			}
+			id, err := interactor.RuleCreate(t, c.ctx, params)
+			require.NoError(t, err)
+
+			params.Name = "New name"
+			params.RuleConditions = beans.RuleConditions{MinAmount: beans.NewAmount(525, -2)}
+			require.NoError(t, interactor.RuleUpdate(t, c.ctx, beans.RuleUpdateParams{ID: id, RuleParams: params}))
+
+			rule, err := interactor.RuleGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("New name"), rule.Name)
+			assert.True(t, rule.PayeeContains.Empty())
+			assert.Equal(t, beans.NewAmount(525, -2), rule.MinAmount)
+		})
+
+		t.Run("can delete", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			id, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+				RuleActions:    beans.RuleActions{AppendNotes: beans.NewTransactionNotes("b")},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, interactor.RuleDelete(t, c.ctx, id))
+
+			_, err = interactor.RuleGet(t, c.ctx, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot update or get from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			params := beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+				RuleActions:    beans.RuleActions{AppendNotes: beans.NewTransactionNotes("b")},
+			}
+			id, err := interactor.RuleCreate(t, c2.ctx, params)
+			require.NoError(t, err)
+
+			_, err = interactor.RuleGet(t, c.ctx, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			err = interactor.RuleUpdate(t, c.ctx, beans.RuleUpdateParams{ID: id, RuleParams: params})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("gets all in order of creation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			params := beans.RuleParams{
+				Name:           "Rule",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("a")},
+				RuleActions:    beans.RuleActions{AppendNotes: beans.NewTransactionNotes("b")},
+			}
+			ids := []beans.ID{}
+			for i := 0; i < 3; i++ {
+				id, err := interactor.RuleCreate(t, c.ctx, params)
+				require.NoError(t, err)
+				ids = append(ids, id)
+			}
+			_, err := interactor.RuleCreate(t, c2.ctx, params)
+			require.NoError(t, err)
+
+			rules, err := interactor.RuleGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, rules, 3)
+			for i, rule := range rules {
+				assert.Equal(t, ids[i], rule.ID)
+			}
+		})
+	})
+
+	t.Run("on create", func(t *testing.T) {
+
+		t.Run("sets payee, category and notes", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			coffeeShop := c.Payee(PayeeOpts{})
+			renamed := c.Payee(PayeeOpts{})
+			category := c.Category(CategoryOpts{})
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name: "Coffee",
+				RuleConditions: beans.RuleConditions{
+					PayeeContains: beans.NewNullString(string(coffeeShop.Name)[2:10]),
+					MaxAmount:     beans.NewAmount(-1, 0),
+				},
+				RuleActions: beans.RuleActions{
+					PayeeID:     renamed.ID,
+					CategoryID:  category.ID,
+					AppendNotes: beans.NewTransactionNotes("coffee"),
+				},
+			})
+			require.NoError(t, err)
+
+			// matches
+			transaction := c.Transaction(TransactionOpts{Payee: coffeeShop, Amount: "-4.25", Notes: "morning"})
+
+			payee, _ := transaction.Payee.Value()
+			assert.Equal(t, renamed.ID, payee.ID)
+			relatedCategory, _ := transaction.Category.Value()
+			assert.Equal(t, category.ID, relatedCategory.ID)
+			assert.Equal(t, beans.NewTransactionNotes("morning coffee"), transaction.Notes)
+
+			// amount out of range
+			transaction = c.Transaction(TransactionOpts{Payee: coffeeShop, Amount: "5"})
+			payee, _ = transaction.Payee.Value()
+			assert.Equal(t, coffeeShop.ID, payee.ID)
+			assert.True(t, transaction.Category.Empty())
+		})
+
+		t.Run("does not set payee or category on transfer", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Everything",
+				RuleConditions: beans.RuleConditions{MaxAmount: beans.NewAmount(0, 0)},
+				RuleActions: beans.RuleActions{
+					PayeeID:     c.Payee(PayeeOpts{}).ID,
+					CategoryID:  c.Category(CategoryOpts{}).ID,
+					AppendNotes: beans.NewTransactionNotes("moved"),
+				},
+			})
+			require.NoError(t, err)
+
+			transactions := c.Transfer(TransferOpts{Amount: "-5"})
+
+			assert.True(t, transactions[0].Payee.Empty())
+			assert.True(t, transactions[0].Category.Empty())
+			assert.Equal(t, beans.NewTransactionNotes("moved"), transactions[0].Notes)
+			assert.Equal(t, beans.NewTransactionNotes("moved"), transactions[1].Notes)
+		})
+
+		t.Run("runs on scheduled transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			category := c.Category(CategoryOpts{})
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Everything",
+				RuleConditions: beans.RuleConditions{MaxAmount: beans.NewAmount(0, 0)},
+				RuleActions:    beans.RuleActions{CategoryID: category.ID},
+			})
+			require.NoError(t, err)
+
+			_, err = interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(-5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-01-01"))
+			require.NoError(t, err)
+			require.Len(t, posted.TransactionIDs, 1)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, posted.TransactionIDs[0])
+			require.NoError(t, err)
+			relatedCategory, _ := transaction.Category.Value()
+			assert.Equal(t, category.ID, relatedCategory.ID)
+		})
+	})
+
+	t.Run("on import", func(t *testing.T) {
+
+		t.Run("matches the statement payee name", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			payee := c.Payee(PayeeOpts{})
+			category := c.Category(CategoryOpts{})
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Coffee",
+				RuleConditions: beans.RuleConditions{PayeeContains: beans.NewNullString("coffee")},
+				RuleActions: beans.RuleActions{
+					PayeeID:    payee.ID,
+					CategoryID: category.ID,
+				},
+			})
+			require.NoError(t, err)
+
+			result, err := interactor.ImportCSV(t, c.ctx, beans.ImportCSVParams{
+				AccountID: account.ID,
+				File:      "01/02/2024,ACME COFFEE #123,-4.25\n01/03/2024,Grocer,-3\n",
+				CSVFormat: beans.CSVFormat{
+					DateFormat: beans.DateFormatMDYSlash,
+					Columns: beans.CSVColumns{
+						Date:   beans.OptionalWrap(0),
+						Payee:  beans.OptionalWrap(1),
+						Amount: beans.OptionalWrap(2),
+					},
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, result.TransactionIDs, 2)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, result.TransactionIDs[0])
+			require.NoError(t, err)
+			related, _ := transaction.Payee.Value()
+			assert.Equal(t, payee.ID, related.ID)
+			relatedCategory, _ := transaction.Category.Value()
+			assert.Equal(t, category.ID, relatedCategory.ID)
+
+			transaction, err = interactor.TransactionGet(t, c.ctx, result.TransactionIDs[1])
+			require.NoError(t, err)
+			assert.True(t, transaction.Category.Empty())
+
+			// no payee is made for the renamed row
+			payees, err := interactor.PayeeGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, payees, 2)
+		})
+	})
+
+	t.Run("run", func(t *testing.T) {
+
+		t.Run("dry run does not save", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			category := c.Category(CategoryOpts{})
+			transaction := c.Transaction(TransactionOpts{Amount: "-5", Notes: "a"})
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Everything",
+				RuleConditions: beans.RuleConditions{MaxAmount: beans.NewAmount(0, 0)},
+				RuleActions: beans.RuleActions{
+					CategoryID:  category.ID,
+					AppendNotes: beans.NewTransactionNotes("b"),
+				},
+			})
+			require.NoError(t, err)
+
+			changes, err := interactor.RuleRun(t, c.ctx, true)
+			require.NoError(t, err)
+			require.Len(t, changes, 1)
+
+			assert.Equal(t, transaction.ID, changes[0].Transaction.ID)
+			assert.Equal(t, transaction.Notes, changes[0].Transaction.Notes)
+			assert.Equal(t, category.ID, changes[0].CategoryID)
+			assert.Equal(t, beans.NewTransactionNotes("a b"), changes[0].Notes)
+
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.True(t, res.Category.Empty())
+			assert.Equal(t, beans.NewTransactionNotes("a"), res.Notes)
+		})
+
+		t.Run("saves changes once", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			payee := c.Payee(PayeeOpts{})
+			transaction := c.Transaction(TransactionOpts{Amount: "-5"})
+			parent, splits := c.Split(SplitOpts{
+				Splits: []SplitOpt{{Amount: "-1"}, {Amount: "-2"}},
+			})
+			unmatched := c.Transaction(TransactionOpts{Amount: "5"})
+
+			_, err := interactor.RuleCreate(t, c.ctx, beans.RuleParams{
+				Name:           "Spending",
+				RuleConditions: beans.RuleConditions{MaxAmount: beans.NewAmount(0, 0)},
+				RuleActions:    beans.RuleActions{PayeeID: payee.ID},
+			})
+			require.NoError(t, err)
+
+			changes, err := interactor.RuleRun(t, c.ctx, false)
+			require.NoError(t, err)
+			assert.Len(t, changes, 2)
+
+			for _, id := range []beans.ID{transaction.ID, parent.ID} {
+				res, err := interactor.TransactionGet(t, c.ctx, id)
+				require.NoError(t, err)
+				related, _ := res.Payee.Value()
+				assert.Equal(t, payee.ID, related.ID)
+			}
+
+			// splits are kept and moved to the payee
+			all, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{PayeeID: payee.ID},
+			})
+			require.NoError(t, err)
+			assert.Len(t, all.Transactions, 2)
+			res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+			require.NoError(t, err)
+			assert.Len(t, res, len(splits))
+
+			res2, err := interactor.TransactionGet(t, c.ctx, unmatched.ID)
+			require.NoError(t, err)
+			assert.True(t, res2.Payee.Empty())
+
+			// nothing left to change
+			changes, err = interactor.RuleRun(t, c.ctx, false)
+			require.NoError(t, err)
+			assert.Len(t, changes, 0)
+		})
+	})
+}
@@ -0,0 +1,519 @@
+package specification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduledTransaction(t *testing.T, interactor Interactor) {
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Run("can create and get", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+			payee := c.Payee(PayeeOpts{})
+
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID:  account.ID,
+						CategoryID: category.ID,
+						PayeeID:    payee.ID,
+						Amount:     beans.NewAmount(-1525, -2),
+						Date:       testutils.NewDate(t, "2022-01-31"),
+						Notes:      beans.NewTransactionNotes("Rent"),
+					},
+				},
+				Frequency: beans.ScheduleMonths,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+
+			assert.Equal(t, id, scheduled.ID)
+			assert.Equal(t, account.ID, scheduled.Template.AccountID)
+			assert.Equal(t, category.ID, scheduled.Template.CategoryID)
+			assert.Equal(t, payee.ID, scheduled.Template.PayeeID)
+			assert.Equal(t, beans.NewAmount(-1525, -2), scheduled.Template.Amount)
+			assert.Equal(t, beans.NewTransactionNotes("Rent"), scheduled.Template.Notes)
+			assert.Equal(t, beans.ScheduleMonths, scheduled.Rule.Frequency)
+			assert.Equal(t, 1, scheduled.Rule.Interval)
+			assert.Equal(t, "2022-01-31", scheduled.Rule.Start.String())
+			assert.Equal(t, "2022-01-31", scheduled.NextDate.String())
+		})
+
+		t.Run("can create with splits", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(-8, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+						Splits: []beans.SplitParams{
+							{CategoryID: category1.ID, Amount: beans.NewAmount(-5, 0), Notes: beans.NewTransactionNotes("A")},
+							{CategoryID: category2.ID, Amount: beans.NewAmount(-3, 0)},
+						},
+					},
+				},
+				Frequency: beans.ScheduleWeeks,
+				Interval:  2,
+			})
+			require.NoError(t, err)
+
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+
+			assert.Equal(t, []beans.SplitParams{
+				{CategoryID: category1.ID, Amount: beans.NewAmount(-5, 0), Notes: beans.NewTransactionNotes("A")},
+				{CategoryID: category2.ID, Amount: beans.NewAmount(-3, 0)},
+			}, scheduled.Template.Splits)
+		})
+
+		t.Run("template is validated", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: beans.NewID(),
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			})
+			testutils.AssertErrorCode(t, err, beans.EINVALID)
+		})
+
+		t.Run("rule is validated", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+
+			params := beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  0,
+			}
+
+			_, err := interactor.ScheduledTransactionCreate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Interval must be between 1 and 999.")
+
+			params.Frequency = beans.ScheduleFrequency("hourly")
+			params.Interval = 1
+			_, err = interactor.ScheduledTransactionCreate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Frequency hourly is not supported.")
+		})
+	})
+
+	t.Run("post due", func(t *testing.T) {
+
+		t.Run("posts every due occurrence", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID:  account.ID,
+						CategoryID: category.ID,
+						Amount:     beans.NewAmount(-525, -2),
+						Date:       testutils.NewDate(t, "2022-01-31"),
+						Notes:      beans.NewTransactionNotes("Gym"),
+					},
+				},
+				Frequency: beans.ScheduleMonths,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-03-30"))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(posted.TransactionIDs))
+
+			dates := []string{}
+			for _, id := range posted.TransactionIDs {
+				transaction, err := interactor.TransactionGet(t, c.ctx, id)
+				require.NoError(t, err)
+
+				assert.Equal(t, account.ID, transaction.Account.ID)
+				relatedCategory, _ := transaction.Category.Value()
+				assert.Equal(t, category.ID, relatedCategory.ID)
+				assert.Equal(t, beans.NewAmount(-525, -2), transaction.Amount)
+				assert.Equal(t, beans.NewTransactionNotes("Gym"), transaction.Notes)
+				assert.Equal(t, beans.TransactionUncleared, transaction.Status)
+				dates = append(dates, transaction.Date.String())
+			}
+			assert.Equal(t, []string{"2022-01-31", "2022-02-28"}, dates)
+
+			// next date has advanced
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-03-31", scheduled.NextDate.String())
+
+			// posting again does not duplicate
+			posted, err = interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-03-30"))
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(posted.TransactionIDs))
+		})
+
+		t.Run("posts transfers and splits", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			savings := c.Account(AccountOpts{})
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+
+			_, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransferAccountID: savings.ID,
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(-125, -1),
+						Date:      testutils.NewDate(t, "2022-01-02"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  7,
+			})
+			require.NoError(t, err)
+
+			_, err = interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(-8, 0),
+						Date:      testutils.NewDate(t, "2022-01-03"),
+						Splits: []beans.SplitParams{
+							{CategoryID: category1.ID, Amount: beans.NewAmount(-5, 0)},
+							{CategoryID: category2.ID, Amount: beans.NewAmount(-3, 0)},
+						},
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  7,
+			})
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-01-03"))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(posted.TransactionIDs))
+
+			// transfer created both sides
+			transfer, err := interactor.TransactionGet(t, c.ctx, posted.TransactionIDs[0])
+			require.NoError(t, err)
+			transferAccount, _ := transfer.TransferAccount.Value()
+			assert.Equal(t, savings.ID, transferAccount.ID)
+			opposite := c.findTransferOpposite(transfer)
+			assert.Equal(t, beans.NewAmount(125, -1), opposite.Amount)
+
+			// split created its lines
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, posted.TransactionIDs[1])
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(splits))
+		})
+
+		t.Run("does not post from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ScheduledTransactionCreate(t, c2.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c2.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-01-01"))
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(posted.TransactionIDs))
+		})
+
+		t.Run("cannot post after today", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.ScheduledTransactionPostDue(t, c.ctx, beans.NewDate(time.Now().AddDate(0, 0, 2)))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot post scheduled transactions after today.")
+		})
+
+		t.Run("cannot post too many occurrences at once", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			_, err = interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2025-01-01"))
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot post more than 1000 occurrences at once. Post up to an earlier date first.")
+
+			// nothing was posted
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-01-01", scheduled.NextDate.String())
+		})
+
+		t.Run("skips and reports a schedule that is no longer valid", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			closed := c.Account(AccountOpts{})
+			create := func(accountID beans.ID) beans.ID {
+				id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+					Template: beans.TransactionCreateParams{
+						TransactionParams: beans.TransactionParams{
+							AccountID: accountID,
+							Amount:    beans.NewAmount(5, 0),
+							Date:      testutils.NewDate(t, "2022-01-01"),
+						},
+					},
+					Frequency: beans.ScheduleDays,
+					Interval:  1,
+				})
+				require.NoError(t, err)
+				return id
+			}
+			invalid := create(closed.ID)
+			valid := create(c.Account(AccountOpts{}).ID)
+
+			require.NoError(t, interactor.AccountClose(t, c.ctx, closed.ID))
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-01-02"))
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(posted.TransactionIDs))
+			assert.Equal(t, []beans.PostDueFailure{
+				{ScheduledTransactionID: invalid, Error: "Account is closed."},
+			}, posted.Failed)
+
+			// only the valid schedule has advanced
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, invalid)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-01-01", scheduled.NextDate.String())
+
+			scheduled, err = interactor.ScheduledTransactionGet(t, c.ctx, valid)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-01-03", scheduled.NextDate.String())
+		})
+	})
+
+	t.Run("update", func(t *testing.T) {
+
+		t.Run("can update", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			}
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, params)
+			require.NoError(t, err)
+
+			params.Template.Amount = beans.NewAmount(7, 0)
+			params.Template.Date = testutils.NewDate(t, "2022-02-28")
+			params.Frequency = beans.ScheduleLastBusinessDay
+			err = interactor.ScheduledTransactionUpdate(t, c.ctx, beans.ScheduledTransactionUpdateParams{
+				ID:                         id,
+				ScheduledTransactionParams: params,
+			})
+			require.NoError(t, err)
+
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+
+			assert.Equal(t, beans.NewAmount(7, 0), scheduled.Template.Amount)
+			assert.Equal(t, beans.ScheduleLastBusinessDay, scheduled.Rule.Frequency)
+			assert.Equal(t, "2022-02-28", scheduled.NextDate.String())
+		})
+
+		t.Run("does not post occurrences again after an edit", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			params := beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: account.ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-31"),
+					},
+				},
+				Frequency: beans.ScheduleMonths,
+				Interval:  1,
+			}
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, params)
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-03-30"))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(posted.TransactionIDs))
+
+			// editing the template keeps the next occurrence
+			params.Template.Amount = beans.NewAmount(7, 0)
+			require.NoError(t, interactor.ScheduledTransactionUpdate(t, c.ctx, beans.ScheduledTransactionUpdateParams{
+				ID:                         id,
+				ScheduledTransactionParams: params,
+			}))
+
+			posted, err = interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-03-30"))
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(posted.TransactionIDs))
+
+			// editing the rule counts from the next occurrence
+			params.Template.Date = testutils.NewDate(t, "2022-01-15")
+			params.Frequency = beans.ScheduleDays
+			params.Interval = 7
+			require.NoError(t, interactor.ScheduledTransactionUpdate(t, c.ctx, beans.ScheduledTransactionUpdateParams{
+				ID:                         id,
+				ScheduledTransactionParams: params,
+			}))
+
+			scheduled, err := interactor.ScheduledTransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-04-02", scheduled.NextDate.String())
+
+			posted, err = interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-03-31"))
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(posted.TransactionIDs))
+		})
+
+		t.Run("cannot update from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			params := beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c2.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			}
+			id, err := interactor.ScheduledTransactionCreate(t, c2.ctx, params)
+			require.NoError(t, err)
+
+			params.Template.AccountID = c.Account(AccountOpts{}).ID
+			err = interactor.ScheduledTransactionUpdate(t, c.ctx, beans.ScheduledTransactionUpdateParams{
+				ID:                         id,
+				ScheduledTransactionParams: params,
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+
+		t.Run("can delete and keeps posted transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(5, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+					},
+				},
+				Frequency: beans.ScheduleDays,
+				Interval:  1,
+			})
+			require.NoError(t, err)
+
+			posted, err := interactor.ScheduledTransactionPostDue(t, c.ctx, testutils.NewDate(t, "2022-01-01"))
+			require.NoError(t, err)
+			require.Equal(t, 1, len(posted.TransactionIDs))
+
+			require.NoError(t, interactor.ScheduledTransactionDelete(t, c.ctx, id))
+
+			_, err = interactor.ScheduledTransactionGet(t, c.ctx, id)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			_, err = interactor.TransactionGet(t, c.ctx, posted.TransactionIDs[0])
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("get all", func(t *testing.T) {
+
+		t.Run("can get all in order of next date", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			create := func(c *userAndBudget, date string) beans.ID {
+				id, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+					Template: beans.TransactionCreateParams{
+						TransactionParams: beans.TransactionParams{
+							AccountID: c.Account(AccountOpts{}).ID,
+							Amount:    beans.NewAmount(5, 0),
+							Date:      testutils.NewDate(t, date),
+						},
+					},
+					Frequency: beans.ScheduleWeeks,
+					Interval:  1,
+				})
+				require.NoError(t, err)
+				return id
+			}
+
+			later := create(c, "2022-05-01")
+			earlier := create(c, "2022-04-01")
+			create(c2, "2022-04-01")
+
+			res, err := interactor.ScheduledTransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, 2, len(res))
+			assert.Equal(t, earlier, res[0].ID)
+			assert.Equal(t, later, res[1].ID)
+		})
+	})
+}
@@ -0,0 +1,405 @@
+package specification
+
+import (
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTag(t *testing.T, interactor Interactor) {
+
+	makeTag := func(t *testing.T, c *userAndBudget, name beans.Name) beans.RelatedTag {
+		id, err := interactor.TagCreate(t, c.ctx, name)
+		require.NoError(t, err)
+		return beans.RelatedTag{ID: id, Name: name}
+	}
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Run("can create and get", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			id, err := interactor.TagCreate(t, c.ctx, "Vacation")
+			require.NoError(t, err)
+
+			tag, err := interactor.TagGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, id, tag.ID)
+			assert.Equal(t, beans.Name("Vacation"), tag.Name)
+		})
+
+		t.Run("name is required", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TagCreate(t, c.ctx, "")
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Name is required.")
+		})
+
+		t.Run("name must be unique", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			makeTag(t, c, "Vacation")
+
+			_, err := interactor.TagCreate(t, c.ctx, "vacation")
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Tag name already exists.")
+		})
+
+		t.Run("same name can be used in another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			makeTag(t, c2, "Vacation")
+
+			_, err := interactor.TagCreate(t, c.ctx, "Vacation")
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("get", func(t *testing.T) {
+
+		t.Run("get all is ordered by name", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			b := makeTag(t, c, "B")
+			a := makeTag(t, c, "A")
+
+			tags, err := interactor.TagGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, tags, 2)
+			assert.Equal(t, a.ID, tags[0].ID)
+			assert.Equal(t, a.Name, tags[0].Name)
+			assert.Equal(t, b.ID, tags[1].ID)
+			assert.Equal(t, b.Name, tags[1].Name)
+		})
+
+		t.Run("cannot get from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c2, "Vacation")
+
+			_, err := interactor.TagGet(t, c.ctx, tag.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+
+			tags, err := interactor.TagGetAll(t, c.ctx)
+			require.NoError(t, err)
+			assert.Len(t, tags, 0)
+		})
+	})
+
+	t.Run("update", func(t *testing.T) {
+
+		t.Run("can rename", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Vacation")
+
+			require.NoError(t, interactor.TagUpdate(t, c.ctx, tag.ID, "Trip"))
+
+			got, err := interactor.TagGet(t, c.ctx, tag.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.Name("Trip"), got.Name)
+		})
+
+		t.Run("can change the case of its own name", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "vacation")
+
+			require.NoError(t, interactor.TagUpdate(t, c.ctx, tag.ID, "Vacation"))
+		})
+
+		t.Run("cannot use the name of another tag", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			makeTag(t, c, "Vacation")
+			tag := makeTag(t, c, "Trip")
+
+			err := interactor.TagUpdate(t, c.ctx, tag.ID, "Vacation")
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Tag name already exists.")
+		})
+
+		t.Run("cannot update in another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c2, "Vacation")
+
+			err := interactor.TagUpdate(t, c.ctx, tag.ID, "Trip")
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+
+		t.Run("can delete", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Vacation")
+
+			require.NoError(t, interactor.TagDelete(t, c.ctx, tag.ID))
+
+			_, err := interactor.TagGet(t, c.ctx, tag.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("removes tag from transactions", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Vacation")
+			other := makeTag(t, c, "Work")
+
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: c.Account(AccountOpts{}).ID,
+					Amount:    beans.NewAmount(-1025, -2),
+					Date:      testutils.NewDate(t, "2022-01-01"),
+					TagIDs:    []beans.ID{tag.ID, other.ID},
+				},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, interactor.TagDelete(t, c.ctx, tag.ID))
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.RelatedTag{other}, transaction.Tags)
+		})
+
+		t.Run("cannot delete in another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c2, "Vacation")
+
+			require.NoError(t, interactor.TagDelete(t, c.ctx, tag.ID))
+
+			_, err := interactor.TagGet(t, c2.ctx, tag.ID)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+
+		t.Run("can create with tags", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			work := makeTag(t, c, "Work")
+			trip := makeTag(t, c, "Trip")
+
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: c.Account(AccountOpts{}).ID,
+					Amount:    beans.NewAmount(-1025, -2),
+					Date:      testutils.NewDate(t, "2022-01-01"),
+					TagIDs:    []beans.ID{work.ID, trip.ID},
+				},
+			})
+			require.NoError(t, err)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.RelatedTag{trip, work}, transaction.Tags)
+
+			transactions, err := interactor.TransactionGetAll(t, c.ctx)
+			require.NoError(t, err)
+			require.Len(t, transactions, 1)
+			assert.Equal(t, []beans.RelatedTag{trip, work}, transactions[0].Tags)
+		})
+
+		t.Run("can tag split lines", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Trip")
+
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: c.Account(AccountOpts{}).ID,
+					Amount:    beans.NewAmount(-8, 0),
+					Date:      testutils.NewDate(t, "2022-01-01"),
+					Splits: []beans.SplitParams{
+						{CategoryID: c.Category(CategoryOpts{}).ID, Amount: beans.NewAmount(-5, 0), TagIDs: []beans.ID{tag.ID}},
+						{CategoryID: c.Category(CategoryOpts{}).ID, Amount: beans.NewAmount(-3, 0)},
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Nil(t, transaction.Tags)
+
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, id)
+			require.NoError(t, err)
+			require.Len(t, splits, 2)
+			for _, split := range splits {
+				if split.Amount.Compare(beans.NewAmount(-5, 0)) == 0 {
+					assert.Equal(t, []beans.RelatedTag{tag}, split.Tags)
+				} else {
+					assert.Nil(t, split.Tags)
+				}
+			}
+		})
+
+		t.Run("update replaces tags", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			work := makeTag(t, c, "Work")
+			trip := makeTag(t, c, "Trip")
+
+			params := beans.TransactionParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				Amount:    beans.NewAmount(-1025, -2),
+				Date:      testutils.NewDate(t, "2022-01-01"),
+				TagIDs:    []beans.ID{work.ID},
+			}
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{TransactionParams: params})
+			require.NoError(t, err)
+
+			params.TagIDs = []beans.ID{trip.ID}
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{ID: id, TransactionParams: params}))
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.RelatedTag{trip}, transaction.Tags)
+
+			params.TagIDs = nil
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{ID: id, TransactionParams: params}))
+
+			transaction, err = interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Nil(t, transaction.Tags)
+		})
+
+		t.Run("update can tag split lines", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Trip")
+			category := c.Category(CategoryOpts{})
+
+			params := beans.TransactionParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				Amount:    beans.NewAmount(-8, 0),
+				Date:      testutils.NewDate(t, "2022-01-01"),
+				Splits: []beans.SplitParams{
+					{CategoryID: category.ID, Amount: beans.NewAmount(-8, 0)},
+				},
+			}
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{TransactionParams: params})
+			require.NoError(t, err)
+
+			params.Splits = []beans.SplitParams{
+				{CategoryID: category.ID, Amount: beans.NewAmount(-5, 0), TagIDs: []beans.ID{tag.ID}},
+				{CategoryID: category.ID, Amount: beans.NewAmount(-3, 0), TagIDs: []beans.ID{tag.ID}},
+			}
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{ID: id, TransactionParams: params}))
+
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, id)
+			require.NoError(t, err)
+			require.Len(t, splits, 2)
+			assert.Equal(t, []beans.RelatedTag{tag}, splits[0].Tags)
+			assert.Equal(t, []beans.RelatedTag{tag}, splits[1].Tags)
+		})
+
+		t.Run("cannot use tag from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c2, "Trip")
+
+			params := beans.TransactionParams{
+				AccountID: c.Account(AccountOpts{}).ID,
+				Amount:    beans.NewAmount(-8, 0),
+				Date:      testutils.NewDate(t, "2022-01-01"),
+				TagIDs:    []beans.ID{tag.ID},
+			}
+			_, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{TransactionParams: params})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Tag ID")
+
+			params.TagIDs = nil
+			params.Splits = []beans.SplitParams{
+				{CategoryID: c.Category(CategoryOpts{}).ID, Amount: beans.NewAmount(-8, 0), TagIDs: []beans.ID{tag.ID}},
+			}
+			_, err = interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{TransactionParams: params})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Invalid Tag ID")
+		})
+
+		t.Run("can filter list by tag", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Trip")
+			account := c.Account(AccountOpts{})
+
+			tagged, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-1, 0),
+					Date:      testutils.NewDate(t, "2022-01-02"),
+					TagIDs:    []beans.ID{tag.ID},
+				},
+			})
+			require.NoError(t, err)
+			split, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-2, 0),
+					Date:      testutils.NewDate(t, "2022-01-01"),
+					Splits: []beans.SplitParams{
+						{CategoryID: c.Category(CategoryOpts{}).ID, Amount: beans.NewAmount(-2, 0), TagIDs: []beans.ID{tag.ID}},
+					},
+				},
+			})
+			require.NoError(t, err)
+			c.Transaction(TransactionOpts{Account: account})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{TagID: tag.ID},
+			})
+			require.NoError(t, err)
+
+			ids := []beans.ID{}
+			for _, transaction := range page.Transactions {
+				ids = append(ids, transaction.ID)
+			}
+			assert.Equal(t, []beans.ID{tagged, split}, ids)
+		})
+
+		t.Run("bulk update keeps tags", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Trip")
+			account := c.Account(AccountOpts{})
+
+			id, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-2, 0),
+					Date:      testutils.NewDate(t, "2022-01-01"),
+					TagIDs:    []beans.ID{tag.ID},
+					Splits: []beans.SplitParams{
+						{CategoryID: c.Category(CategoryOpts{}).ID, Amount: beans.NewAmount(-2, 0), TagIDs: []beans.ID{tag.ID}},
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:   []beans.ID{id},
+				Notes: beans.OptionalWrap(beans.NewTransactionNotes("trip")),
+			}))
+
+			transaction, err := interactor.TransactionGet(t, c.ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []beans.RelatedTag{tag}, transaction.Tags)
+
+			splits, err := interactor.TransactionGetSplits(t, c.ctx, id)
+			require.NoError(t, err)
+			require.Len(t, splits, 1)
+			assert.Equal(t, []beans.RelatedTag{tag}, splits[0].Tags)
+		})
+
+		t.Run("scheduled transactions cannot have tags", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			tag := makeTag(t, c, "Trip")
+
+			_, err := interactor.ScheduledTransactionCreate(t, c.ctx, beans.ScheduledTransactionParams{
+				Template: beans.TransactionCreateParams{
+					TransactionParams: beans.TransactionParams{
+						AccountID: c.Account(AccountOpts{}).ID,
+						Amount:    beans.NewAmount(-2, 0),
+						Date:      testutils.NewDate(t, "2022-01-01"),
+						TagIDs:    []beans.ID{tag.ID},
+					},
+				},
+				Frequency: beans.ScheduleMonths,
+				Interval:  1,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Scheduled transactions cannot have tags.")
+		})
+	})
+}
@@ -8,6 +8,10 @@ func DoTests(t *testing.T, interactor Interactor) {
 		t.Parallel()
 		testAccount(t, interactor)
 	})
+	t.Run("attachment", func(t *testing.T) {
+		t.Parallel()
+		testAttachment(t, interactor)
+	})
 	t.Run("budget", func(t *testing.T) {
 		t.Parallel()
 		testBudget(t, interactor)
@@ -16,6 +20,10 @@ func DoTests(t *testing.T, interactor Interactor) {
 		t.Parallel()
 		testCategory(t, interactor)
 	})
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+		testImport(t, interactor)
+	})
 	t.Run("month", func(t *testing.T) {
 		t.Parallel()
 		testMonth(t, interactor)
@@ -24,6 +32,18 @@ func DoTests(t *testing.T, interactor Interactor) {
 		t.Parallel()
 		testPayee(t, interactor)
 	})
+	t.Run("rule", func(t *testing.T) {
+		t.Parallel()
+		testRule(t, interactor)
+	})
+	t.Run("scheduled transaction", func(t *testing.T) {
+		t.Parallel()
+		testScheduledTransaction(t, interactor)
+	})
+	t.Run("tag", func(t *testing.T) {
+		t.Parallel()
+		testTag(t, interactor)
+	})
 	t.Run("transaction", func(t *testing.T) {
 		t.Parallel()
 		testTransaction(t, interactor)
@@ -1,7 +1,10 @@
 package specification
 
 import (
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"github.com/bradenrayhorn/beans/server/internal/testutils"
@@ -881,15 +884,16 @@ func testTransaction(t *testing.T, interactor Interactor) {
 				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "cannot update a split directly")
 			})
 
-			t.Run("cannot update to have different amount of splits", func(t *testing.T) {
+			t.Run("can add a split", func(t *testing.T) {
 				c := makeUserAndBudget(t, interactor)
 
 				// create split
 				parent, splits := c.Split(SplitOpts{
 					Splits: []SplitOpt{{Amount: "1"}},
 				})
+				category := c.Category(CategoryOpts{})
 
-				// try to add a second split to parent
+				// add a second split to parent
 				params := beans.TransactionUpdateParams{
 					ID: parent.ID,
 					TransactionParams: beans.TransactionParams{
@@ -903,15 +907,158 @@ func testTransaction(t *testing.T, interactor Interactor) {
 							},
 							{
 								Amount:     beans.NewAmount(3, 0),
-								CategoryID: splits[0].Category.ID,
+								CategoryID: category.ID,
+								Notes:      beans.NewTransactionNotes("new"),
 							},
 						},
 					},
 				}
+				require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+
+				// verify splits
+				res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				require.Equal(t, 2, len(res))
+
+				findSplit(t, res, splits[0].ID, func(it beans.Split) {
+					assert.Equal(t, beans.NewAmount(1, 0), it.Amount)
+				})
+				for _, it := range res {
+					if it.ID != splits[0].ID {
+						assert.Equal(t, beans.NewAmount(3, 0), it.Amount)
+						assert.Equal(t, category.ToRelated(), it.Category)
+						assert.Equal(t, beans.NewTransactionNotes("new"), it.Notes)
+					}
+				}
+			})
+
+			t.Run("can remove a split", func(t *testing.T) {
+				c := makeUserAndBudget(t, interactor)
+
+				// create split
+				parent, splits := c.Split(SplitOpts{
+					Splits: []SplitOpt{{Amount: "1"}, {Amount: "3"}},
+				})
+
+				// keep only one split
+				params := beans.TransactionUpdateParams{
+					ID: parent.ID,
+					TransactionParams: beans.TransactionParams{
+						AccountID: parent.Account.ID,
+						Amount:    beans.NewAmount(3, 0),
+						Date:      parent.Date,
+						Splits: []beans.SplitParams{
+							{
+								Amount:     beans.NewAmount(3, 0),
+								CategoryID: splits[1].Category.ID,
+							},
+						},
+					},
+				}
+				require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+
+				// verify splits
+				res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(res))
+				assert.Equal(t, beans.NewAmount(3, 0), res[0].Amount)
+				assert.Equal(t, splits[1].Category, res[0].Category)
+
+				// parent is still a split
+				transaction, err := interactor.TransactionGet(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				assert.Equal(t, beans.TransactionSplit, transaction.Variant)
+			})
+
+			t.Run("can convert a transaction to a split", func(t *testing.T) {
+				c := makeUserAndBudget(t, interactor)
+
+				transaction := c.Transaction(TransactionOpts{Amount: "4"})
+				category1 := c.Category(CategoryOpts{})
+				category2 := c.Category(CategoryOpts{})
+
+				params := beans.TransactionUpdateParams{
+					ID: transaction.ID,
+					TransactionParams: beans.TransactionParams{
+						AccountID: transaction.Account.ID,
+						Amount:    beans.NewAmount(4, 0),
+						Date:      transaction.Date,
+						Splits: []beans.SplitParams{
+							{Amount: beans.NewAmount(1, 0), CategoryID: category1.ID},
+							{Amount: beans.NewAmount(3, 0), CategoryID: category2.ID},
+						},
+					},
+				}
+				require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+
+				res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+				require.NoError(t, err)
+				assert.Equal(t, beans.TransactionSplit, res.Variant)
+
+				splits, err := interactor.TransactionGetSplits(t, c.ctx, transaction.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 2, len(splits))
+			})
+
+			t.Run("can convert a split to a transaction", func(t *testing.T) {
+				c := makeUserAndBudget(t, interactor)
+
+				// create split
+				parent, _ := c.Split(SplitOpts{
+					Splits: []SplitOpt{{Amount: "1"}, {Amount: "3"}},
+				})
+				category := c.Category(CategoryOpts{})
+
+				params := beans.TransactionUpdateParams{
+					ID: parent.ID,
+					TransactionParams: beans.TransactionParams{
+						AccountID:  parent.Account.ID,
+						CategoryID: category.ID,
+						Amount:     beans.NewAmount(4, 0),
+						Date:       parent.Date,
+					},
+				}
+				require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+
+				res, err := interactor.TransactionGet(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				assert.Equal(t, beans.TransactionStandard, res.Variant)
+				relatedCategory, _ := res.Category.Value()
+				assert.Equal(t, category.ID, relatedCategory.ID)
+
+				splits, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 0, len(splits))
+			})
+
+			t.Run("cannot add splits that do not sum to transaction", func(t *testing.T) {
+				c := makeUserAndBudget(t, interactor)
+
+				// create split
+				parent, splits := c.Split(SplitOpts{
+					Splits: []SplitOpt{{Amount: "1"}},
+				})
+
+				params := beans.TransactionUpdateParams{
+					ID: parent.ID,
+					TransactionParams: beans.TransactionParams{
+						AccountID: parent.Account.ID,
+						Amount:    beans.NewAmount(1, 0),
+						Date:      parent.Date,
+						Splits: []beans.SplitParams{
+							{Amount: beans.NewAmount(1, 0), CategoryID: splits[0].Category.ID},
+							{Amount: beans.NewAmount(3, 0), CategoryID: splits[0].Category.ID},
+						},
+					},
+				}
 
-				// should fail
 				err := interactor.TransactionUpdate(t, c.ctx, params)
-				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "cannot add or remove split")
+				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Splits must sum to transaction.")
+
+				// splits are unchanged
+				res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 1, len(res))
 			})
 
 			t.Run("cannot update split with off-budget account", func(t *testing.T) {
@@ -967,7 +1114,7 @@ func testTransaction(t *testing.T, interactor Interactor) {
 
 				// should fail
 				err := interactor.TransactionUpdate(t, c.ctx, params)
-				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "cannot add or remove split")
+				testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot transfer on split")
 			})
 
 			t.Run("cannot update split with a category", func(t *testing.T) {
@@ -1264,6 +1411,154 @@ func testTransaction(t *testing.T, interactor Interactor) {
 
 	})
 
+	t.Run("bulk update", func(t *testing.T) {
+
+		t.Run("can change category and notes", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			payee := c.Payee(PayeeOpts{})
+			category := c.Category(CategoryOpts{})
+			transaction1 := c.Transaction(TransactionOpts{Payee: payee, Amount: "5", Notes: "old"})
+			transaction2 := c.Transaction(TransactionOpts{Category: c.Category(CategoryOpts{}), Amount: "7"})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:        []beans.ID{transaction1.ID, transaction2.ID},
+				CategoryID: beans.OptionalWrap(category.ID),
+				Notes:      beans.OptionalWrap(beans.NewTransactionNotes("new")),
+			})
+			require.NoError(t, err)
+
+			for _, transaction := range []beans.TransactionWithRelations{transaction1, transaction2} {
+				res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+				require.NoError(t, err)
+
+				relatedCategory, _ := res.Category.Value()
+				assert.Equal(t, category.ID, relatedCategory.ID)
+				assert.Equal(t, beans.NewTransactionNotes("new"), res.Notes)
+
+				// other fields are unchanged
+				assert.Equal(t, transaction.Account, res.Account)
+				assert.Equal(t, transaction.Payee, res.Payee)
+				assert.Equal(t, transaction.Amount, res.Amount)
+				assert.Equal(t, transaction.Date, res.Date)
+			}
+		})
+
+		t.Run("can change account, date and clear payee", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Payee: c.Payee(PayeeOpts{})})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:       []beans.ID{transaction.ID},
+				AccountID: beans.OptionalWrap(account.ID),
+				PayeeID:   beans.OptionalWrap(beans.EmptyID()),
+				Date:      beans.OptionalWrap(testutils.NewDate(t, "2022-03-04")),
+			})
+			require.NoError(t, err)
+
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, account.ID, res.Account.ID)
+			assert.True(t, res.Payee.Empty())
+			assert.Equal(t, "2022-03-04", res.Date.String())
+		})
+
+		t.Run("keeps splits and transfers in sync", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			parent, splits := c.Split(SplitOpts{
+				Splits: []SplitOpt{{Amount: "1"}, {Amount: "3"}},
+			})
+			transfer := c.Transfer(TransferOpts{})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:  []beans.ID{parent.ID, transfer[0].ID},
+				Date: beans.OptionalWrap(testutils.NewDate(t, "2022-03-04")),
+			})
+			require.NoError(t, err)
+
+			// splits are kept
+			res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+			require.NoError(t, err)
+			require.Equal(t, 2, len(res))
+			findSplit(t, res, splits[0].ID, func(it beans.Split) {
+				assert.Equal(t, splits[0].Amount, it.Amount)
+			})
+
+			// other side of transfer is moved
+			opposite, err := interactor.TransactionGet(t, c.ctx, transfer[1].ID)
+			require.NoError(t, err)
+			assert.Equal(t, "2022-03-04", opposite.Date.String())
+		})
+
+		t.Run("cannot set category on transfer and changes nothing", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transaction := c.Transaction(TransactionOpts{})
+			transfer := c.Transfer(TransferOpts{})
+			category := c.Category(CategoryOpts{})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:        []beans.ID{transaction.ID, transfer[0].ID},
+				CategoryID: beans.OptionalWrap(category.ID),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "category can only be set on standard transaction")
+
+			// first transaction was not changed
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.True(t, res.Category.Empty())
+		})
+
+		t.Run("cannot set category on split", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			parent, _ := c.Split(SplitOpts{
+				Splits: []SplitOpt{{Amount: "1"}},
+			})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:        []beans.ID{parent.ID},
+				CategoryID: beans.OptionalWrap(c.Category(CategoryOpts{}).ID),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "category can only be set on standard transaction")
+		})
+
+		t.Run("cannot edit both sides of a transfer", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transfer := c.Transfer(TransferOpts{})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:   []beans.ID{transfer[0].ID, transfer[1].ID},
+				Notes: beans.OptionalWrap(beans.NewTransactionNotes("hi")),
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot bulk edit both sides of a transfer.")
+		})
+
+		t.Run("ids are required", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction IDs is required.")
+		})
+
+		t.Run("cannot update transaction from another budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			transaction := c2.Transaction(TransactionOpts{})
+
+			err := interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:   []beans.ID{transaction.ID},
+				Notes: beans.OptionalWrap(beans.NewTransactionNotes("hi")),
+			})
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+	})
+
 	t.Run("delete", func(t *testing.T) {
 
 		t.Run("can delete", func(t *testing.T) {
@@ -1484,62 +1779,791 @@ func testTransaction(t *testing.T, interactor Interactor) {
 		})
 	})
 
-	t.Run("get splits", func(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
 
-		t.Run("can get splits", func(t *testing.T) {
+		t.Run("defaults to uncleared", func(t *testing.T) {
 			c := makeUserAndBudget(t, interactor)
 
-			// make some categories
-			category1 := c.Category(CategoryOpts{})
-			category2 := c.Category(CategoryOpts{})
+			transaction := c.Transaction(TransactionOpts{})
+			assert.Equal(t, beans.TransactionUncleared, transaction.Status)
+		})
 
-			// make split transaction
-			parent, splits := c.Split(SplitOpts{Splits: []SplitOpt{
-				{Amount: "10.72", Category: category1, Notes: ":0)"},
-				{Amount: "1.43", Category: category2, Notes: ":)"},
-			}})
+		t.Run("can create cleared", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
 
-			// get splits for parent
-			res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
-			require.NoError(t, err)
-			assert.Equal(t, 2, len(res))
+			transaction := c.Transaction(TransactionOpts{Status: beans.TransactionCleared})
+			assert.Equal(t, beans.TransactionCleared, transaction.Status)
+		})
 
-			findSplit(t, res, splits[0].ID, func(it beans.Split) {
-				assert.Equal(t, beans.NewAmount(1072, -2), it.Amount)
-				assert.Equal(t, beans.NewTransactionNotes(":0)"), it.Notes)
-				assert.Equal(t, category1.ToRelated(), it.Category)
+		t.Run("cannot create reconciled", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: c.Account(AccountOpts{}).ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-01"),
+					Status:    beans.TransactionReconciled,
+				},
 			})
-			findSplit(t, res, splits[1].ID, func(it beans.Split) {
-				assert.Equal(t, beans.NewAmount(143, -2), it.Amount)
-				assert.Equal(t, beans.NewTransactionNotes(":)"), it.Notes)
-				assert.Equal(t, category2.ToRelated(), it.Category)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transactions can only be reconciled by reconciling the account.")
+		})
+
+		t.Run("cannot use invalid status", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TransactionCreate(t, c.ctx, beans.TransactionCreateParams{
+				TransactionParams: beans.TransactionParams{
+					AccountID: c.Account(AccountOpts{}).ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-01"),
+					Status:    "pending",
+				},
 			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Status pending is not supported.")
 		})
 
-		t.Run("filters by budget", func(t *testing.T) {
+		t.Run("can update and keeps status when not given", func(t *testing.T) {
 			c := makeUserAndBudget(t, interactor)
-			c2 := makeUserAndBudget(t, interactor)
 
-			// make split transaction
-			parent, _ := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account})
+			params := beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-01"),
+					Status:    beans.TransactionCleared,
+				},
+			}
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
 
-			// get splits for parent, but budget 2
-			res, err := interactor.TransactionGetSplits(t, c2.ctx, parent.ID)
+			params.Status = ""
+			params.Notes = beans.NewTransactionNotes("hi")
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
 			require.NoError(t, err)
-			assert.Equal(t, 0, len(res))
+			assert.Equal(t, beans.TransactionCleared, res.Status)
 		})
 
-		t.Run("filters by transaction", func(t *testing.T) {
+		t.Run("reconciled transaction", func(t *testing.T) {
 			c := makeUserAndBudget(t, interactor)
 
-			// make split transactions
-			c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
-			parent1, _ := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
-
-			// only two splits are returned
-			res, err := interactor.TransactionGetSplits(t, c.ctx, parent1.ID)
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account, Amount: "5", Date: "2024-01-15", Status: beans.TransactionCleared})
+			_, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID: account.ID,
+				Balance:   beans.NewAmount(5, 0),
+				Date:      testutils.NewDate(t, "2024-01-31"),
+			})
 			require.NoError(t, err)
-			assert.Equal(t, 2, len(res))
+
+			params := beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(5, 0),
+					Date:      testutils.NewDate(t, "2024-01-02"),
+					Notes:     beans.NewTransactionNotes("still reconciled"),
+				},
+			}
+
+			// can change details other than the amount and account
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, params))
+			res, err := interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionReconciled, res.Status)
+
+			// amount is locked
+			params.Amount = beans.NewAmount(6, 0)
+			err = interactor.TransactionUpdate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot change the amount or account of a reconciled transaction.")
+
+			// account is locked
+			params.Amount = beans.NewAmount(5, 0)
+			params.AccountID = c.Account(AccountOpts{}).ID
+			err = interactor.TransactionUpdate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot change the amount or account of a reconciled transaction.")
+
+			// cannot be unlocked by clearing it again
+			params.AccountID = account.ID
+			params.Status = beans.TransactionCleared
+			err = interactor.TransactionUpdate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot change the status of a reconciled transaction.")
+
+			params.Amount = beans.NewAmount(6, 0)
+			err = interactor.TransactionUpdate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot change the status of a reconciled transaction.")
+
+			params.Status = beans.TransactionUncleared
+			err = interactor.TransactionUpdate(t, c.ctx, params)
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cannot change the status of a reconciled transaction.")
+
+			res, err = interactor.TransactionGet(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, beans.TransactionReconciled, res.Status)
+			assert.Equal(t, beans.NewAmount(5, 0), res.Amount)
+		})
+
+		t.Run("splits share status of parent", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			parent, _ := c.Split(SplitOpts{Account: account, Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: parent.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(3, 0),
+					Date:      parent.Date,
+					Status:    beans.TransactionCleared,
+					Splits: []beans.SplitParams{
+						{Amount: beans.NewAmount(2, 0), CategoryID: c.Category(CategoryOpts{}).ID},
+						{Amount: beans.NewAmount(1, 0), CategoryID: c.Category(CategoryOpts{}).ID},
+					},
+				},
+			}))
+
+			accounts, err := interactor.AccountList(t, c.ctx)
+			require.NoError(t, err)
+			findAccountWithBalance(t, accounts, account.ID, func(it beans.AccountWithBalance) {
+				assert.Equal(t, beans.NewAmount(3, 0), it.ClearedBalance)
+				assert.Equal(t, beans.NewAmount(0, 0), it.UnclearedBalance)
+			})
+		})
+	})
+
+	t.Run("list", func(t *testing.T) {
+
+		ids := func(page beans.TransactionPage) []beans.ID {
+			res := make([]beans.ID, len(page.Transactions))
+			for i, transaction := range page.Transactions {
+				res[i] = transaction.ID
+			}
+			return res
+		}
+
+		t.Run("filters by account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account})
+			c.Transaction(TransactionOpts{})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{AccountID: account.ID},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction.ID}, ids(page))
+		})
+
+		t.Run("filters by category including splits", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			category := c.Category(CategoryOpts{})
+			transaction := c.Transaction(TransactionOpts{Category: category, Date: "2024-01-02"})
+			parent, _ := c.Split(SplitOpts{Date: "2024-01-01", Splits: []SplitOpt{
+				{Amount: "2", Category: category},
+				{Amount: "1"},
+			}})
+			c.Transaction(TransactionOpts{})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{CategoryID: category.ID},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction.ID, parent.ID}, ids(page))
+		})
+
+		t.Run("filters by payee", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			payee := c.Payee(PayeeOpts{})
+			transaction := c.Transaction(TransactionOpts{Payee: payee})
+			c.Transaction(TransactionOpts{Payee: c.Payee(PayeeOpts{})})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{PayeeID: payee.ID},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction.ID}, ids(page))
+		})
+
+		t.Run("filters by date range", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			c.Transaction(TransactionOpts{Date: "2024-01-01"})
+			transaction1 := c.Transaction(TransactionOpts{Date: "2024-01-02"})
+			transaction2 := c.Transaction(TransactionOpts{Date: "2024-01-03"})
+			c.Transaction(TransactionOpts{Date: "2024-01-04"})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{
+					From: testutils.NewDate(t, "2024-01-02"),
+					To:   testutils.NewDate(t, "2024-01-03"),
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction2.ID, transaction1.ID}, ids(page))
+		})
+
+		t.Run("filters by amount range", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			c.Transaction(TransactionOpts{Amount: "-5.01", Date: "2024-01-01"})
+			transaction1 := c.Transaction(TransactionOpts{Amount: "-5", Date: "2024-01-02"})
+			transaction2 := c.Transaction(TransactionOpts{Amount: "3.25", Date: "2024-01-03"})
+			c.Transaction(TransactionOpts{Amount: "3.26", Date: "2024-01-04"})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{
+					MinAmount: beans.NewAmount(-5, 0),
+					MaxAmount: beans.NewAmount(325, -2),
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction2.ID, transaction1.ID}, ids(page))
+		})
+
+		t.Run("filters by variant", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			standard := c.Transaction(TransactionOpts{})
+			offBudget := c.Transaction(TransactionOpts{Account: c.Account(AccountOpts{OffBudget: true})})
+			split, _ := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+			transfer := c.Transfer(TransferOpts{
+				AccountA: c.Account(AccountOpts{}),
+				AccountB: c.Account(AccountOpts{}),
+			})
+			// on to off budget transfers are not the transfer variant
+			onOff := c.Transfer(TransferOpts{
+				AccountA: c.Account(AccountOpts{}),
+				AccountB: c.Account(AccountOpts{OffBudget: true}),
+			})
+
+			variants := map[beans.TransactionVariant][]beans.ID{
+				beans.TransactionStandard:  {standard.ID, onOff[0].ID},
+				beans.TransactionOffBudget: {offBudget.ID, onOff[1].ID},
+				beans.TransactionSplit:     {split.ID},
+				beans.TransactionTransfer:  {transfer[0].ID, transfer[1].ID},
+			}
+			for variant, expected := range variants {
+				page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+					TransactionFilter: beans.TransactionFilter{Variant: variant},
+				})
+				require.NoError(t, err)
+				assert.ElementsMatch(t, expected, ids(page), variant)
+			}
+		})
+
+		t.Run("sorts", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transaction1 := c.Transaction(TransactionOpts{Amount: "3", Date: "2024-01-01"})
+			transaction2 := c.Transaction(TransactionOpts{Amount: "-7", Date: "2024-01-02"})
+			transaction3 := c.Transaction(TransactionOpts{Amount: "1", Date: "2024-01-03"})
+
+			sorts := map[beans.TransactionSort][]beans.ID{
+				"":                              {transaction3.ID, transaction2.ID, transaction1.ID},
+				beans.TransactionSortDateDesc:   {transaction3.ID, transaction2.ID, transaction1.ID},
+				beans.TransactionSortDateAsc:    {transaction1.ID, transaction2.ID, transaction3.ID},
+				beans.TransactionSortAmountDesc: {transaction1.ID, transaction3.ID, transaction2.ID},
+				beans.TransactionSortAmountAsc:  {transaction2.ID, transaction3.ID, transaction1.ID},
+			}
+			for sort, expected := range sorts {
+				page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{Sort: sort})
+				require.NoError(t, err)
+				assert.Equal(t, expected, ids(page), sort)
+			}
+		})
+
+		t.Run("paginates", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			// transactions on the same date are ordered by id
+			expected := []beans.ID{}
+			for i := 0; i < 5; i++ {
+				expected = append(expected, c.Transaction(TransactionOpts{Amount: "-3.5", Date: "2024-01-01"}).ID)
+			}
+			slices.SortFunc(expected, func(a, b beans.ID) int { return strings.Compare(a.String(), b.String()) })
+
+			for _, sort := range []beans.TransactionSort{beans.TransactionSortDateAsc, beans.TransactionSortAmountAsc} {
+				res := []beans.ID{}
+				params := beans.TransactionListParams{Sort: sort, Limit: 2}
+				for pages := 0; ; pages++ {
+					page, err := interactor.TransactionList(t, c.ctx, params)
+					require.NoError(t, err)
+					res = append(res, ids(page)...)
+
+					if page.NextCursor.Empty() {
+						assert.Equal(t, 2, pages)
+						break
+					}
+					params.Cursor = page.NextCursor
+				}
+
+				assert.Equal(t, expected, res, sort)
+			}
+		})
+
+		t.Run("last page has no cursor", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			c.Transaction(TransactionOpts{})
+			c.Transaction(TransactionOpts{})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{Limit: 2})
+			require.NoError(t, err)
+			assert.Len(t, page.Transactions, 2)
+			assert.True(t, page.NextCursor.Empty())
+		})
+
+		t.Run("does validation", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{Limit: 1001})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Limit must be between 0 and 1000.")
+
+			_, err = interactor.TransactionList(t, c.ctx, beans.TransactionListParams{Sort: "payee"})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Sort payee is not supported.")
+
+			_, err = interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				TransactionFilter: beans.TransactionFilter{MinAmount: beans.NewAmount(1, -3)},
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Min amount must have at most 2 decimal points.")
+		})
+
+		t.Run("cursor must match sort", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			c.Transaction(TransactionOpts{})
+			c.Transaction(TransactionOpts{})
+
+			page, err := interactor.TransactionList(t, c.ctx, beans.TransactionListParams{Limit: 1})
+			require.NoError(t, err)
+			require.False(t, page.NextCursor.Empty())
+
+			_, err = interactor.TransactionList(t, c.ctx, beans.TransactionListParams{
+				Sort:   beans.TransactionSortAmountAsc,
+				Cursor: page.NextCursor,
+			})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Cursor does not match sort.")
+		})
+	})
+
+	t.Run("search", func(t *testing.T) {
+
+		ids := func(transactions []beans.TransactionWithRelations) []beans.ID {
+			res := make([]beans.ID, len(transactions))
+			for i, transaction := range transactions {
+				res[i] = transaction.ID
+			}
+			return res
+		}
+
+		search := func(c *userAndBudget, query string) []beans.ID {
+			transactions, err := interactor.TransactionSearch(t, c.ctx, beans.TransactionSearchParams{
+				Query: beans.NewNullString(query),
+			})
+			require.NoError(t, err)
+			return ids(transactions)
+		}
+
+		t.Run("finds by prefix of notes ignoring case", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transaction := c.Transaction(TransactionOpts{Notes: "Hardware store run"})
+			c.Transaction(TransactionOpts{Notes: "grocery store"})
+
+			assert.Equal(t, []beans.ID{transaction.ID}, search(c, "hard STORE"))
+		})
+
+		t.Run("finds by payee, category and account", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			payee := c.Payee(PayeeOpts{})
+			category := c.Category(CategoryOpts{})
+			account := c.Account(AccountOpts{})
+			withPayee := c.Transaction(TransactionOpts{Payee: payee})
+			withCategory := c.Transaction(TransactionOpts{Category: category})
+			withAccount := c.Transaction(TransactionOpts{Account: account})
+
+			assert.Equal(t, []beans.ID{withPayee.ID}, search(c, string(payee.Name)))
+			assert.Equal(t, []beans.ID{withCategory.ID}, search(c, string(category.Name)))
+			assert.Equal(t, []beans.ID{withAccount.ID}, search(c, string(account.Name)))
+		})
+
+		t.Run("finds split by split line", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			category := c.Category(CategoryOpts{})
+			parent, _ := c.Split(SplitOpts{Splits: []SplitOpt{
+				{Amount: "2", Category: category, Notes: "paint"},
+				{Amount: "1", Notes: "paint brush"},
+			}})
+
+			assert.Equal(t, []beans.ID{parent.ID}, search(c, "paint"))
+			assert.Equal(t, []beans.ID{parent.ID}, search(c, string(category.Name)))
+		})
+
+		t.Run("ranks by relevance", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			weak := c.Transaction(TransactionOpts{Date: "2024-01-02", Notes: "lumber and a few other things for the shed"})
+			strong := c.Transaction(TransactionOpts{Date: "2024-01-01", Notes: "lumber lumber"})
+
+			assert.Equal(t, []beans.ID{strong.ID, weak.ID}, search(c, "lumber"))
+		})
+
+		t.Run("filters by account and date range", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			account := c.Account(AccountOpts{})
+			c.Transaction(TransactionOpts{Account: account, Date: "2024-01-01", Notes: "tools"})
+			transaction := c.Transaction(TransactionOpts{Account: account, Date: "2024-01-02", Notes: "tools"})
+			c.Transaction(TransactionOpts{Account: account, Date: "2024-01-03", Notes: "tools"})
+			c.Transaction(TransactionOpts{Date: "2024-01-02", Notes: "tools"})
+
+			res, err := interactor.TransactionSearch(t, c.ctx, beans.TransactionSearchParams{
+				Query:     beans.NewNullString("tools"),
+				AccountID: account.ID,
+				From:      testutils.NewDate(t, "2024-01-02"),
+				To:        testutils.NewDate(t, "2024-01-02"),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []beans.ID{transaction.ID}, ids(res))
+		})
+
+		t.Run("can limit", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			c.Transaction(TransactionOpts{Notes: "tools"})
+			c.Transaction(TransactionOpts{Notes: "tools"})
+
+			res, err := interactor.TransactionSearch(t, c.ctx, beans.TransactionSearchParams{
+				Query: beans.NewNullString("tools"),
+				Limit: 1,
+			})
+			require.NoError(t, err)
+			assert.Len(t, res, 1)
+		})
+
+		t.Run("keeps up with changes", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transaction := c.Transaction(TransactionOpts{Notes: "before"})
+
+			err := interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: transaction.Account.ID,
+					Amount:    transaction.Amount,
+					Date:      transaction.Date,
+					Notes:     beans.NewTransactionNotes("after"),
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Empty(t, search(c, "before"))
+			assert.Equal(t, []beans.ID{transaction.ID}, search(c, "after"))
+
+			require.NoError(t, interactor.TransactionDelete(t, c.ctx, []beans.ID{transaction.ID}))
+			assert.Empty(t, search(c, "after"))
+		})
+
+		t.Run("does not find other budgets", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			c2.Transaction(TransactionOpts{Notes: "secret"})
+
+			assert.Empty(t, search(c, "secret"))
+		})
+
+		t.Run("ignores query syntax", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			transaction := c.Transaction(TransactionOpts{Notes: `say "hi" OR bye`})
+
+			assert.Equal(t, []beans.ID{transaction.ID}, search(c, `"hi* -`))
+			assert.Equal(t, []beans.ID{transaction.ID}, search(c, `"hi" OR`))
+			assert.Empty(t, search(c, "hi NOT bye"))
+		})
+
+		t.Run("requires query", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TransactionSearch(t, c.ctx, beans.TransactionSearchParams{})
+			testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Query is required.")
+		})
+	})
+
+	t.Run("get splits", func(t *testing.T) {
+
+		t.Run("can get splits", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			// make some categories
+			category1 := c.Category(CategoryOpts{})
+			category2 := c.Category(CategoryOpts{})
+
+			// make split transaction
+			parent, splits := c.Split(SplitOpts{Splits: []SplitOpt{
+				{Amount: "10.72", Category: category1, Notes: ":0)"},
+				{Amount: "1.43", Category: category2, Notes: ":)"},
+			}})
+
+			// get splits for parent
+			res, err := interactor.TransactionGetSplits(t, c.ctx, parent.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(res))
+
+			findSplit(t, res, splits[0].ID, func(it beans.Split) {
+				assert.Equal(t, beans.NewAmount(1072, -2), it.Amount)
+				assert.Equal(t, beans.NewTransactionNotes(":0)"), it.Notes)
+				assert.Equal(t, category1.ToRelated(), it.Category)
+			})
+			findSplit(t, res, splits[1].ID, func(it beans.Split) {
+				assert.Equal(t, beans.NewAmount(143, -2), it.Amount)
+				assert.Equal(t, beans.NewTransactionNotes(":)"), it.Notes)
+				assert.Equal(t, category2.ToRelated(), it.Category)
+			})
+		})
+
+		t.Run("filters by budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+
+			// make split transaction
+			parent, _ := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+
+			// get splits for parent, but budget 2
+			res, err := interactor.TransactionGetSplits(t, c2.ctx, parent.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(res))
+		})
+
+		t.Run("filters by transaction", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			// make split transactions
+			c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+			parent1, _ := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+
+			// only two splits are returned
+			res, err := interactor.TransactionGetSplits(t, c.ctx, parent1.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(res))
+		})
+	})
+
+	t.Run("history", func(t *testing.T) {
+
+		t.Run("records create", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			me, err := interactor.UserGetMe(t, c.ctx)
+			require.NoError(t, err)
+
+			account := c.Account(AccountOpts{})
+			category := c.Category(CategoryOpts{})
+			payee := c.Payee(PayeeOpts{})
+			transaction := c.Transaction(TransactionOpts{
+				Account:  account,
+				Category: category,
+				Payee:    payee,
+				Amount:   "-10.25",
+				Date:     "2022-01-02",
+				Notes:    "hi",
+			})
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+
+			assert.Equal(t, transaction.ID, history[0].TransactionID)
+			assert.True(t, history[0].SplitID.Empty())
+			assert.Equal(t, me, history[0].User)
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+			assert.WithinDuration(t, time.Now(), history[0].CreatedAt, time.Minute)
+			assert.True(t, history[0].Before.Empty())
+			assert.Equal(t, beans.OptionalWrap(beans.TransactionHistoryValues{
+				AccountID:  account.ID,
+				CategoryID: category.ID,
+				PayeeID:    payee.ID,
+				Amount:     beans.NewAmount(-1025, -2),
+				Date:       testutils.NewDate(t, "2022-01-02"),
+				Notes:      beans.NewTransactionNotes("hi"),
+				Status:     beans.TransactionUncleared,
+			}), history[0].After)
+		})
+
+		t.Run("records update with before and after", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account, Amount: "-10.25", Date: "2022-01-02"})
+
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-125, -1),
+					Date:      testutils.NewDate(t, "2022-01-02"),
+				},
+			}))
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+
+			before, ok := history[1].Before.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.NewAmount(-1025, -2), before.Amount)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.NewAmount(-125, -1), after.Amount)
+		})
+
+		t.Run("does not record update without changes", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+			transaction := c.Transaction(TransactionOpts{Account: account, Amount: "-10.25", Date: "2022-01-02"})
+
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: transaction.ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: account.ID,
+					Amount:    beans.NewAmount(-1025, -2),
+					Date:      testutils.NewDate(t, "2022-01-02"),
+				},
+			}))
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+		})
+
+		t.Run("records transactions made by reconciling", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			account := c.Account(AccountOpts{})
+
+			adjustmentID, err := interactor.AccountReconcile(t, c.ctx, beans.AccountReconcileParams{
+				AccountID:        account.ID,
+				Balance:          beans.NewAmount(5, 0),
+				Date:             testutils.NewDate(t, "2022-01-31"),
+				CreateAdjustment: true,
+			})
+			require.NoError(t, err)
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, adjustmentID)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+		})
+
+		t.Run("records bulk update", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{Notes: "a"})
+
+			require.NoError(t, interactor.TransactionBulkUpdate(t, c.ctx, beans.TransactionBulkUpdateParams{
+				IDs:   []beans.ID{transaction.ID},
+				Notes: beans.OptionalWrap(beans.NewTransactionNotes("b")),
+			}))
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.NewTransactionNotes("b"), after.Notes)
+		})
+
+		t.Run("keeps history after delete", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{Amount: "-10.25"})
+
+			require.NoError(t, interactor.TransactionDelete(t, c.ctx, []beans.ID{transaction.ID}))
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transaction.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+
+			assert.Equal(t, beans.TransactionDeleted, history[1].Action)
+			assert.True(t, history[1].After.Empty())
+			before, ok := history[1].Before.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.NewAmount(-1025, -2), before.Amount)
+		})
+
+		t.Run("includes split lines", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			parent, splits := c.Split(SplitOpts{Splits: []SplitOpt{{Amount: "2"}, {Amount: "1"}}})
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, parent.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 3)
+
+			assert.Equal(t, parent.ID, history[0].TransactionID)
+			assert.ElementsMatch(t,
+				[]beans.ID{splits[0].ID, splits[1].ID},
+				[]beans.ID{history[1].TransactionID, history[2].TransactionID})
+			assert.Equal(t, parent.ID, history[1].SplitID)
+			assert.Equal(t, parent.ID, history[2].SplitID)
+
+			history, err = interactor.TransactionGetHistory(t, c.ctx, splits[0].ID)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, splits[0].ID, history[0].TransactionID)
+		})
+
+		t.Run("records both sides of a transfer", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			accountA := c.Account(AccountOpts{})
+			accountB := c.Account(AccountOpts{})
+			transfer := c.Transfer(TransferOpts{AccountA: accountA, AccountB: accountB, Amount: "5", Date: "2022-01-02"})
+
+			require.NoError(t, interactor.TransactionUpdate(t, c.ctx, beans.TransactionUpdateParams{
+				ID: transfer[0].ID,
+				TransactionParams: beans.TransactionParams{
+					AccountID: accountA.ID,
+					Amount:    beans.NewAmount(7, 0),
+					Date:      testutils.NewDate(t, "2022-01-02"),
+				},
+			}))
+
+			history, err := interactor.TransactionGetHistory(t, c.ctx, transfer[1].ID)
+			require.NoError(t, err)
+			require.Len(t, history, 2)
+
+			assert.Equal(t, beans.TransactionCreated, history[0].Action)
+			assert.Equal(t, beans.TransactionUpdated, history[1].Action)
+			after, ok := history[1].After.Value()
+			require.True(t, ok)
+			assert.Equal(t, beans.NewAmount(-7, 0), after.Amount)
+		})
+
+		t.Run("cannot get from other budget", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+			c2 := makeUserAndBudget(t, interactor)
+			transaction := c.Transaction(TransactionOpts{})
+
+			_, err := interactor.TransactionGetHistory(t, c2.ctx, transaction.ID)
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
+		})
+
+		t.Run("cannot get non-existent", func(t *testing.T) {
+			c := makeUserAndBudget(t, interactor)
+
+			_, err := interactor.TransactionGetHistory(t, c.ctx, beans.NewID())
+			testutils.AssertErrorCode(t, err, beans.ENOTFOUND)
 		})
 	})
 }
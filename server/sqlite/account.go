@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/bradenrayhorn/beans/server/beans"
 	"zombiezen.com/go/sqlite"
@@ -13,17 +14,116 @@ var _ beans.AccountRepository = (*accountRepository)(nil)
 
 const accountCreateSQL = `
 INSERT INTO accounts
-	(id, budget_id, name, off_budget)
-	VALUES (:id, :budgetID, :name, :offBudget)
+	(id, budget_id, name, type, off_budget, payment_category_id)
+	VALUES (:id, :budgetID, :name, :type, :offBudget, :paymentCategoryID)
 `
 
-func (r *accountRepository) Create(ctx context.Context, account beans.Account) error {
-	return db[any](r.pool).execute(ctx, accountCreateSQL, map[string]any{
-		":id":        account.ID.String(),
-		":budgetID":  account.BudgetID.String(),
-		":name":      string(account.Name),
-		":offBudget": account.OffBudget,
-	})
+func (r *accountRepository) Create(ctx context.Context, tx beans.Tx, account beans.Account) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, accountCreateSQL, map[string]any{
+			":id":                account.ID.String(),
+			":budgetID":          account.BudgetID.String(),
+			":name":              string(account.Name),
+			":type":              string(account.Type),
+			":offBudget":         account.OffBudget,
+			":paymentCategoryID": serializeID(account.PaymentCategoryID),
+		})
+}
+
+const accountUpdateSQL = `
+UPDATE accounts
+	SET name = :name, off_budget = :offBudget, closed = :closed, payment_category_id = :paymentCategoryID
+	WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *accountRepository) Update(ctx context.Context, tx beans.Tx, account beans.Account) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, accountUpdateSQL, map[string]any{
+			":id":                account.ID.String(),
+			":budgetID":          account.BudgetID.String(),
+			":name":              string(account.Name),
+			":offBudget":         account.OffBudget,
+			":closed":            account.Closed,
+			":paymentCategoryID": serializeID(account.PaymentCategoryID),
+		})
+}
+
+const accountDeleteSQL = `
+DELETE FROM accounts WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *accountRepository) Delete(ctx context.Context, tx beans.Tx, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, accountDeleteSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+}
+
+// both accounts must be in the budget
+const accountMoveGuardSQL = `
+	AND EXISTS (SELECT 1 FROM accounts WHERE id = :fromID AND budget_id = :budgetID)
+	AND EXISTS (SELECT 1 FROM accounts WHERE id = :toID AND budget_id = :budgetID)
+`
+
+// import IDs already on the other account are cleared, as each import ID
+// can only be on an account once
+const accountMoveTransactionsSQL = `
+UPDATE transactions
+SET
+	account_id = :toID,
+	import_id = CASE
+		WHEN EXISTS (SELECT 1 FROM transactions existing WHERE existing.account_id = :toID AND existing.import_id = transactions.import_id) THEN NULL
+		ELSE import_id
+	END
+WHERE account_id = :fromID
+` + accountMoveGuardSQL
+
+// scheduled transfers between the two accounts would transfer to themselves
+const accountDeleteScheduledTransfersBetweenSQL = `
+DELETE FROM scheduled_transactions
+WHERE ((account_id = :fromID AND transfer_account_id = :toID) OR (account_id = :toID AND transfer_account_id = :fromID))
+` + accountMoveGuardSQL
+
+const accountMoveScheduledTransactionsSQL = `
+UPDATE scheduled_transactions SET account_id = :toID
+WHERE account_id = :fromID
+` + accountMoveGuardSQL
+
+const accountMoveScheduledTransfersSQL = `
+UPDATE scheduled_transactions SET transfer_account_id = :toID
+WHERE transfer_account_id = :fromID
+` + accountMoveGuardSQL
+
+func (r *accountRepository) MoveTransactions(ctx context.Context, tx beans.Tx, budgetID beans.ID, fromID beans.ID, toID beans.ID) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.MoveTransactions(ctx, tx, budgetID, fromID, toID)
+		})
+	}
+
+	args := map[string]any{
+		":budgetID": budgetID.String(),
+		":fromID":   fromID.String(),
+		":toID":     toID.String(),
+	}
+
+	for _, query := range []string{
+		accountMoveTransactionsSQL,
+		accountDeleteScheduledTransfersBetweenSQL,
+		accountMoveScheduledTransactionsSQL,
+		accountMoveScheduledTransfersSQL,
+	} {
+		if err := db[any](r.pool).inTx(tx).execute(ctx, query, args); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 const accountGetOneSQL = `
@@ -40,8 +140,25 @@ func (r *accountRepository) Get(ctx context.Context, budgetID beans.ID, id beans
 		})
 }
 
+const accountGetForBudgetSQL = `
+SELECT * FROM accounts
+	WHERE budget_id = :budgetID
+`
+
+func (r *accountRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.Account, error) {
+	return db[beans.Account](r.pool).
+		mapWith(mapAccount).
+		many(ctx, accountGetForBudgetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+}
+
 const accountGetWithBalance = `
-SELECT accounts.*, sum(transactions.amount) as balance
+SELECT
+	accounts.*,
+	sum(transactions.amount) as balance,
+	sum(CASE WHEN transactions.status = 'uncleared' THEN 0 ELSE transactions.amount END) as cleared_balance,
+	sum(CASE WHEN transactions.status = 'uncleared' THEN transactions.amount ELSE 0 END) as uncleared_balance
 	FROM accounts
 	LEFT JOIN transactions ON
 		accounts.id = transactions.account_id
@@ -58,9 +175,28 @@ func (r *accountRepository) GetWithBalance(ctx context.Context, budgetID beans.I
 		})
 }
 
+const accountGetBalanceSQL = `
+SELECT sum(transactions.amount) as balance
+	FROM transactions
+	JOIN accounts ON accounts.id = transactions.account_id
+	WHERE accounts.budget_id = :budgetID
+		AND accounts.id = :id
+		AND transactions.is_split = false
+`
+
+func (r *accountRepository) GetBalance(ctx context.Context, tx beans.Tx, budgetID beans.ID, id beans.ID) (beans.Amount, error) {
+	return db[beans.Amount](r.pool).
+		inTx(tx).
+		mapWith(func(stmt *sqlite.Stmt) (beans.Amount, error) { return mapAmount(stmt, "balance"), nil }).
+		one(ctx, accountGetBalanceSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+}
+
 const accountGetTransactableSQL = `
 SELECT * FROM accounts
-	WHERE budget_id = :budgetID
+	WHERE budget_id = :budgetID AND closed = false
 `
 
 func (r *accountRepository) GetTransactable(ctx context.Context, budgetID beans.ID) ([]beans.Account, error) {
@@ -71,6 +207,66 @@ func (r *accountRepository) GetTransactable(ctx context.Context, budgetID beans.
 		})
 }
 
+const accountGetBalancesSQL = `
+WITH RECURSIVE periods(date) AS (
+	SELECT date(:from, :align1, :align2)
+	UNION ALL
+	SELECT date(date, :step) FROM periods WHERE date(date, :step) <= :to
+),
+activity AS (
+	SELECT
+		transactions.account_id,
+		CASE
+			WHEN transactions.date < :from THEN date(:from, :align1, :align2)
+			ELSE date(transactions.date, :align1, :align2)
+		END as period,
+		sum(transactions.amount) as amount
+	FROM transactions
+	JOIN accounts ON accounts.id = transactions.account_id
+		AND accounts.budget_id = :budgetID
+	WHERE transactions.is_split = false AND transactions.date <= :to
+	GROUP BY transactions.account_id, period
+)
+SELECT
+	accounts.id as account_id,
+	periods.date,
+	sum(coalesce(activity.amount, 0)) OVER (PARTITION BY accounts.id ORDER BY periods.date) as balance
+FROM accounts
+CROSS JOIN periods
+LEFT JOIN activity ON activity.account_id = accounts.id
+	AND activity.period = periods.date
+WHERE accounts.budget_id = :budgetID
+	AND (:accountID IS NULL OR accounts.id = :accountID)
+ORDER BY accounts.id, periods.date
+`
+
+// Date modifiers that move a date to the start of its period, and then step
+// to the next period.
+var balanceIntervalModifiers = map[beans.BalanceInterval][3]string{
+	beans.BalanceDaily:   {"+0 days", "+0 days", "+1 day"},
+	beans.BalanceWeekly:  {"-6 days", "weekday 1", "+7 days"},
+	beans.BalanceMonthly: {"start of month", "+0 days", "+1 month"},
+}
+
+func (r *accountRepository) GetBalances(ctx context.Context, budgetID beans.ID, params beans.AccountBalanceParams) ([]beans.AccountBalance, error) {
+	modifiers, ok := balanceIntervalModifiers[params.Interval]
+	if !ok {
+		return nil, fmt.Errorf("unsupported balance interval %s", params.Interval)
+	}
+
+	return db[beans.AccountBalance](r.pool).
+		mapWith(mapAccountBalance).
+		many(ctx, accountGetBalancesSQL, map[string]any{
+			":budgetID":  budgetID.String(),
+			":accountID": serializeID(params.AccountID),
+			":from":      serializeDate(params.From),
+			":to":        serializeDate(params.To),
+			":align1":    modifiers[0],
+			":align2":    modifiers[1],
+			":step":      modifiers[2],
+		})
+}
+
 // mappers
 
 func mapAccount(stmt *sqlite.Stmt) (beans.Account, error) {
@@ -82,12 +278,36 @@ func mapAccount(stmt *sqlite.Stmt) (beans.Account, error) {
 	if err != nil {
 		return beans.Account{}, err
 	}
+	paymentCategoryID, err := mapID(stmt, "payment_category_id")
+	if err != nil {
+		return beans.Account{}, err
+	}
 
 	return beans.Account{
-		ID:        id,
-		Name:      beans.Name(stmt.GetText("name")),
-		OffBudget: stmt.GetBool("off_budget"),
-		BudgetID:  budgetID,
+		ID:                id,
+		Name:              beans.Name(stmt.GetText("name")),
+		Type:              beans.AccountType(stmt.GetText("type")),
+		OffBudget:         stmt.GetBool("off_budget"),
+		PaymentCategoryID: paymentCategoryID,
+		Closed:            stmt.GetBool("closed"),
+		BudgetID:          budgetID,
+	}, nil
+}
+
+func mapAccountBalance(stmt *sqlite.Stmt) (beans.AccountBalance, error) {
+	accountID, err := mapID(stmt, "account_id")
+	if err != nil {
+		return beans.AccountBalance{}, err
+	}
+	date, err := mapDate(stmt, "date")
+	if err != nil {
+		return beans.AccountBalance{}, err
+	}
+
+	return beans.AccountBalance{
+		AccountID: accountID,
+		Date:      date,
+		Balance:   mapAmount(stmt, "balance"),
 	}, nil
 }
 
@@ -100,5 +320,8 @@ func mapAccountWithBalance(stmt *sqlite.Stmt) (beans.AccountWithBalance, error)
 	return beans.AccountWithBalance{
 		Account: account,
 		Balance: mapAmount(stmt, "balance"),
+
+		ClearedBalance:   mapAmount(stmt, "cleared_balance"),
+		UnclearedBalance: mapAmount(stmt, "uncleared_balance"),
 	}, nil
 }
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type attachmentRepository struct{ repository }
+
+var _ beans.AttachmentRepository = (*attachmentRepository)(nil)
+
+const attachmentCreateSQL = `
+INSERT INTO attachments (id, transaction_id, file_name, mime_type, size)
+	VALUES (:id, :transactionID, :fileName, :mimeType, :size)
+`
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment beans.Attachment) error {
+	return db[any](r.pool).execute(ctx, attachmentCreateSQL, map[string]any{
+		":id":            attachment.ID.String(),
+		":transactionID": attachment.TransactionID.String(),
+		":fileName":      string(attachment.FileName),
+		":mimeType":      attachment.MimeType,
+		":size":          attachment.Size,
+	})
+}
+
+const attachmentDeleteSQL = `
+DELETE FROM attachments WHERE id IN (
+	SELECT attachments.id FROM attachments
+	JOIN transactions ON transactions.id = attachments.transaction_id
+	JOIN accounts ON accounts.id = transactions.account_id
+		AND accounts.budget_id = :budgetID
+	WHERE attachments.id = :id
+)
+`
+
+func (r *attachmentRepository) Delete(ctx context.Context, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).execute(ctx, attachmentDeleteSQL, map[string]any{
+		":budgetID": budgetID.String(),
+		":id":       id.String(),
+	})
+}
+
+const attachmentGetSQL = `
+SELECT attachments.* FROM attachments
+JOIN transactions ON transactions.id = attachments.transaction_id
+JOIN accounts ON accounts.id = transactions.account_id
+	AND accounts.budget_id = :budgetID
+WHERE attachments.id = :id
+`
+
+func (r *attachmentRepository) Get(ctx context.Context, budgetID beans.ID, id beans.ID) (beans.Attachment, error) {
+	return db[beans.Attachment](r.pool).
+		mapWith(mapAttachment).
+		one(ctx, attachmentGetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+}
+
+const attachmentGetForTransactionSQL = `
+SELECT attachments.* FROM attachments
+JOIN transactions ON transactions.id = attachments.transaction_id
+JOIN accounts ON accounts.id = transactions.account_id
+	AND accounts.budget_id = :budgetID
+WHERE attachments.transaction_id = :transactionID
+ORDER BY attachments.rowid ASC
+`
+
+func (r *attachmentRepository) GetForTransaction(ctx context.Context, budgetID beans.ID, transactionID beans.ID) ([]beans.Attachment, error) {
+	return db[beans.Attachment](r.pool).
+		mapWith(mapAttachment).
+		many(ctx, attachmentGetForTransactionSQL, map[string]any{
+			":budgetID":      budgetID.String(),
+			":transactionID": transactionID.String(),
+		})
+}
+
+func (r *attachmentRepository) GetForTransactions(ctx context.Context, budgetID beans.ID, transactionIDs []beans.ID) ([]beans.Attachment, error) {
+	ids := make([]string, len(transactionIDs))
+	for i, id := range transactionIDs {
+		ids[i] = id.String()
+	}
+
+	sql, args, err := squirrel.
+		Select("attachments.*").
+		From("attachments").
+		Join("transactions ON transactions.id = attachments.transaction_id").
+		Join("accounts ON accounts.id = transactions.account_id AND accounts.budget_id = ?", budgetID.String()).
+		Where(squirrel.Or{
+			squirrel.Eq{"transactions.id": ids},
+			squirrel.Eq{"transactions.transfer_id": ids},
+		}).
+		OrderBy("attachments.rowid ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return db[beans.Attachment](r.pool).
+		mapWith(mapAttachment).
+		manyWithArgs(ctx, sql, args)
+}
+
+func mapAttachment(stmt *sqlite.Stmt) (beans.Attachment, error) {
+	id, err := mapID(stmt, "id")
+	if err != nil {
+		return beans.Attachment{}, err
+	}
+	transactionID, err := mapID(stmt, "transaction_id")
+	if err != nil {
+		return beans.Attachment{}, err
+	}
+
+	return beans.Attachment{
+		ID:            id,
+		TransactionID: transactionID,
+		FileName:      beans.Name(stmt.GetText("file_name")),
+		MimeType:      stmt.GetText("mime_type"),
+		Size:          stmt.GetInt64("size"),
+	}, nil
+}
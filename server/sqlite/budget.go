@@ -76,6 +76,19 @@ func (r *budgetRepository) GetBudgetUserIDs(ctx context.Context, id beans.ID) ([
 		})
 }
 
+const budgetUpdateSQL = `
+UPDATE budgets SET name = :name, overspending_rollover = :overspendingRollover WHERE id = :id
+`
+
+func (r *budgetRepository) Update(ctx context.Context, budget beans.Budget) error {
+	return db[any](r.pool).
+		execute(ctx, budgetUpdateSQL, map[string]any{
+			":id":                   budget.ID.String(),
+			":name":                 string(budget.Name),
+			":overspendingRollover": string(budget.OverspendingRollover),
+		})
+}
+
 // mappers
 
 func mapBudget(stmt *sqlite.Stmt) (beans.Budget, error) {
@@ -85,7 +98,8 @@ func mapBudget(stmt *sqlite.Stmt) (beans.Budget, error) {
 	}
 
 	return beans.Budget{
-		ID:   id,
-		Name: beans.Name(stmt.GetText("name")),
+		ID:                   id,
+		Name:                 beans.Name(stmt.GetText("name")),
+		OverspendingRollover: beans.OverspendingRollover(stmt.GetText("overspending_rollover")),
 	}, nil
 }
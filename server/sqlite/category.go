@@ -11,19 +11,19 @@ type categoryRepository struct{ repository }
 var _ beans.CategoryRepository = (*categoryRepository)(nil)
 
 const categoryCreateSQL = `
-INSERT INTO categories (id, budget_id, group_id, name)
-	VALUES (:id, :budgetID, :groupID, :name)
+INSERT INTO categories (id, budget_id, group_id, name, sort_order, hidden, goal_type, goal_amount, goal_target_month)
+	VALUES (:id, :budgetID, :groupID, :name, :sortOrder, :hidden, :goalType, :goalAmount, :goalTargetMonth)
 `
 
 func (r *categoryRepository) Create(ctx context.Context, tx beans.Tx, category beans.Category) error {
+	args, err := categoryArgs(category)
+	if err != nil {
+		return err
+	}
+
 	return db[any](r.pool).
 		inTx(tx).
-		execute(ctx, categoryCreateSQL, map[string]any{
-			":id":       category.ID.String(),
-			":budgetID": category.BudgetID.String(),
-			":groupID":  category.GroupID.String(),
-			":name":     string(category.Name),
-		})
+		execute(ctx, categoryCreateSQL, args)
 }
 
 const getCategorySQL = `
@@ -39,6 +39,146 @@ func (r *categoryRepository) GetSingleForBudget(ctx context.Context, id beans.ID
 		})
 }
 
+const categoryUpdateSQL = `
+UPDATE categories SET group_id = :groupID, name = :name, sort_order = :sortOrder, hidden = :hidden,
+		goal_type = :goalType, goal_amount = :goalAmount, goal_target_month = :goalTargetMonth
+	WHERE id = :id AND budget_id = :budgetID
+`
+
+func (r *categoryRepository) Update(ctx context.Context, tx beans.Tx, category beans.Category) error {
+	args, err := categoryArgs(category)
+	if err != nil {
+		return err
+	}
+
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, categoryUpdateSQL, args)
+}
+
+func categoryArgs(category beans.Category) (map[string]any, error) {
+	args := map[string]any{
+		":id":              category.ID.String(),
+		":budgetID":        category.BudgetID.String(),
+		":groupID":         category.GroupID.String(),
+		":name":            string(category.Name),
+		":sortOrder":       category.SortOrder,
+		":hidden":          category.Hidden,
+		":goalType":        nil,
+		":goalAmount":      nil,
+		":goalTargetMonth": nil,
+	}
+
+	if goal, ok := category.Goal.Value(); ok {
+		amount, err := serializeAmount(goal.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		args[":goalType"] = string(goal.Type)
+		args[":goalAmount"] = amount
+		args[":goalTargetMonth"] = serializeDate(goal.TargetMonth.FirstDay())
+	}
+
+	return args, nil
+}
+
+const categoryDeleteSQL = `
+DELETE FROM categories WHERE id = :id AND budget_id = :budgetID
+`
+
+func (r *categoryRepository) Delete(ctx context.Context, tx beans.Tx, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, categoryDeleteSQL, map[string]any{
+			":id":       id.String(),
+			":budgetID": budgetID.String(),
+		})
+}
+
+// both categories must be in the budget
+const categoryReassignGuardSQL = `
+	AND EXISTS (SELECT 1 FROM categories WHERE id = :fromID AND budget_id = :budgetID)
+	AND EXISTS (SELECT 1 FROM categories WHERE id = :toID AND budget_id = :budgetID)
+`
+
+const categoryReassignTransactionsSQL = `
+UPDATE transactions SET category_id = :toID
+WHERE category_id = :fromID
+` + categoryReassignGuardSQL
+
+const categoryReassignScheduledTransactionsSQL = `
+UPDATE scheduled_transactions SET category_id = :toID
+WHERE category_id = :fromID
+` + categoryReassignGuardSQL
+
+const categoryReassignScheduledTransactionSplitsSQL = `
+UPDATE scheduled_transaction_splits SET category_id = :toID
+WHERE category_id = :fromID
+` + categoryReassignGuardSQL
+
+const categoryReassignRulesSQL = `
+UPDATE rules SET category_id = :toID
+WHERE category_id = :fromID
+` + categoryReassignGuardSQL
+
+const categoryReassignMergeMonthCategoriesSQL = `
+UPDATE month_categories SET amount = COALESCE(amount, 0) + COALESCE((
+	SELECT source.amount FROM month_categories source
+	WHERE source.month_id = month_categories.month_id AND source.category_id = :fromID
+), 0)
+WHERE category_id = :toID
+` + categoryReassignGuardSQL
+
+const categoryReassignMovementsFromSQL = `
+UPDATE money_movements SET from_category_id = :toID
+WHERE from_category_id = :fromID
+` + categoryReassignGuardSQL
+
+const categoryReassignMovementsToSQL = `
+UPDATE money_movements SET to_category_id = :toID
+WHERE to_category_id = :fromID
+` + categoryReassignGuardSQL
+
+// months where the replacement has no month category keep the original row
+const categoryReassignMoveMonthCategoriesSQL = `
+UPDATE month_categories SET category_id = :toID
+WHERE category_id = :fromID
+	AND month_id NOT IN (SELECT month_id FROM month_categories WHERE category_id = :toID)
+` + categoryReassignGuardSQL
+
+func (r *categoryRepository) Reassign(ctx context.Context, tx beans.Tx, budgetID beans.ID, fromID beans.ID, toID beans.ID) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Reassign(ctx, tx, budgetID, fromID, toID)
+		})
+	}
+
+	args := map[string]any{
+		":budgetID": budgetID.String(),
+		":fromID":   fromID.String(),
+		":toID":     toID.String(),
+	}
+
+	for _, query := range []string{
+		categoryReassignTransactionsSQL,
+		categoryReassignScheduledTransactionsSQL,
+		categoryReassignScheduledTransactionSplitsSQL,
+		categoryReassignRulesSQL,
+		categoryReassignMergeMonthCategoriesSQL,
+		categoryReassignMoveMonthCategoriesSQL,
+		categoryReassignMovementsFromSQL,
+		categoryReassignMovementsToSQL,
+	} {
+		if err := db[any](r.pool).inTx(tx).execute(ctx, query, args); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 const getCategoryGroupSQL = `
 SELECT * FROM category_groups WHERE id = :id AND budget_id = :budgetID
 `
@@ -52,11 +192,42 @@ func (r *categoryRepository) GetCategoryGroup(ctx context.Context, id beans.ID,
 		})
 }
 
+const categoryGroupUpdateSQL = `
+UPDATE category_groups SET name = :name, sort_order = :sortOrder, hidden = :hidden
+	WHERE id = :id AND budget_id = :budgetID
+`
+
+func (r *categoryRepository) UpdateGroup(ctx context.Context, tx beans.Tx, group beans.CategoryGroup) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, categoryGroupUpdateSQL, map[string]any{
+			":id":        group.ID.String(),
+			":budgetID":  group.BudgetID.String(),
+			":name":      string(group.Name),
+			":sortOrder": group.SortOrder,
+			":hidden":    group.Hidden,
+		})
+}
+
+const categoryGroupDeleteSQL = `
+DELETE FROM category_groups WHERE id = :id AND budget_id = :budgetID
+`
+
+func (r *categoryRepository) DeleteGroup(ctx context.Context, tx beans.Tx, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, categoryGroupDeleteSQL, map[string]any{
+			":id":       id.String(),
+			":budgetID": budgetID.String(),
+		})
+}
+
 const getCategoriesForGroupSQL = `
 SELECT categories.* FROM categories
 JOIN category_groups ON category_groups.id = categories.group_id
 	AND category_groups.id = :groupID
 	AND category_groups.budget_id = :budgetID
+ORDER BY categories.sort_order, categories.rowid
 `
 
 func (r *categoryRepository) GetCategoriesForGroup(ctx context.Context, id beans.ID, budgetID beans.ID) ([]beans.Category, error) {
@@ -70,6 +241,7 @@ func (r *categoryRepository) GetCategoriesForGroup(ctx context.Context, id beans
 
 const getCategoriesForBudgetSQL = `
 SELECT * FROM categories WHERE budget_id = :budgetID
+ORDER BY sort_order, rowid
 `
 
 func (r *categoryRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.Category, error) {
@@ -81,23 +253,27 @@ func (r *categoryRepository) GetForBudget(ctx context.Context, budgetID beans.ID
 }
 
 const categoryGroupCreateSQL = `
-INSERT INTO category_groups (id, budget_id, name, is_income)
-	VALUES (:id, :budgetID, :name, :isIncome)
+INSERT INTO category_groups (id, budget_id, name, is_income, is_credit_card_payments, sort_order, hidden)
+	VALUES (:id, :budgetID, :name, :isIncome, :isCreditCardPayments, :sortOrder, :hidden)
 `
 
 func (r *categoryRepository) CreateGroup(ctx context.Context, tx beans.Tx, category beans.CategoryGroup) error {
 	return db[any](r.pool).
 		inTx(tx).
 		execute(ctx, categoryGroupCreateSQL, map[string]any{
-			":id":       category.ID.String(),
-			":budgetID": category.BudgetID.String(),
-			":name":     string(category.Name),
-			":isIncome": category.IsIncome,
+			":id":                   category.ID.String(),
+			":budgetID":             category.BudgetID.String(),
+			":name":                 string(category.Name),
+			":isIncome":             category.IsIncome,
+			":isCreditCardPayments": category.IsCreditCardPayments,
+			":sortOrder":            category.SortOrder,
+			":hidden":               category.Hidden,
 		})
 }
 
 const getCategoryGroupsForBudgetSQL = `
 SELECT * FROM category_groups WHERE budget_id = :budgetID
+ORDER BY sort_order, rowid
 `
 
 func (r *categoryRepository) GetGroupsForBudget(ctx context.Context, budgetID beans.ID) ([]beans.CategoryGroup, error) {
@@ -124,14 +300,43 @@ func mapCategory(stmt *sqlite.Stmt) (beans.Category, error) {
 		return beans.Category{}, err
 	}
 
+	goal, err := mapCategoryGoal(stmt)
+	if err != nil {
+		return beans.Category{}, err
+	}
+
 	return beans.Category{
-		ID:       id,
-		BudgetID: budgetID,
-		GroupID:  groupID,
-		Name:     beans.Name(stmt.GetText("name")),
+		ID:        id,
+		BudgetID:  budgetID,
+		GroupID:   groupID,
+		Name:      beans.Name(stmt.GetText("name")),
+		SortOrder: int(stmt.GetInt64("sort_order")),
+		Hidden:    stmt.GetBool("hidden"),
+		Goal:      goal,
 	}, nil
 }
 
+func mapCategoryGoal(stmt *sqlite.Stmt) (beans.Optional[beans.CategoryGoal], error) {
+	if stmt.IsNull("goal_type") {
+		return beans.Optional[beans.CategoryGoal]{}, nil
+	}
+
+	targetMonth, err := mapDate(stmt, "goal_target_month")
+	if err != nil {
+		return beans.Optional[beans.CategoryGoal]{}, err
+	}
+
+	goal := beans.CategoryGoal{
+		Type:   beans.GoalType(stmt.GetText("goal_type")),
+		Amount: mapAmount(stmt, "goal_amount"),
+	}
+	if !targetMonth.Empty() {
+		goal.TargetMonth = beans.NewMonthDate(targetMonth)
+	}
+
+	return beans.OptionalWrap(goal), nil
+}
+
 func mapCategoryGroup(stmt *sqlite.Stmt) (beans.CategoryGroup, error) {
 	id, err := mapID(stmt, "id")
 	if err != nil {
@@ -143,9 +348,12 @@ func mapCategoryGroup(stmt *sqlite.Stmt) (beans.CategoryGroup, error) {
 	}
 
 	return beans.CategoryGroup{
-		ID:       id,
-		BudgetID: budgetID,
-		IsIncome: stmt.GetBool("is_income"),
-		Name:     beans.Name(stmt.GetText("name")),
+		ID:                   id,
+		BudgetID:             budgetID,
+		IsIncome:             stmt.GetBool("is_income"),
+		Name:                 beans.Name(stmt.GetText("name")),
+		IsCreditCardPayments: stmt.GetBool("is_credit_card_payments"),
+		SortOrder:            int(stmt.GetInt64("sort_order")),
+		Hidden:               stmt.GetBool("hidden"),
 	}, nil
 }
@@ -6,12 +6,18 @@ var _ beans.DataSource = (*datasource)(nil)
 
 type datasource struct {
 	accountRepository       beans.AccountRepository
+	attachmentRepository    beans.AttachmentRepository
 	budgetRepository        beans.BudgetRepository
 	categoryRepository      beans.CategoryRepository
 	monthRepository         beans.MonthRepository
 	monthCategoryRepository beans.MonthCategoryRepository
+	monthSnapshotRepository beans.MonthSnapshotRepository
 	payeeRepository         beans.PayeeRepository
+	ruleRepository          beans.RuleRepository
+	scheduledRepository     beans.ScheduledTransactionRepository
+	tagRepository           beans.TagRepository
 	transactionRepository   beans.TransactionRepository
+	historyRepository       beans.TransactionHistoryRepository
 	userRepository          beans.UserRepository
 
 	txManager beans.TxManager
@@ -21,6 +27,10 @@ func (ds *datasource) AccountRepository() beans.AccountRepository {
 	return ds.accountRepository
 }
 
+func (ds *datasource) AttachmentRepository() beans.AttachmentRepository {
+	return ds.attachmentRepository
+}
+
 func (ds *datasource) BudgetRepository() beans.BudgetRepository {
 	return ds.budgetRepository
 }
@@ -37,14 +47,34 @@ func (ds *datasource) MonthCategoryRepository() beans.MonthCategoryRepository {
 	return ds.monthCategoryRepository
 }
 
+func (ds *datasource) MonthSnapshotRepository() beans.MonthSnapshotRepository {
+	return ds.monthSnapshotRepository
+}
+
 func (ds *datasource) PayeeRepository() beans.PayeeRepository {
 	return ds.payeeRepository
 }
 
+func (ds *datasource) RuleRepository() beans.RuleRepository {
+	return ds.ruleRepository
+}
+
+func (ds *datasource) ScheduledTransactionRepository() beans.ScheduledTransactionRepository {
+	return ds.scheduledRepository
+}
+
+func (ds *datasource) TagRepository() beans.TagRepository {
+	return ds.tagRepository
+}
+
 func (ds *datasource) TransactionRepository() beans.TransactionRepository {
 	return ds.transactionRepository
 }
 
+func (ds *datasource) TransactionHistoryRepository() beans.TransactionHistoryRepository {
+	return ds.historyRepository
+}
+
 func (ds *datasource) UserRepository() beans.UserRepository {
 	return ds.userRepository
 }
@@ -56,12 +86,18 @@ func (ds *datasource) TxManager() beans.TxManager {
 func NewDataSource(pool *Pool) *datasource {
 	return &datasource{
 		accountRepository:       &accountRepository{repository{pool}},
+		attachmentRepository:    &attachmentRepository{repository{pool}},
 		budgetRepository:        &budgetRepository{repository{pool}},
 		categoryRepository:      &categoryRepository{repository{pool}},
 		monthRepository:         &monthRepository{repository{pool}},
 		monthCategoryRepository: &monthCategoryRepository{repository{pool}},
+		monthSnapshotRepository: &monthSnapshotRepository{repository{pool}},
 		payeeRepository:         &payeeRepository{repository{pool}},
+		ruleRepository:          &ruleRepository{repository{pool}},
+		scheduledRepository:     &scheduledTransactionRepository{repository{pool}},
+		tagRepository:           &tagRepository{repository{pool}},
 		transactionRepository:   &TransactionRepository{repository{pool}},
+		historyRepository:       &transactionHistoryRepository{repository{pool}},
 		userRepository:          &userRepository{repository{pool}},
 
 		txManager: &txManager{pool},
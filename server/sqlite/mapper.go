@@ -75,3 +75,17 @@ func serializeAmount(amount beans.Amount) (int64, error) {
 func mapAmount(stmt *sqlite.Stmt, col string) beans.Amount {
 	return beans.NewAmount(stmt.GetInt64(col), -2).Normalize()
 }
+
+func serializeNullAmount(amount beans.Amount) (any, error) {
+	if amount.Empty() {
+		return nil, nil
+	}
+	return serializeAmount(amount)
+}
+
+func mapNullAmount(stmt *sqlite.Stmt, col string) beans.Amount {
+	if stmt.IsNull(col) {
+		return beans.Amount{}
+	}
+	return mapAmount(stmt, col)
+}
@@ -89,4 +89,265 @@ var migrations = []string{
 		FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE,
 		UNIQUE (month_id, category_id)
 	);`,
+	`ALTER TABLE transactions ADD COLUMN import_id VARCHAR(255);`,
+	`CREATE UNIQUE INDEX transactions_account_import_id ON transactions (account_id, import_id);`,
+	`ALTER TABLE transactions ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'uncleared';`,
+	`CREATE TABLE scheduled_transactions (
+		id CHAR(27) PRIMARY KEY,
+		budget_id CHAR(27) NOT NULL,
+		account_id CHAR(27) NOT NULL,
+		category_id CHAR(27),
+		payee_id CHAR(27),
+		transfer_account_id CHAR(27),
+		amount INTEGER NOT NULL,
+		notes VARCHAR(255),
+		frequency VARCHAR(32) NOT NULL,
+		interval INTEGER NOT NULL,
+		start_date DATE NOT NULL,
+		next_date DATE NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE,
+		FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+		FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE,
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE CASCADE,
+		FOREIGN KEY (transfer_account_id) REFERENCES accounts (id) ON DELETE CASCADE
+	);`,
+	`CREATE TABLE scheduled_transaction_splits (
+		scheduled_transaction_id CHAR(27) NOT NULL,
+		position INTEGER NOT NULL,
+		category_id CHAR(27) NOT NULL,
+		amount INTEGER NOT NULL,
+		notes VARCHAR(255),
+		PRIMARY KEY (scheduled_transaction_id, position),
+		FOREIGN KEY (scheduled_transaction_id) REFERENCES scheduled_transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+	);`,
+	`CREATE TABLE rules (
+		id CHAR(27) PRIMARY KEY,
+		budget_id CHAR(27) NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		payee_contains VARCHAR(255),
+		min_amount INTEGER,
+		max_amount INTEGER,
+		payee_id CHAR(27),
+		category_id CHAR(27),
+		append_notes VARCHAR(255),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE,
+		FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE CASCADE,
+		FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+	);`,
+	`CREATE VIRTUAL TABLE transaction_search USING fts5(
+		notes,
+		payee_name,
+		category_name,
+		account_name,
+		tokenize = 'unicode61 remove_diacritics 2'
+	);`,
+	`INSERT INTO transaction_search (rowid, notes, payee_name, category_name, account_name)
+		SELECT transactions.rowid, transactions.notes, payees.name, categories.name, accounts.name
+		FROM transactions
+		JOIN accounts ON accounts.id = transactions.account_id
+		LEFT JOIN payees ON payees.id = transactions.payee_id
+		LEFT JOIN categories ON categories.id = transactions.category_id;`,
+	`CREATE TRIGGER transactions_search_insert AFTER INSERT ON transactions BEGIN
+		INSERT INTO transaction_search (rowid, notes, payee_name, category_name, account_name) VALUES (
+			NEW.rowid,
+			NEW.notes,
+			(SELECT name FROM payees WHERE id = NEW.payee_id),
+			(SELECT name FROM categories WHERE id = NEW.category_id),
+			(SELECT name FROM accounts WHERE id = NEW.account_id)
+		);
+	END;`,
+	`CREATE TRIGGER transactions_search_update AFTER UPDATE OF notes, payee_id, category_id, account_id ON transactions BEGIN
+		DELETE FROM transaction_search WHERE rowid = OLD.rowid;
+		INSERT INTO transaction_search (rowid, notes, payee_name, category_name, account_name) VALUES (
+			NEW.rowid,
+			NEW.notes,
+			(SELECT name FROM payees WHERE id = NEW.payee_id),
+			(SELECT name FROM categories WHERE id = NEW.category_id),
+			(SELECT name FROM accounts WHERE id = NEW.account_id)
+		);
+	END;`,
+	`CREATE TRIGGER transactions_search_delete AFTER DELETE ON transactions BEGIN
+		DELETE FROM transaction_search WHERE rowid = OLD.rowid;
+	END;`,
+	`CREATE TRIGGER payees_search_update AFTER UPDATE OF name ON payees BEGIN
+		UPDATE transaction_search SET payee_name = NEW.name
+		WHERE rowid IN (SELECT rowid FROM transactions WHERE payee_id = NEW.id);
+	END;`,
+	`CREATE TRIGGER categories_search_update AFTER UPDATE OF name ON categories BEGIN
+		UPDATE transaction_search SET category_name = NEW.name
+		WHERE rowid IN (SELECT rowid FROM transactions WHERE category_id = NEW.id);
+	END;`,
+	`CREATE TRIGGER accounts_search_update AFTER UPDATE OF name ON accounts BEGIN
+		UPDATE transaction_search SET account_name = NEW.name
+		WHERE rowid IN (SELECT rowid FROM transactions WHERE account_id = NEW.id);
+	END;`,
+	`CREATE TABLE attachments (
+		id CHAR(27) PRIMARY KEY,
+		transaction_id CHAR(27) NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		mime_type VARCHAR(255) NOT NULL,
+		size INTEGER NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE
+	);`,
+	`CREATE INDEX attachments_transaction_id ON attachments (transaction_id);`,
+	`CREATE TABLE tags (
+		id CHAR(27) PRIMARY KEY,
+		budget_id CHAR(27) NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE
+	);`,
+	`CREATE TABLE transaction_tags (
+		transaction_id CHAR(27) NOT NULL,
+		tag_id CHAR(27) NOT NULL,
+		PRIMARY KEY (transaction_id, tag_id),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+	);`,
+	`CREATE INDEX transaction_tags_tag_id ON transaction_tags (tag_id);`,
+	`CREATE TABLE transaction_history (
+		id CHAR(27) PRIMARY KEY,
+		budget_id CHAR(27) NOT NULL,
+		transaction_id CHAR(27) NOT NULL,
+		split_id CHAR(27),
+		user_id CHAR(27) NOT NULL,
+		action VARCHAR(16) NOT NULL,
+		before TEXT,
+		after TEXT,
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id)
+	);`,
+	`CREATE INDEX transaction_history_transaction_id ON transaction_history (transaction_id);`,
+	`CREATE INDEX transaction_history_split_id ON transaction_history (split_id);`,
+	`CREATE TRIGGER transaction_history_immutable BEFORE UPDATE ON transaction_history BEGIN
+		SELECT RAISE(ABORT, 'transaction history cannot be changed');
+	END;`,
+	`ALTER TABLE accounts ADD COLUMN closed BOOLEAN NOT NULL DEFAULT false;`,
+	`ALTER TABLE accounts ADD COLUMN type VARCHAR(32) NOT NULL DEFAULT 'checking';`,
+	`ALTER TABLE accounts ADD COLUMN payment_category_id CHAR(27) REFERENCES categories (id) ON DELETE SET NULL;`,
+	`ALTER TABLE category_groups ADD COLUMN is_credit_card_payments BOOLEAN NOT NULL DEFAULT false;`,
+	`ALTER TABLE category_groups ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;`,
+	`ALTER TABLE category_groups ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;`,
+	`ALTER TABLE categories ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;`,
+	`ALTER TABLE categories ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;`,
+	`ALTER TABLE categories ADD COLUMN goal_type VARCHAR(32);`,
+	`ALTER TABLE categories ADD COLUMN goal_amount INTEGER;`,
+	`ALTER TABLE categories ADD COLUMN goal_target_month DATE;`,
+	`CREATE TABLE money_movements (
+		id CHAR(27) PRIMARY KEY,
+		month_id CHAR(27) NOT NULL,
+		from_category_id CHAR(27),
+		to_category_id CHAR(27),
+		amount INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (month_id) REFERENCES months (id) ON DELETE CASCADE,
+		FOREIGN KEY (from_category_id) REFERENCES categories (id) ON DELETE CASCADE,
+		FOREIGN KEY (to_category_id) REFERENCES categories (id) ON DELETE CASCADE
+	);`,
+	`CREATE INDEX money_movements_month_id ON money_movements (month_id);`,
+	`ALTER TABLE budgets ADD COLUMN overspending_rollover VARCHAR(32) NOT NULL DEFAULT 'carry_forward';`,
+	`ALTER TABLE budgets ADD COLUMN snapshot_version INTEGER NOT NULL DEFAULT 0;`,
+	`CREATE TABLE month_snapshots (
+		budget_id CHAR(27) NOT NULL,
+		month_date DATE NOT NULL,
+		overspent INTEGER NOT NULL,
+		PRIMARY KEY (budget_id, month_date),
+		FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE
+	);`,
+	`CREATE TABLE month_category_snapshots (
+		budget_id CHAR(27) NOT NULL,
+		month_date DATE NOT NULL,
+		category_id CHAR(27) NOT NULL,
+		carried_in INTEGER NOT NULL,
+		assigned INTEGER NOT NULL,
+		activity INTEGER NOT NULL,
+		PRIMARY KEY (budget_id, month_date, category_id),
+		FOREIGN KEY (budget_id, month_date) REFERENCES month_snapshots (budget_id, month_date) ON DELETE CASCADE,
+		FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+	);`,
+	`CREATE TRIGGER transactions_snapshot_insert AFTER INSERT ON transactions BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM accounts WHERE id = NEW.account_id)
+			AND month_date >= date(NEW.date, 'start of month');
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM accounts WHERE id = NEW.account_id);
+	END;`,
+	`CREATE TRIGGER transactions_snapshot_update AFTER UPDATE OF account_id, category_id, transfer_id, date, amount ON transactions BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM accounts WHERE id = OLD.account_id)
+			AND month_date >= min(date(OLD.date, 'start of month'), date(NEW.date, 'start of month'));
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM accounts WHERE id = OLD.account_id);
+	END;`,
+	`CREATE TRIGGER transactions_snapshot_delete AFTER DELETE ON transactions BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM accounts WHERE id = OLD.account_id)
+			AND month_date >= date(OLD.date, 'start of month');
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM accounts WHERE id = OLD.account_id);
+	END;`,
+	`CREATE TRIGGER month_categories_snapshot_insert AFTER INSERT ON month_categories WHEN NEW.amount != 0 BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM months WHERE id = NEW.month_id)
+			AND month_date >= (SELECT date FROM months WHERE id = NEW.month_id);
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM months WHERE id = NEW.month_id);
+	END;`,
+	`CREATE TRIGGER month_categories_snapshot_update AFTER UPDATE OF category_id, amount ON month_categories BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM months WHERE id = OLD.month_id)
+			AND month_date >= (SELECT date FROM months WHERE id = OLD.month_id);
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM months WHERE id = OLD.month_id);
+	END;`,
+	`CREATE TRIGGER month_categories_snapshot_delete AFTER DELETE ON month_categories BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = (SELECT budget_id FROM months WHERE id = OLD.month_id)
+			AND month_date >= (SELECT date FROM months WHERE id = OLD.month_id);
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = (SELECT budget_id FROM months WHERE id = OLD.month_id);
+	END;`,
+	`CREATE TRIGGER months_snapshot_insert AFTER INSERT ON months BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = NEW.budget_id
+			AND month_date >= NEW.date;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = NEW.budget_id;
+	END;`,
+	`CREATE TRIGGER accounts_snapshot_update AFTER UPDATE OF budget_id, off_budget, payment_category_id ON accounts BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = OLD.budget_id;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = OLD.budget_id;
+	END;`,
+	`CREATE TRIGGER accounts_snapshot_delete AFTER DELETE ON accounts BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = OLD.budget_id;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = OLD.budget_id;
+	END;`,
+	`CREATE TRIGGER categories_snapshot_update AFTER UPDATE OF group_id ON categories BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = OLD.budget_id;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = OLD.budget_id;
+	END;`,
+	`CREATE TRIGGER categories_snapshot_delete AFTER DELETE ON categories BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = OLD.budget_id;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = OLD.budget_id;
+	END;`,
+	`CREATE TRIGGER budgets_snapshot_update AFTER UPDATE OF overspending_rollover ON budgets BEGIN
+		DELETE FROM month_snapshots
+			WHERE budget_id = OLD.id;
+		UPDATE budgets SET snapshot_version = snapshot_version + 1
+			WHERE id = OLD.id;
+	END;`,
+	`ALTER TABLE transactions ADD COLUMN import_payee VARCHAR(255);`,
 }
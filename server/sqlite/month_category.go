@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -41,13 +42,14 @@ const monthCategoryUpdateAmountSQL = `
 UPDATE month_categories SET amount = :amount WHERE id = :id
 `
 
-func (r *monthCategoryRepository) UpdateAmount(ctx context.Context, monthCategory beans.MonthCategory) error {
+func (r *monthCategoryRepository) UpdateAmount(ctx context.Context, tx beans.Tx, monthCategory beans.MonthCategory) error {
 	amount, err := serializeAmount(monthCategory.Amount)
 	if err != nil {
 		return err
 	}
 
 	return db[any](r.pool).
+		inTx(tx).
 		execute(ctx, monthCategoryUpdateAmountSQL, map[string]any{
 			":id":     monthCategory.ID.String(),
 			":amount": amount,
@@ -141,8 +143,66 @@ func (r *monthCategoryRepository) GetAssignedInMonth(ctx context.Context, month
 		})
 }
 
+const monthCategoryCreateMovementSQL = `
+INSERT INTO money_movements (id, month_id, from_category_id, to_category_id, amount, created_at)
+	VALUES (:id, :monthID, :fromCategoryID, :toCategoryID, :amount, :createdAt)
+`
+
+func (r *monthCategoryRepository) CreateMovement(ctx context.Context, tx beans.Tx, movement beans.MoneyMovement) error {
+	amount, err := serializeAmount(movement.Amount)
+	if err != nil {
+		return err
+	}
+
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, monthCategoryCreateMovementSQL, map[string]any{
+			":id":             movement.ID.String(),
+			":monthID":        movement.MonthID.String(),
+			":fromCategoryID": serializeID(movement.FromCategoryID),
+			":toCategoryID":   serializeID(movement.ToCategoryID),
+			":amount":         amount,
+			":createdAt":      movement.CreatedAt.UTC().Format(time.RFC3339Nano),
+		})
+}
+
+const monthCategoryGetMovementsForMonthSQL = `
+SELECT * FROM money_movements WHERE month_id = :monthID ORDER BY rowid ASC
+`
+
+func (r *monthCategoryRepository) GetMovementsForMonth(ctx context.Context, month beans.Month) ([]beans.MoneyMovement, error) {
+	return db[beans.MoneyMovement](r.pool).
+		mapWith(mapMoneyMovement).
+		many(ctx, monthCategoryGetMovementsForMonthSQL, map[string]any{
+			":monthID": month.ID.String(),
+		})
+}
+
 // mappers
 
+func mapMoneyMovement(stmt *sqlite.Stmt) (beans.MoneyMovement, error) {
+	var err error
+	m := beans.MoneyMovement{Amount: mapAmount(stmt, "amount")}
+
+	if m.ID, err = mapID(stmt, "id"); err != nil {
+		return m, err
+	}
+	if m.MonthID, err = mapID(stmt, "month_id"); err != nil {
+		return m, err
+	}
+	if m.FromCategoryID, err = mapID(stmt, "from_category_id"); err != nil {
+		return m, err
+	}
+	if m.ToCategoryID, err = mapID(stmt, "to_category_id"); err != nil {
+		return m, err
+	}
+	if m.CreatedAt, err = time.Parse(time.RFC3339Nano, stmt.GetText("created_at")); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
 func mapMonthCategory(stmt *sqlite.Stmt) (beans.MonthCategory, error) {
 	id, err := mapID(stmt, "id")
 	if err != nil {
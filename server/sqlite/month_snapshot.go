@@ -0,0 +1,237 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type monthSnapshotRepository struct{ repository }
+
+var _ beans.MonthSnapshotRepository = (*monthSnapshotRepository)(nil)
+
+const monthSnapshotGetVersionSQL = `
+SELECT snapshot_version FROM budgets WHERE id = :budgetID
+`
+
+func (r *monthSnapshotRepository) GetVersion(ctx context.Context, budgetID beans.ID) (int64, error) {
+	return r.getVersion(ctx, nil, budgetID)
+}
+
+func (r *monthSnapshotRepository) getVersion(ctx context.Context, tx beans.Tx, budgetID beans.ID) (int64, error) {
+	return db[int64](r.pool).
+		inTx(tx).
+		mapWith(func(stmt *sqlite.Stmt) (int64, error) { return stmt.GetInt64("snapshot_version"), nil }).
+		one(ctx, monthSnapshotGetVersionSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+}
+
+const monthSnapshotDeleteSQL = `
+DELETE FROM month_snapshots WHERE budget_id = :budgetID AND month_date = :date
+`
+
+const monthSnapshotCreateSQL = `
+INSERT INTO month_snapshots (budget_id, month_date, overspent) VALUES (:budgetID, :date, :overspent)
+`
+
+const monthSnapshotCreateCategorySQL = `
+INSERT INTO month_category_snapshots (budget_id, month_date, category_id, carried_in, assigned, activity)
+	VALUES (:budgetID, :date, :categoryID, :carriedIn, :assigned, :activity)
+`
+
+func (r *monthSnapshotRepository) Create(ctx context.Context, tx beans.Tx, budgetID beans.ID, version int64, snapshots []beans.MonthSnapshot) error {
+	// snapshots built before something changed are already out of date
+	current, err := r.getVersion(ctx, tx, budgetID)
+	if err != nil {
+		return err
+	}
+	if current != version {
+		return nil
+	}
+
+	for _, snapshot := range snapshots {
+		overspent, err := serializeAmount(snapshot.Overspent)
+		if err != nil {
+			return err
+		}
+
+		err = db[any](r.pool).
+			inTx(tx).
+			execute(ctx, monthSnapshotDeleteSQL, map[string]any{
+				":budgetID": budgetID.String(),
+				":date":     serializeDate(snapshot.Date.FirstDay()),
+			})
+		if err != nil {
+			return err
+		}
+
+		err = db[any](r.pool).
+			inTx(tx).
+			execute(ctx, monthSnapshotCreateSQL, map[string]any{
+				":budgetID":  budgetID.String(),
+				":date":      serializeDate(snapshot.Date.FirstDay()),
+				":overspent": overspent,
+			})
+		if err != nil {
+			return err
+		}
+
+		for categoryID, category := range snapshot.Categories {
+			carriedIn, err := serializeAmount(category.CarriedIn)
+			if err != nil {
+				return err
+			}
+			assigned, err := serializeAmount(category.Assigned)
+			if err != nil {
+				return err
+			}
+			activity, err := serializeAmount(category.Activity)
+			if err != nil {
+				return err
+			}
+
+			err = db[any](r.pool).
+				inTx(tx).
+				execute(ctx, monthSnapshotCreateCategorySQL, map[string]any{
+					":budgetID":   budgetID.String(),
+					":date":       serializeDate(snapshot.Date.FirstDay()),
+					":categoryID": categoryID.String(),
+					":carriedIn":  carriedIn,
+					":assigned":   assigned,
+					":activity":   activity,
+				})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+const monthSnapshotGetSQL = `
+SELECT * FROM month_snapshots WHERE budget_id = :budgetID AND month_date = :date
+`
+
+func (r *monthSnapshotRepository) Get(ctx context.Context, budgetID beans.ID, date beans.MonthDate) (beans.MonthSnapshot, error) {
+	snapshot, err := db[beans.MonthSnapshot](r.pool).
+		mapWith(mapMonthSnapshot).
+		one(ctx, monthSnapshotGetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":date":     serializeDate(date.FirstDay()),
+		})
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	return r.withCategories(ctx, snapshot)
+}
+
+const monthSnapshotGetLatestBeforeSQL = `
+SELECT * FROM month_snapshots
+	WHERE budget_id = :budgetID AND month_date < :date
+	ORDER BY month_date DESC
+	LIMIT 1
+`
+
+func (r *monthSnapshotRepository) GetLatestBefore(ctx context.Context, budgetID beans.ID, date beans.MonthDate) (beans.MonthSnapshot, error) {
+	snapshot, err := db[beans.MonthSnapshot](r.pool).
+		mapWith(mapMonthSnapshot).
+		one(ctx, monthSnapshotGetLatestBeforeSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":date":     serializeDate(date.FirstDay()),
+		})
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	return r.withCategories(ctx, snapshot)
+}
+
+const monthSnapshotGetForBudgetSQL = `
+SELECT * FROM month_snapshots WHERE budget_id = :budgetID ORDER BY month_date ASC
+`
+
+func (r *monthSnapshotRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.MonthSnapshot, error) {
+	snapshots, err := db[beans.MonthSnapshot](r.pool).
+		mapWith(mapMonthSnapshot).
+		many(ctx, monthSnapshotGetForBudgetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, snapshot := range snapshots {
+		if snapshots[i], err = r.withCategories(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshots, nil
+}
+
+const monthSnapshotGetCategoriesSQL = `
+SELECT * FROM month_category_snapshots WHERE budget_id = :budgetID AND month_date = :date
+`
+
+type monthCategorySnapshotRow struct {
+	CategoryID beans.ID
+	Snapshot   beans.MonthCategorySnapshot
+}
+
+func (r *monthSnapshotRepository) withCategories(ctx context.Context, snapshot beans.MonthSnapshot) (beans.MonthSnapshot, error) {
+	rows, err := db[monthCategorySnapshotRow](r.pool).
+		mapWith(mapMonthCategorySnapshotRow).
+		many(ctx, monthSnapshotGetCategoriesSQL, map[string]any{
+			":budgetID": snapshot.BudgetID.String(),
+			":date":     serializeDate(snapshot.Date.FirstDay()),
+		})
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	snapshot.Categories = make(map[beans.ID]beans.MonthCategorySnapshot, len(rows))
+	for _, row := range rows {
+		snapshot.Categories[row.CategoryID] = row.Snapshot
+	}
+
+	return snapshot, nil
+}
+
+// mappers
+
+func mapMonthSnapshot(stmt *sqlite.Stmt) (beans.MonthSnapshot, error) {
+	budgetID, err := mapID(stmt, "budget_id")
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+	date, err := mapDate(stmt, "month_date")
+	if err != nil {
+		return beans.MonthSnapshot{}, err
+	}
+
+	return beans.MonthSnapshot{
+		BudgetID:  budgetID,
+		Date:      beans.NewMonthDate(date),
+		Overspent: mapAmount(stmt, "overspent"),
+	}, nil
+}
+
+func mapMonthCategorySnapshotRow(stmt *sqlite.Stmt) (monthCategorySnapshotRow, error) {
+	categoryID, err := mapID(stmt, "category_id")
+	if err != nil {
+		return monthCategorySnapshotRow{}, err
+	}
+
+	return monthCategorySnapshotRow{
+		CategoryID: categoryID,
+		Snapshot: beans.MonthCategorySnapshot{
+			CarriedIn: mapAmount(stmt, "carried_in"),
+			Assigned:  mapAmount(stmt, "assigned"),
+			Activity:  mapAmount(stmt, "activity"),
+		},
+	}, nil
+}
@@ -15,8 +15,9 @@ const payeeCreateSQL = `
 INSERT INTO payees (id, budget_id, name) VALUES (:id, :budgetID, :name)
 `
 
-func (r *payeeRepository) Create(ctx context.Context, payee beans.Payee) error {
+func (r *payeeRepository) Create(ctx context.Context, tx beans.Tx, payee beans.Payee) error {
 	return db[any](r.pool).
+		inTx(tx).
 		execute(ctx, payeeCreateSQL, map[string]any{
 			":id":       payee.ID.String(),
 			":budgetID": payee.BudgetID.String(),
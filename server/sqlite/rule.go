@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type ruleRepository struct{ repository }
+
+var _ beans.RuleRepository = (*ruleRepository)(nil)
+
+const ruleCreateSQL = `
+INSERT INTO rules
+	(id, budget_id, name, payee_contains, min_amount, max_amount, payee_id, category_id, append_notes)
+	VALUES (:id, :budgetID, :name, :payeeContains, :minAmount, :maxAmount, :payeeID, :categoryID, :appendNotes)
+`
+
+func (r *ruleRepository) Create(ctx context.Context, rule beans.Rule) error {
+	args, err := ruleArgs(rule)
+	if err != nil {
+		return err
+	}
+
+	return db[any](r.pool).execute(ctx, ruleCreateSQL, args)
+}
+
+const ruleUpdateSQL = `
+UPDATE rules
+	SET name=:name, payee_contains=:payeeContains, min_amount=:minAmount, max_amount=:maxAmount,
+		payee_id=:payeeID, category_id=:categoryID, append_notes=:appendNotes
+	WHERE budget_id=:budgetID AND id=:id
+`
+
+func (r *ruleRepository) Update(ctx context.Context, rule beans.Rule) error {
+	args, err := ruleArgs(rule)
+	if err != nil {
+		return err
+	}
+
+	return db[any](r.pool).execute(ctx, ruleUpdateSQL, args)
+}
+
+const ruleDeleteSQL = `
+DELETE FROM rules WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *ruleRepository) Delete(ctx context.Context, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).execute(ctx, ruleDeleteSQL, map[string]any{
+		":budgetID": budgetID.String(),
+		":id":       id.String(),
+	})
+}
+
+const ruleGetSQL = `
+SELECT * FROM rules WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *ruleRepository) Get(ctx context.Context, budgetID beans.ID, id beans.ID) (beans.Rule, error) {
+	return db[beans.Rule](r.pool).
+		mapWith(mapRule).
+		one(ctx, ruleGetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+}
+
+// Rules run in the order they were inserted.
+const ruleGetForBudgetSQL = `
+SELECT * FROM rules WHERE budget_id = :budgetID
+ORDER BY rowid ASC
+`
+
+func (r *ruleRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.Rule, error) {
+	return db[beans.Rule](r.pool).
+		mapWith(mapRule).
+		many(ctx, ruleGetForBudgetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+}
+
+func ruleArgs(rule beans.Rule) (map[string]any, error) {
+	minAmount, err := serializeNullAmount(rule.MinAmount)
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := serializeNullAmount(rule.MaxAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		":id":            rule.ID.String(),
+		":budgetID":      rule.BudgetID.String(),
+		":name":          string(rule.Name),
+		":payeeContains": serializeNullString(rule.PayeeContains),
+		":minAmount":     minAmount,
+		":maxAmount":     maxAmount,
+		":payeeID":       serializeID(rule.PayeeID),
+		":categoryID":    serializeID(rule.CategoryID),
+		":appendNotes":   serializeNullString(rule.AppendNotes.NullString),
+	}, nil
+}
+
+func mapRule(stmt *sqlite.Stmt) (beans.Rule, error) {
+	id, err := mapID(stmt, "id")
+	if err != nil {
+		return beans.Rule{}, err
+	}
+	budgetID, err := mapID(stmt, "budget_id")
+	if err != nil {
+		return beans.Rule{}, err
+	}
+	payeeID, err := mapID(stmt, "payee_id")
+	if err != nil {
+		return beans.Rule{}, err
+	}
+	categoryID, err := mapID(stmt, "category_id")
+	if err != nil {
+		return beans.Rule{}, err
+	}
+
+	return beans.Rule{
+		ID:       id,
+		BudgetID: budgetID,
+		Name:     beans.Name(stmt.GetText("name")),
+		RuleConditions: beans.RuleConditions{
+			PayeeContains: mapNullString(stmt, "payee_contains"),
+			MinAmount:     mapNullAmount(stmt, "min_amount"),
+			MaxAmount:     mapNullAmount(stmt, "max_amount"),
+		},
+		RuleActions: beans.RuleActions{
+			PayeeID:     payeeID,
+			CategoryID:  categoryID,
+			AppendNotes: beans.TransactionNotes{NullString: mapNullString(stmt, "append_notes")},
+		},
+	}, nil
+}
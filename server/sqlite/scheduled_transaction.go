@@ -0,0 +1,330 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type scheduledTransactionRepository struct{ repository }
+
+var _ beans.ScheduledTransactionRepository = (*scheduledTransactionRepository)(nil)
+
+const scheduledTransactionCreateSQL = `
+INSERT INTO scheduled_transactions
+	(id, budget_id, account_id, category_id, payee_id, transfer_account_id, amount, notes, frequency, interval, start_date, next_date)
+	VALUES (:id, :budgetID, :accountID, :categoryID, :payeeID, :transferAccountID, :amount, :notes, :frequency, :interval, :startDate, :nextDate)
+`
+
+func (r *scheduledTransactionRepository) Create(ctx context.Context, scheduled beans.ScheduledTransaction) error {
+	txm := &txManager{r.pool}
+	return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+		args, err := scheduledTransactionArgs(scheduled)
+		if err != nil {
+			return err
+		}
+		args[":budgetID"] = scheduled.BudgetID.String()
+
+		if err := db[any](r.pool).inTx(tx).execute(ctx, scheduledTransactionCreateSQL, args); err != nil {
+			return err
+		}
+
+		return r.createSplits(ctx, tx, scheduled)
+	})
+}
+
+const scheduledTransactionUpdateSQL = `
+UPDATE scheduled_transactions
+	SET account_id=:accountID, category_id=:categoryID, payee_id=:payeeID, transfer_account_id=:transferAccountID,
+		amount=:amount, notes=:notes, frequency=:frequency, interval=:interval, start_date=:startDate, next_date=:nextDate
+	WHERE id=:id
+`
+
+const scheduledTransactionDeleteSplitsSQL = `
+DELETE FROM scheduled_transaction_splits WHERE scheduled_transaction_id = :id
+`
+
+func (r *scheduledTransactionRepository) Update(ctx context.Context, tx beans.Tx, scheduled beans.ScheduledTransaction) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Update(ctx, tx, scheduled)
+		})
+	}
+
+	args, err := scheduledTransactionArgs(scheduled)
+	if err != nil {
+		return err
+	}
+
+	if err := db[any](r.pool).inTx(tx).execute(ctx, scheduledTransactionUpdateSQL, args); err != nil {
+		return err
+	}
+
+	err = db[any](r.pool).inTx(tx).execute(ctx, scheduledTransactionDeleteSplitsSQL, map[string]any{
+		":id": scheduled.ID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.createSplits(ctx, tx, scheduled)
+}
+
+const scheduledTransactionCreateSplitSQL = `
+INSERT INTO scheduled_transaction_splits
+	(scheduled_transaction_id, position, category_id, amount, notes)
+	VALUES (:id, :position, :categoryID, :amount, :notes)
+`
+
+func (r *scheduledTransactionRepository) createSplits(ctx context.Context, tx beans.Tx, scheduled beans.ScheduledTransaction) error {
+	for i, split := range scheduled.Template.Splits {
+		amount, err := serializeAmount(split.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = db[any](r.pool).inTx(tx).execute(ctx, scheduledTransactionCreateSplitSQL, map[string]any{
+			":id":         scheduled.ID.String(),
+			":position":   i,
+			":categoryID": serializeID(split.CategoryID),
+			":amount":     amount,
+			":notes":      serializeNullString(split.Notes.NullString),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const scheduledTransactionDeleteSQL = `
+DELETE FROM scheduled_transactions WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *scheduledTransactionRepository) Delete(ctx context.Context, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).execute(ctx, scheduledTransactionDeleteSQL, map[string]any{
+		":budgetID": budgetID.String(),
+		":id":       id.String(),
+	})
+}
+
+const scheduledTransactionGetSQL = `
+SELECT * FROM scheduled_transactions WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *scheduledTransactionRepository) Get(ctx context.Context, budgetID beans.ID, id beans.ID) (beans.ScheduledTransaction, error) {
+	scheduled, err := db[beans.ScheduledTransaction](r.pool).
+		mapWith(mapScheduledTransaction).
+		one(ctx, scheduledTransactionGetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+	if err != nil {
+		return scheduled, err
+	}
+
+	res, err := r.withSplits(ctx, budgetID, []beans.ScheduledTransaction{scheduled})
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	return res[0], nil
+}
+
+const scheduledTransactionGetForBudgetSQL = `
+SELECT * FROM scheduled_transactions WHERE budget_id = :budgetID
+ORDER BY next_date ASC, id ASC
+`
+
+func (r *scheduledTransactionRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.ScheduledTransaction, error) {
+	scheduled, err := db[beans.ScheduledTransaction](r.pool).
+		mapWith(mapScheduledTransaction).
+		many(ctx, scheduledTransactionGetForBudgetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.withSplits(ctx, budgetID, scheduled)
+}
+
+const scheduledTransactionGetDueSQL = `
+SELECT * FROM scheduled_transactions WHERE budget_id = :budgetID AND next_date <= :date
+ORDER BY next_date ASC, id ASC
+`
+
+func (r *scheduledTransactionRepository) GetDue(ctx context.Context, budgetID beans.ID, date beans.Date) ([]beans.ScheduledTransaction, error) {
+	scheduled, err := db[beans.ScheduledTransaction](r.pool).
+		mapWith(mapScheduledTransaction).
+		many(ctx, scheduledTransactionGetDueSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":date":     serializeDate(date),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.withSplits(ctx, budgetID, scheduled)
+}
+
+const scheduledTransactionAdvanceNextDateSQL = `
+UPDATE scheduled_transactions SET next_date = :next
+	WHERE id = :id AND next_date = :previous
+	RETURNING id
+`
+
+func (r *scheduledTransactionRepository) AdvanceNextDate(ctx context.Context, tx beans.Tx, id beans.ID, previous beans.Date, next beans.Date) (bool, error) {
+	_, err := db[beans.ID](r.pool).
+		inTx(tx).
+		mapWith(func(stmt *sqlite.Stmt) (beans.ID, error) { return mapID(stmt, "id") }).
+		one(ctx, scheduledTransactionAdvanceNextDateSQL, map[string]any{
+			":id":       id.String(),
+			":previous": serializeDate(previous),
+			":next":     serializeDate(next),
+		})
+	if errors.Is(err, beans.ErrorNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+const scheduledTransactionGetSplitsSQL = `
+SELECT scheduled_transaction_splits.* FROM scheduled_transaction_splits
+JOIN scheduled_transactions ON scheduled_transactions.id = scheduled_transaction_splits.scheduled_transaction_id
+WHERE scheduled_transactions.budget_id = :budgetID
+ORDER BY scheduled_transaction_splits.position ASC
+`
+
+type scheduledTransactionSplitRow struct {
+	scheduledTransactionID beans.ID
+	split                  beans.SplitParams
+}
+
+// Loads the template splits of the scheduled transactions.
+func (r *scheduledTransactionRepository) withSplits(ctx context.Context, budgetID beans.ID, scheduled []beans.ScheduledTransaction) ([]beans.ScheduledTransaction, error) {
+	if len(scheduled) == 0 {
+		return scheduled, nil
+	}
+
+	rows, err := db[scheduledTransactionSplitRow](r.pool).
+		mapWith(mapScheduledTransactionSplitRow).
+		many(ctx, scheduledTransactionGetSplitsSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	splits := make(map[beans.ID][]beans.SplitParams)
+	for _, row := range rows {
+		splits[row.scheduledTransactionID] = append(splits[row.scheduledTransactionID], row.split)
+	}
+	for i := range scheduled {
+		scheduled[i].Template.Splits = splits[scheduled[i].ID]
+	}
+
+	return scheduled, nil
+}
+
+func scheduledTransactionArgs(scheduled beans.ScheduledTransaction) (map[string]any, error) {
+	amount, err := serializeAmount(scheduled.Template.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		":id":                scheduled.ID.String(),
+		":accountID":         scheduled.Template.AccountID.String(),
+		":categoryID":        serializeID(scheduled.Template.CategoryID),
+		":payeeID":           serializeID(scheduled.Template.PayeeID),
+		":transferAccountID": serializeID(scheduled.Template.TransferAccountID),
+		":amount":            amount,
+		":notes":             serializeNullString(scheduled.Template.Notes.NullString),
+		":frequency":         string(scheduled.Rule.Frequency),
+		":interval":          scheduled.Rule.Interval,
+		":startDate":         serializeDate(scheduled.Rule.Start),
+		":nextDate":          serializeDate(scheduled.NextDate),
+	}, nil
+}
+
+// mappers
+
+func mapScheduledTransaction(stmt *sqlite.Stmt) (beans.ScheduledTransaction, error) {
+	id, err := mapID(stmt, "id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	budgetID, err := mapID(stmt, "budget_id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	accountID, err := mapID(stmt, "account_id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	categoryID, err := mapID(stmt, "category_id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	payeeID, err := mapID(stmt, "payee_id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	transferAccountID, err := mapID(stmt, "transfer_account_id")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	startDate, err := mapDate(stmt, "start_date")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+	nextDate, err := mapDate(stmt, "next_date")
+	if err != nil {
+		return beans.ScheduledTransaction{}, err
+	}
+
+	return beans.ScheduledTransaction{
+		ID:       id,
+		BudgetID: budgetID,
+		Template: beans.TransactionCreateParams{
+			TransferAccountID: transferAccountID,
+			TransactionParams: beans.TransactionParams{
+				AccountID:  accountID,
+				CategoryID: categoryID,
+				PayeeID:    payeeID,
+				Amount:     mapAmount(stmt, "amount"),
+				Notes:      beans.TransactionNotes{NullString: mapNullString(stmt, "notes")},
+			},
+		},
+		Rule: beans.ScheduleRule{
+			Frequency: beans.ScheduleFrequency(stmt.GetText("frequency")),
+			Interval:  int(stmt.GetInt64("interval")),
+			Start:     startDate,
+		},
+		NextDate: nextDate,
+	}, nil
+}
+
+func mapScheduledTransactionSplitRow(stmt *sqlite.Stmt) (scheduledTransactionSplitRow, error) {
+	scheduledTransactionID, err := mapID(stmt, "scheduled_transaction_id")
+	if err != nil {
+		return scheduledTransactionSplitRow{}, err
+	}
+	categoryID, err := mapID(stmt, "category_id")
+	if err != nil {
+		return scheduledTransactionSplitRow{}, err
+	}
+
+	return scheduledTransactionSplitRow{
+		scheduledTransactionID: scheduledTransactionID,
+		split: beans.SplitParams{
+			Amount:     mapAmount(stmt, "amount"),
+			CategoryID: categoryID,
+			Notes:      beans.TransactionNotes{NullString: mapNullString(stmt, "notes")},
+		},
+	}, nil
+}
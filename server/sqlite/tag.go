@@ -0,0 +1,164 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type tagRepository struct{ repository }
+
+var _ beans.TagRepository = (*tagRepository)(nil)
+
+const tagCreateSQL = `
+INSERT INTO tags (id, budget_id, name) VALUES (:id, :budgetID, :name)
+`
+
+func (r *tagRepository) Create(ctx context.Context, tag beans.Tag) error {
+	return db[any](r.pool).execute(ctx, tagCreateSQL, map[string]any{
+		":id":       tag.ID.String(),
+		":budgetID": tag.BudgetID.String(),
+		":name":     string(tag.Name),
+	})
+}
+
+const tagUpdateSQL = `
+UPDATE tags SET name = :name WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *tagRepository) Update(ctx context.Context, tag beans.Tag) error {
+	return db[any](r.pool).execute(ctx, tagUpdateSQL, map[string]any{
+		":id":       tag.ID.String(),
+		":budgetID": tag.BudgetID.String(),
+		":name":     string(tag.Name),
+	})
+}
+
+const tagDeleteSQL = `
+DELETE FROM tags WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *tagRepository) Delete(ctx context.Context, budgetID beans.ID, id beans.ID) error {
+	return db[any](r.pool).execute(ctx, tagDeleteSQL, map[string]any{
+		":budgetID": budgetID.String(),
+		":id":       id.String(),
+	})
+}
+
+const tagGetSQL = `
+SELECT * FROM tags WHERE budget_id = :budgetID AND id = :id
+`
+
+func (r *tagRepository) Get(ctx context.Context, budgetID beans.ID, id beans.ID) (beans.Tag, error) {
+	return db[beans.Tag](r.pool).
+		mapWith(mapTag).
+		one(ctx, tagGetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+			":id":       id.String(),
+		})
+}
+
+const tagGetForBudgetSQL = `
+SELECT * FROM tags WHERE budget_id = :budgetID
+ORDER BY tags.name ASC, tags.id ASC
+`
+
+func (r *tagRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.Tag, error) {
+	return db[beans.Tag](r.pool).
+		mapWith(mapTag).
+		many(ctx, tagGetForBudgetSQL, map[string]any{
+			":budgetID": budgetID.String(),
+		})
+}
+
+const tagAddToTransactionSQL = `
+INSERT OR IGNORE INTO transaction_tags (transaction_id, tag_id) VALUES (:transactionID, :tagID)
+`
+
+func (r *tagRepository) SetForTransactions(ctx context.Context, tx beans.Tx, tags map[beans.ID][]beans.ID) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.SetForTransactions(ctx, tx, tags)
+		})
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	transactionIDs := make([]string, 0, len(tags))
+	for transactionID := range tags {
+		transactionIDs = append(transactionIDs, transactionID.String())
+	}
+	sql, args, err := squirrel.
+		Delete("transaction_tags").
+		Where(squirrel.Eq{"transaction_id": transactionIDs}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if err := db[any](r.pool).inTx(tx).executeWithArgs(ctx, sql, args); err != nil {
+		return err
+	}
+
+	for transactionID, tagIDs := range tags {
+		for _, tagID := range tagIDs {
+			err := db[any](r.pool).inTx(tx).execute(ctx, tagAddToTransactionSQL, map[string]any{
+				":transactionID": transactionID.String(),
+				":tagID":         tagID.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func mapTag(stmt *sqlite.Stmt) (beans.Tag, error) {
+	id, err := mapID(stmt, "id")
+	if err != nil {
+		return beans.Tag{}, err
+	}
+	budgetID, err := mapID(stmt, "budget_id")
+	if err != nil {
+		return beans.Tag{}, err
+	}
+
+	return beans.Tag{
+		ID:       id,
+		BudgetID: budgetID,
+		Name:     beans.Name(stmt.GetText("name")),
+	}, nil
+}
+
+// Maps a JSON array of tags made by json_group_array. Transactions without
+// tags have nil tags.
+func mapRelatedTags(stmt *sqlite.Stmt, col string) ([]beans.RelatedTag, error) {
+	var rows []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(stmt.GetText(col)), &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]beans.RelatedTag, len(rows))
+	for i, row := range rows {
+		id, err := beans.IDFromString(row.ID)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = beans.RelatedTag{ID: id, Name: beans.Name(row.Name)}
+	}
+
+	return tags, nil
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/bradenrayhorn/beans/server/beans"
@@ -13,10 +14,31 @@ type TransactionRepository struct{ repository }
 
 var _ beans.TransactionRepository = (*TransactionRepository)(nil)
 
-func (r *TransactionRepository) Create(ctx context.Context, transactions []beans.Transaction) error {
+// Transactions are inserted in batches to stay under SQLite's bound parameter limit.
+const transactionCreateBatchSize = 500
+
+func (r *TransactionRepository) Create(ctx context.Context, tx beans.Tx, transactions []beans.Transaction) error {
+	if len(transactions) > transactionCreateBatchSize && tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Create(ctx, tx, transactions)
+		})
+	}
+
+	for start := 0; start < len(transactions); start += transactionCreateBatchSize {
+		end := min(start+transactionCreateBatchSize, len(transactions))
+		if err := r.createBatch(ctx, tx, transactions[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) createBatch(ctx context.Context, tx beans.Tx, transactions []beans.Transaction) error {
 	q := squirrel.
 		Insert("transactions").
-		Columns("id", "account_id", "category_id", "payee_id", "amount", "date", "notes", "transfer_id", "split_id", "is_split")
+		Columns("id", "account_id", "category_id", "payee_id", "amount", "date", "notes", "transfer_id", "split_id", "is_split", "import_id", "import_payee", "status")
 
 	for _, t := range transactions {
 		amount, err := serializeAmount(t.Amount)
@@ -35,6 +57,9 @@ func (r *TransactionRepository) Create(ctx context.Context, transactions []beans
 			serializeID(t.TransferID),
 			serializeID(t.SplitID),
 			t.IsSplit,
+			serializeNullString(t.ImportID),
+			serializeNullString(t.ImportPayee),
+			string(t.Status),
 		)
 	}
 
@@ -43,46 +68,58 @@ func (r *TransactionRepository) Create(ctx context.Context, transactions []beans
 		return err
 	}
 
-	return db[any](r.pool).executeWithArgs(ctx, sql, params)
+	return db[any](r.pool).inTx(tx).executeWithArgs(ctx, sql, params)
 }
 
 const updateTransactionSQL = `
 UPDATE transactions
-	SET account_id=:accountID, category_id=:categoryID, payee_id=:payeeID, date=:date, amount=:amount, notes=:notes, is_split=:isSplit
+	SET account_id=:accountID, category_id=:categoryID, payee_id=:payeeID, date=:date, amount=:amount, notes=:notes, is_split=:isSplit, status=:status
 	WHERE id=:id
 `
 
-func (r *TransactionRepository) Update(ctx context.Context, transactions []beans.Transaction) error {
-	txm := &txManager{r.pool}
-	return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
-		for _, t := range transactions {
-			amount, err := serializeAmount(t.Amount)
-			if err != nil {
-				return err
-			}
+func (r *TransactionRepository) Update(ctx context.Context, tx beans.Tx, transactions []beans.Transaction) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Update(ctx, tx, transactions)
+		})
+	}
 
-			err = db[any](r.pool).
-				inTx(tx).
-				execute(ctx, updateTransactionSQL, map[string]any{
-					":id":         t.ID.String(),
-					":accountID":  t.AccountID.String(),
-					":categoryID": serializeID(t.CategoryID),
-					":payeeID":    serializeID(t.PayeeID),
-					":amount":     amount,
-					":date":       serializeDate(t.Date),
-					":notes":      serializeNullString(t.Notes.NullString),
-					":isSplit":    t.IsSplit,
-				})
-			if err != nil {
-				return err
-			}
+	for _, t := range transactions {
+		amount, err := serializeAmount(t.Amount)
+		if err != nil {
+			return err
 		}
 
-		return nil
-	})
+		err = db[any](r.pool).
+			inTx(tx).
+			execute(ctx, updateTransactionSQL, map[string]any{
+				":id":         t.ID.String(),
+				":accountID":  t.AccountID.String(),
+				":categoryID": serializeID(t.CategoryID),
+				":payeeID":    serializeID(t.PayeeID),
+				":amount":     amount,
+				":date":       serializeDate(t.Date),
+				":notes":      serializeNullString(t.Notes.NullString),
+				":isSplit":    t.IsSplit,
+				":status":     string(t.Status),
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (r *TransactionRepository) Delete(ctx context.Context, budgetID beans.ID, transactionIDs []beans.ID) error {
+func (r *TransactionRepository) Delete(ctx context.Context, tx beans.Tx, budgetID beans.ID, transactionIDs []beans.ID) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Delete(ctx, tx, budgetID, transactionIDs)
+		})
+	}
+
 	// get transaction ids to delete
 	sql, params, err := squirrel.
 		Select("transactions.id").
@@ -98,6 +135,7 @@ func (r *TransactionRepository) Delete(ctx context.Context, budgetID beans.ID, t
 	}
 
 	ids, err := db[string](r.pool).
+		inTx(tx).
 		mapWith(func(stmt *sqlite.Stmt) (string, error) { return stmt.GetText("id"), nil }).
 		manyWithArgs(ctx, sql, params)
 	if err != nil {
@@ -115,9 +153,34 @@ func (r *TransactionRepository) Delete(ctx context.Context, budgetID beans.ID, t
 	}
 
 	return db[any](r.pool).
+		inTx(tx).
 		executeWithArgs(ctx, sql, params)
 }
 
+func (r *TransactionRepository) DeleteSplits(ctx context.Context, tx beans.Tx, splitIDs []beans.ID) error {
+	if len(splitIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(splitIDs))
+	for i, id := range splitIDs {
+		ids[i] = id.String()
+	}
+
+	sql, params, err := squirrel.
+		Delete("transactions").
+		Where(squirrel.And{
+			squirrel.Expr("split_id IS NOT NULL"),
+			squirrel.Eq{"id": ids},
+		}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return db[any](r.pool).inTx(tx).executeWithArgs(ctx, sql, params)
+}
+
 const transactionGetSQL = `
 SELECT transactions.* FROM transactions
 JOIN accounts ON accounts.id = transactions.account_id
@@ -135,7 +198,7 @@ func (r *TransactionRepository) Get(ctx context.Context, budgetID beans.ID, id b
 }
 
 func (r *TransactionRepository) GetWithRelations(ctx context.Context, budgetID beans.ID, id beans.ID) (beans.TransactionWithRelations, error) {
-	q := getTransactionWithRelationshipsQuery(budgetID.String()).
+	q := getTransactionWithRelationshipsQuery(budgetID.String(), beans.TransactionListParams{}).
 		Where("transactions.id = ?", id)
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -147,9 +210,58 @@ func (r *TransactionRepository) GetWithRelations(ctx context.Context, budgetID b
 		oneWithArgs(ctx, sql, args)
 }
 
-func (r *TransactionRepository) GetForBudget(ctx context.Context, budgetID beans.ID) ([]beans.TransactionWithRelations, error) {
-	q := getTransactionWithRelationshipsQuery(budgetID.String()).
-		Where("transactions.split_id IS NULL")
+func (r *TransactionRepository) GetForBudget(ctx context.Context, budgetID beans.ID, params beans.TransactionListParams) ([]beans.TransactionWithRelations, error) {
+	q, err := filterTransactionWithRelationshipsQuery(
+		getTransactionWithRelationshipsQuery(budgetID.String(), params).
+			Where("transactions.split_id IS NULL"),
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return db[beans.TransactionWithRelations](r.pool).
+		mapWith(mapTransactionWithRelations).
+		manyWithArgs(ctx, sql, args)
+}
+
+// matches on split lines are ranked by the best match of the transaction
+const transactionSearchJoin = `(
+	SELECT COALESCE(matched.split_id, matched.id) AS id, MIN(transaction_search.rank) AS rank
+	FROM transaction_search
+	JOIN transactions matched ON matched.rowid = transaction_search.rowid
+	WHERE transaction_search MATCH ?
+	GROUP BY 1
+) search ON search.id = transactions.id`
+
+func (r *TransactionRepository) Search(ctx context.Context, budgetID beans.ID, params beans.TransactionSearchParams) ([]beans.TransactionWithRelations, error) {
+	match := ftsQuery(params.Query.String())
+	if match == "" {
+		return []beans.TransactionWithRelations{}, nil
+	}
+
+	q := selectTransactionWithRelationshipsQuery(budgetID.String()).
+		Join(transactionSearchJoin, match).
+		Where("transactions.split_id IS NULL").
+		OrderBy("search.rank ASC, transactions.date DESC, transactions.id DESC")
+	if params.Limit > 0 {
+		q = q.Limit(uint64(params.Limit))
+	}
+
+	q, err := filterTransactionWithRelationshipsQuery(q, beans.TransactionListParams{
+		TransactionFilter: beans.TransactionFilter{
+			AccountID: params.AccountID,
+			From:      params.From,
+			To:        params.To,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
 	sql, args, err := q.ToSql()
 	if err != nil {
 		return nil, err
@@ -161,7 +273,7 @@ func (r *TransactionRepository) GetForBudget(ctx context.Context, budgetID beans
 }
 
 func (r *TransactionRepository) GetSplits(ctx context.Context, budgetID beans.ID, transactionID beans.ID) ([]beans.TransactionAsSplit, error) {
-	q := getTransactionWithRelationshipsQuery(budgetID.String()).
+	q := getTransactionWithRelationshipsQuery(budgetID.String(), beans.TransactionListParams{}).
 		Where("transactions.split_id = ?", transactionID.String())
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -173,6 +285,110 @@ func (r *TransactionRepository) GetSplits(ctx context.Context, budgetID beans.ID
 		manyWithArgs(ctx, sql, args)
 }
 
+func (r *TransactionRepository) GetSplitsForAccount(ctx context.Context, budgetID beans.ID, accountID beans.ID) ([]beans.TransactionAsSplit, error) {
+	q := getTransactionWithRelationshipsQuery(budgetID.String(), beans.TransactionListParams{}).
+		Where("transactions.split_id IS NOT NULL").
+		Where("transactions.account_id = ?", accountID.String())
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return db[beans.TransactionAsSplit](r.pool).
+		mapWith(mapTransactionAsSplit).
+		manyWithArgs(ctx, sql, args)
+}
+
+const transactionGetForAccountBetweenSQL = `
+SELECT transactions.* FROM transactions
+JOIN accounts ON accounts.id = transactions.account_id
+	AND accounts.budget_id = :budgetID
+WHERE
+	transactions.account_id = :accountID
+	AND transactions.split_id IS NULL
+	AND transactions.date >= :begin
+	AND transactions.date <= :end
+ORDER BY transactions.date ASC, transactions.id ASC
+`
+
+func (r *TransactionRepository) GetForAccountBetween(ctx context.Context, budgetID beans.ID, accountID beans.ID, begin beans.Date, end beans.Date) ([]beans.Transaction, error) {
+	return db[beans.Transaction](r.pool).
+		mapWith(mapTransaction).
+		many(ctx, transactionGetForAccountBetweenSQL, map[string]any{
+			":budgetID":  budgetID.String(),
+			":accountID": accountID.String(),
+			":begin":     serializeDate(begin),
+			":end":       serializeDate(end),
+		})
+}
+
+const transactionGetClearedBalanceSQL = `
+SELECT sum(amount) as cleared_balance FROM transactions
+	WHERE account_id = :accountID
+		AND is_split = false
+		AND status != 'uncleared'
+		AND date <= :date
+`
+
+func (r *TransactionRepository) GetClearedBalance(ctx context.Context, tx beans.Tx, accountID beans.ID, date beans.Date) (beans.Amount, error) {
+	return db[beans.Amount](r.pool).
+		inTx(tx).
+		mapWith(func(stmt *sqlite.Stmt) (beans.Amount, error) { return mapAmount(stmt, "cleared_balance"), nil }).
+		one(ctx, transactionGetClearedBalanceSQL, map[string]any{
+			":accountID": accountID.String(),
+			":date":      serializeDate(date),
+		})
+}
+
+const transactionGetToReconcileSQL = `
+SELECT * FROM transactions
+	WHERE account_id = :accountID AND status = 'cleared' AND date <= :date
+	ORDER BY date ASC, id ASC
+`
+
+func (r *TransactionRepository) GetToReconcile(ctx context.Context, tx beans.Tx, accountID beans.ID, date beans.Date) ([]beans.Transaction, error) {
+	return db[beans.Transaction](r.pool).
+		inTx(tx).
+		mapWith(mapTransaction).
+		many(ctx, transactionGetToReconcileSQL, map[string]any{
+			":accountID": accountID.String(),
+			":date":      serializeDate(date),
+		})
+}
+
+const transactionReconcileSQL = `
+UPDATE transactions
+	SET status = 'reconciled'
+	WHERE account_id = :accountID AND status = 'cleared' AND date <= :date
+`
+
+func (r *TransactionRepository) Reconcile(ctx context.Context, tx beans.Tx, accountID beans.ID, date beans.Date) error {
+	return db[any](r.pool).
+		inTx(tx).
+		execute(ctx, transactionReconcileSQL, map[string]any{
+			":accountID": accountID.String(),
+			":date":      serializeDate(date),
+		})
+}
+
+const transactionGetImportIDsSQL = `
+SELECT transactions.import_id FROM transactions
+JOIN accounts ON accounts.id = transactions.account_id
+	AND accounts.budget_id = :budgetID
+WHERE
+	transactions.account_id = :accountID
+	AND transactions.import_id IS NOT NULL
+`
+
+func (r *TransactionRepository) GetImportIDs(ctx context.Context, budgetID beans.ID, accountID beans.ID) ([]string, error) {
+	return db[string](r.pool).
+		mapWith(func(stmt *sqlite.Stmt) (string, error) { return stmt.GetText("import_id"), nil }).
+		many(ctx, transactionGetImportIDsSQL, map[string]any{
+			":budgetID":  budgetID.String(),
+			":accountID": accountID.String(),
+		})
+}
+
 type getActivityByCategoryRow struct {
 	ID       beans.ID
 	Activity beans.Amount
@@ -187,6 +403,83 @@ func (r *TransactionRepository) GetActivityByCategory(ctx context.Context, budge
 		Join("accounts ON transactions.account_id = accounts.id AND accounts.budget_id = ?", budgetID.String()).
 		GroupBy("categories.id")
 
+	return r.getActivityByCategory(ctx, q, from, to)
+}
+
+func (r *TransactionRepository) GetPaymentActivityByCategory(ctx context.Context, budgetID beans.ID, from beans.Date, to beans.Date) (map[beans.ID]beans.Amount, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q := psql.
+		Select("accounts.payment_category_id as id", "-sum(transactions.amount) as activity").
+		From("transactions").
+		Join("accounts ON transactions.account_id = accounts.id AND accounts.budget_id = ?", budgetID.String()).
+		LeftJoin("categories ON categories.id = transactions.category_id").
+		LeftJoin("category_groups ON category_groups.id = categories.group_id").
+		Where("accounts.payment_category_id IS NOT NULL AND accounts.off_budget = false").
+		// spending in budgeted categories and transfers with other on-budget accounts
+		Where("(category_groups.is_income = false OR (transactions.category_id IS NULL AND transactions.transfer_id IS NOT NULL))").
+		GroupBy("accounts.payment_category_id")
+
+	return r.getActivityByCategory(ctx, q, from, to)
+}
+
+func (r *TransactionRepository) GetCreditActivityByCategory(ctx context.Context, budgetID beans.ID, from beans.Date, to beans.Date) (map[beans.ID]map[beans.ID]beans.Amount, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q := psql.
+		Select("categories.id", "accounts.payment_category_id", "sum(transactions.amount) as activity").
+		From("transactions").
+		Join("categories ON transactions.category_id = categories.id").
+		Join("category_groups ON category_groups.id = categories.group_id AND category_groups.is_income = false").
+		Join("accounts ON transactions.account_id = accounts.id AND accounts.budget_id = ?", budgetID.String()).
+		Where("accounts.payment_category_id IS NOT NULL AND accounts.off_budget = false").
+		GroupBy("categories.id", "accounts.payment_category_id")
+
+	if !from.Empty() {
+		q = q.Where("transactions.date >= ?", serializeDate(from))
+	}
+	if !to.Empty() {
+		q = q.Where("transactions.date <= ?", serializeDate(to))
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	type row struct {
+		CategoryID        beans.ID
+		PaymentCategoryID beans.ID
+		Activity          beans.Amount
+	}
+	rows, err := db[row](r.pool).
+		mapWith(func(stmt *sqlite.Stmt) (row, error) {
+			categoryID, err := mapID(stmt, "id")
+			if err != nil {
+				return row{}, err
+			}
+			paymentCategoryID, err := mapID(stmt, "payment_category_id")
+			if err != nil {
+				return row{}, err
+			}
+			return row{CategoryID: categoryID, PaymentCategoryID: paymentCategoryID, Activity: mapAmount(stmt, "activity")}, nil
+		}).
+		manyWithArgs(ctx, sql, args)
+	if err != nil {
+		return nil, err
+	}
+
+	activityByCategory := make(map[beans.ID]map[beans.ID]beans.Amount)
+	for _, v := range rows {
+		if _, ok := activityByCategory[v.CategoryID]; !ok {
+			activityByCategory[v.CategoryID] = make(map[beans.ID]beans.Amount)
+		}
+		activityByCategory[v.CategoryID][v.PaymentCategoryID] = v.Activity
+	}
+
+	return activityByCategory, nil
+}
+
+// Sums activity between the dates. The query must select an id and activity.
+func (r *TransactionRepository) getActivityByCategory(ctx context.Context, q squirrel.SelectBuilder, from beans.Date, to beans.Date) (map[beans.ID]beans.Amount, error) {
 	if !from.Empty() {
 		q = q.Where("transactions.date >= ?", serializeDate(from))
 	}
@@ -247,7 +540,14 @@ func (r *TransactionRepository) GetIncomeBetween(ctx context.Context, budgetID b
 
 // big queries
 
-func getTransactionWithRelationshipsQuery(budgetID string) squirrel.SelectBuilder {
+const transactionTagsColumn = `(
+	SELECT json_group_array(json_object('id', tags.id, 'name', tags.name) ORDER BY tags.name, tags.id)
+	FROM transaction_tags
+	JOIN tags ON tags.id = transaction_tags.tag_id
+	WHERE transaction_tags.transaction_id = transactions.id
+) as tags`
+
+func selectTransactionWithRelationshipsQuery(budgetID string) squirrel.SelectBuilder {
 	return squirrel.
 		Select(
 			"transactions.*",
@@ -258,14 +558,127 @@ func getTransactionWithRelationshipsQuery(budgetID string) squirrel.SelectBuilde
 			"transfer_account.id as transfer_account_id",
 			"transfer_account.name as transfer_account_name",
 			"transfer_account.off_budget as transfer_account_off_budget",
+			transactionTagsColumn,
 		).
 		From("transactions").
 		Join("accounts ON transactions.account_id = accounts.id AND accounts.budget_id = ?", budgetID).
 		LeftJoin("categories ON categories.id = transactions.category_id").
 		LeftJoin("payees ON payees.id = transactions.payee_id").
 		LeftJoin("transactions transfer ON transfer.id = transactions.transfer_id").
-		LeftJoin("accounts transfer_account ON transfer.account_id = transfer_account.id").
-		OrderBy("transactions.date DESC, transactions.id DESC")
+		LeftJoin("accounts transfer_account ON transfer.account_id = transfer_account.id")
+}
+
+func getTransactionWithRelationshipsQuery(budgetID string, params beans.TransactionListParams) squirrel.SelectBuilder {
+	q := selectTransactionWithRelationshipsQuery(budgetID)
+
+	switch params.Sort.OrDefault() {
+	case beans.TransactionSortDateAsc:
+		q = q.OrderBy("transactions.date ASC, transactions.id ASC")
+	case beans.TransactionSortAmountDesc:
+		q = q.OrderBy("transactions.amount DESC, transactions.id DESC")
+	case beans.TransactionSortAmountAsc:
+		q = q.OrderBy("transactions.amount ASC, transactions.id ASC")
+	default:
+		q = q.OrderBy("transactions.date DESC, transactions.id DESC")
+	}
+
+	if params.Limit > 0 {
+		q = q.Limit(uint64(params.Limit))
+	}
+
+	return q
+}
+
+// a transfer between accounts that are both on or both off budget
+const transactionIsTransferCondition = "(transactions.transfer_id IS NOT NULL AND COALESCE(transfer_account.off_budget = accounts.off_budget, FALSE))"
+
+// Applies the filters and cursor of the params to a query from
+// getTransactionWithRelationshipsQuery.
+func filterTransactionWithRelationshipsQuery(q squirrel.SelectBuilder, params beans.TransactionListParams) (squirrel.SelectBuilder, error) {
+	if !params.AccountID.Empty() {
+		q = q.Where("transactions.account_id = ?", params.AccountID.String())
+	}
+	if !params.CategoryID.Empty() {
+		q = q.Where(
+			"(transactions.category_id = ? OR EXISTS (SELECT 1 FROM transactions split WHERE split.split_id = transactions.id AND split.category_id = ?))",
+			params.CategoryID.String(),
+			params.CategoryID.String(),
+		)
+	}
+	if !params.PayeeID.Empty() {
+		q = q.Where("transactions.payee_id = ?", params.PayeeID.String())
+	}
+	if !params.TagID.Empty() {
+		q = q.Where(
+			"EXISTS (SELECT 1 FROM transaction_tags JOIN transactions tagged ON tagged.id = transaction_tags.transaction_id WHERE transaction_tags.tag_id = ? AND (tagged.id = transactions.id OR tagged.split_id = transactions.id))",
+			params.TagID.String(),
+		)
+	}
+	if !params.From.Empty() {
+		q = q.Where("transactions.date >= ?", serializeDate(params.From))
+	}
+	if !params.To.Empty() {
+		q = q.Where("transactions.date <= ?", serializeDate(params.To))
+	}
+	if !params.MinAmount.Empty() {
+		amount, err := serializeAmount(params.MinAmount)
+		if err != nil {
+			return q, err
+		}
+		q = q.Where("transactions.amount >= ?", amount)
+	}
+	if !params.MaxAmount.Empty() {
+		amount, err := serializeAmount(params.MaxAmount)
+		if err != nil {
+			return q, err
+		}
+		q = q.Where("transactions.amount <= ?", amount)
+	}
+
+	switch params.Variant {
+	case beans.TransactionSplit:
+		q = q.Where("transactions.is_split")
+	case beans.TransactionTransfer:
+		q = q.Where("NOT transactions.is_split AND " + transactionIsTransferCondition)
+	case beans.TransactionOffBudget:
+		q = q.Where("NOT transactions.is_split AND NOT " + transactionIsTransferCondition + " AND accounts.off_budget")
+	case beans.TransactionStandard:
+		q = q.Where("NOT transactions.is_split AND NOT " + transactionIsTransferCondition + " AND NOT accounts.off_budget")
+	}
+
+	// continue after the cursor, using the id to break ties
+	if cursor := params.Cursor; !cursor.Empty() {
+		column, value, op := "transactions.date", any(serializeDate(cursor.Date)), "<"
+
+		switch cursor.Sort {
+		case beans.TransactionSortAmountDesc, beans.TransactionSortAmountAsc:
+			amount, err := serializeAmount(cursor.Amount)
+			if err != nil {
+				return q, err
+			}
+			column, value = "transactions.amount", amount
+		}
+		if cursor.Sort == beans.TransactionSortDateAsc || cursor.Sort == beans.TransactionSortAmountAsc {
+			op = ">"
+		}
+
+		q = q.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND transactions.id %[2]s ?))", column, op),
+			value, value, cursor.ID.String(),
+		)
+	}
+
+	return q, nil
+}
+
+// Makes an FTS5 query that matches every word of the search as a prefix.
+// Words are quoted so that the search cannot use the query syntax.
+func ftsQuery(search string) string {
+	words := strings.Fields(search)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
 }
 
 // mappers
@@ -309,10 +722,14 @@ func mapTransaction(stmt *sqlite.Stmt) (beans.Transaction, error) {
 		Amount: mapAmount(stmt, "amount"),
 		Date:   date,
 		Notes:  beans.TransactionNotes{NullString: mapNullString(stmt, "notes")},
+		Status: beans.TransactionStatus(stmt.GetText("status")),
 
 		TransferID: transferID,
 		SplitID:    splitID,
 		IsSplit:    stmt.GetBool("is_split"),
+
+		ImportID:    mapNullString(stmt, "import_id"),
+		ImportPayee: mapNullString(stmt, "import_payee"),
 	}, nil
 }
 
@@ -325,16 +742,23 @@ func mapTransactionWithRelations(stmt *sqlite.Stmt) (beans.TransactionWithRelati
 	categoryName := mapNullString(stmt, "category_name")
 	payeeName := mapNullString(stmt, "payee_name")
 
+	tags, err := mapRelatedTags(stmt, "tags")
+	if err != nil {
+		return beans.TransactionWithRelations{}, err
+	}
+
 	transactionWithRelations := beans.TransactionWithRelations{
 		ID:     transaction.ID,
 		Amount: transaction.Amount,
 		Date:   transaction.Date,
 		Notes:  transaction.Notes,
+		Status: transaction.Status,
 		Account: beans.RelatedAccount{
 			ID:        transaction.AccountID,
 			Name:      beans.Name(stmt.GetText("account_name")),
 			OffBudget: stmt.GetBool("account_off_budget"),
 		},
+		Tags: tags,
 	}
 
 	if !transaction.TransferID.Empty() {
@@ -383,6 +807,11 @@ func mapTransactionAsSplit(stmt *sqlite.Stmt) (beans.TransactionAsSplit, error)
 		return beans.TransactionAsSplit{}, fmt.Errorf("category null on split %s", transaction.ID)
 	}
 
+	tags, err := mapRelatedTags(stmt, "tags")
+	if err != nil {
+		return beans.TransactionAsSplit{}, err
+	}
+
 	return beans.TransactionAsSplit{
 		Transaction: transaction,
 		Split: beans.Split{
@@ -393,6 +822,7 @@ func mapTransactionAsSplit(stmt *sqlite.Stmt) (beans.TransactionAsSplit, error)
 				ID:   transaction.CategoryID,
 				Name: beans.Name(stmt.GetText("category_name")),
 			},
+			Tags: tags,
 		},
 	}, nil
 }
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"zombiezen.com/go/sqlite"
+)
+
+type transactionHistoryRepository struct{ repository }
+
+var _ beans.TransactionHistoryRepository = (*transactionHistoryRepository)(nil)
+
+// Transaction fields as stored in the before and after columns. Amounts are
+// stored the same way as in the transactions table.
+type historyValues struct {
+	AccountID  beans.ID `json:"accountId"`
+	CategoryID beans.ID `json:"categoryId"`
+	PayeeID    beans.ID `json:"payeeId"`
+	Amount     int64    `json:"amount"`
+	Date       string   `json:"date"`
+	Notes      string   `json:"notes"`
+	Status     string   `json:"status"`
+}
+
+const transactionHistoryCreateSQL = `
+INSERT INTO transaction_history (id, budget_id, transaction_id, split_id, user_id, action, before, after, created_at)
+	VALUES (:id, :budgetID, :transactionID, :splitID, :userID, :action, :before, :after, :createdAt)
+`
+
+func (r *transactionHistoryRepository) Create(ctx context.Context, tx beans.Tx, history []beans.TransactionHistory) error {
+	if tx == nil {
+		txm := &txManager{r.pool}
+		return beans.ExecTxNil(ctx, txm, func(tx beans.Tx) error {
+			return r.Create(ctx, tx, history)
+		})
+	}
+
+	for _, h := range history {
+		before, err := serializeHistoryValues(h.Before)
+		if err != nil {
+			return err
+		}
+		after, err := serializeHistoryValues(h.After)
+		if err != nil {
+			return err
+		}
+
+		err = db[any](r.pool).inTx(tx).execute(ctx, transactionHistoryCreateSQL, map[string]any{
+			":id":            h.ID.String(),
+			":budgetID":      h.BudgetID.String(),
+			":transactionID": h.TransactionID.String(),
+			":splitID":       serializeID(h.SplitID),
+			":userID":        h.User.ID.String(),
+			":action":        string(h.Action),
+			":before":        before,
+			":after":         after,
+			":createdAt":     h.CreatedAt.UTC().Format(time.RFC3339Nano),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const transactionHistoryGetForTransactionSQL = `
+SELECT transaction_history.*, users.username FROM transaction_history
+JOIN users ON users.id = transaction_history.user_id
+WHERE transaction_history.budget_id = :budgetID
+	AND (transaction_history.transaction_id = :transactionID OR transaction_history.split_id = :transactionID)
+ORDER BY transaction_history.rowid ASC
+`
+
+func (r *transactionHistoryRepository) GetForTransaction(ctx context.Context, budgetID beans.ID, transactionID beans.ID) ([]beans.TransactionHistory, error) {
+	return db[beans.TransactionHistory](r.pool).
+		mapWith(mapTransactionHistory).
+		many(ctx, transactionHistoryGetForTransactionSQL, map[string]any{
+			":budgetID":      budgetID.String(),
+			":transactionID": transactionID.String(),
+		})
+}
+
+func mapTransactionHistory(stmt *sqlite.Stmt) (beans.TransactionHistory, error) {
+	var err error
+	h := beans.TransactionHistory{
+		Action: beans.TransactionHistoryAction(stmt.GetText("action")),
+	}
+
+	if h.ID, err = mapID(stmt, "id"); err != nil {
+		return h, err
+	}
+	if h.BudgetID, err = mapID(stmt, "budget_id"); err != nil {
+		return h, err
+	}
+	if h.TransactionID, err = mapID(stmt, "transaction_id"); err != nil {
+		return h, err
+	}
+	if h.SplitID, err = mapID(stmt, "split_id"); err != nil {
+		return h, err
+	}
+	if h.User.ID, err = mapID(stmt, "user_id"); err != nil {
+		return h, err
+	}
+	h.User.Username = beans.Username(stmt.GetText("username"))
+
+	if h.CreatedAt, err = time.Parse(time.RFC3339Nano, stmt.GetText("created_at")); err != nil {
+		return h, err
+	}
+	if h.Before, err = mapHistoryValues(stmt, "before"); err != nil {
+		return h, err
+	}
+	if h.After, err = mapHistoryValues(stmt, "after"); err != nil {
+		return h, err
+	}
+
+	return h, nil
+}
+
+func serializeHistoryValues(values beans.Optional[beans.TransactionHistoryValues]) (any, error) {
+	v, ok := values.Value()
+	if !ok {
+		return nil, nil
+	}
+
+	amount, err := serializeAmount(v.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(historyValues{
+		AccountID:  v.AccountID,
+		CategoryID: v.CategoryID,
+		PayeeID:    v.PayeeID,
+		Amount:     amount,
+		Date:       v.Date.String(),
+		Notes:      v.Notes.String(),
+		Status:     string(v.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func mapHistoryValues(stmt *sqlite.Stmt, col string) (beans.Optional[beans.TransactionHistoryValues], error) {
+	if stmt.IsNull(col) {
+		return beans.Optional[beans.TransactionHistoryValues]{}, nil
+	}
+
+	var stored historyValues
+	if err := json.Unmarshal([]byte(stmt.GetText(col)), &stored); err != nil {
+		return beans.Optional[beans.TransactionHistoryValues]{}, err
+	}
+
+	date, err := time.Parse("2006-01-02", stored.Date)
+	if err != nil {
+		return beans.Optional[beans.TransactionHistoryValues]{}, err
+	}
+
+	return beans.OptionalWrap(beans.TransactionHistoryValues{
+		AccountID:  stored.AccountID,
+		CategoryID: stored.CategoryID,
+		PayeeID:    stored.PayeeID,
+		Amount:     beans.NewAmount(stored.Amount, -2).Normalize(),
+		Date:       beans.NewDate(date),
+		Notes:      beans.NewTransactionNotes(stored.Notes),
+		Status:     beans.TransactionStatus(stored.Status),
+	}), nil
+}
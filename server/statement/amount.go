@@ -0,0 +1,71 @@
+package statement
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+var amountPattern = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)$`)
+
+var decimalCommaPattern = regexp.MustCompile(`,\d{1,2}\D*$`)
+
+var errInvalidAmount = errors.New("invalid amount")
+
+// Parses an amount as banks tend to write them. Currency symbols and
+// thousands separators are ignored, and a leading or trailing minus sign or
+// surrounding parentheses make the amount negative. A comma is read as the
+// decimal separator when it is followed by one or two digits and no period
+// comes after it. Blank values return an empty amount.
+func parseAmount(value string) (beans.Amount, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return beans.NewEmptyAmount(), nil
+	}
+
+	// swap a decimal comma for a period, dropping any period separators
+	lastComma := strings.LastIndex(value, ",")
+	lastPeriod := strings.LastIndex(value, ".")
+	if lastComma > lastPeriod && (lastPeriod >= 0 || decimalCommaPattern.MatchString(value)) {
+		value = strings.ReplaceAll(value[:lastComma], ".", "") + "." + value[lastComma+1:]
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9', r == '.':
+			digits.WriteRune(r)
+		case r == '-':
+			negative = !negative
+		case r == ',', r == ' ', r == '+':
+		default:
+			// skip currency symbols and codes, but only before any digits
+			if digits.Len() > 0 {
+				return beans.NewEmptyAmount(), errInvalidAmount
+			}
+		}
+	}
+
+	if !amountPattern.MatchString(digits.String()) {
+		return beans.NewEmptyAmount(), errInvalidAmount
+	}
+
+	amount, err := beans.NewAmountFromString(digits.String())
+	if err != nil {
+		return beans.NewEmptyAmount(), errInvalidAmount
+	}
+
+	if negative {
+		amount = beans.Arithmetic.Negate(amount)
+	}
+
+	return amount, nil
+}
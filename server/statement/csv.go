@@ -0,0 +1,146 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+// Reads transactions from a CSV file using the column mapping in format.
+func ReadCSV(r io.Reader, format beans.CSVFormat) ([]beans.ImportRow, error) {
+	layout, ok := format.DateFormat.Layout()
+	if !ok {
+		return nil, beans.NewError(beans.EINVALID, fmt.Sprintf("Date format %s is not supported.", format.DateFormat))
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	rows := []beans.ImportRow{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseError *csv.ParseError
+			if errors.As(err, &parseError) {
+				return nil, beans.NewError(beans.EINVALID, fmt.Sprintf("Row %d: %s.", parseError.Line, parseError.Err))
+			}
+			return nil, err
+		}
+
+		if first {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			if format.HasHeader {
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, err := readCSVRecord(record, layout, format.Columns)
+		if err == nil {
+			err = row.ValidateAll()
+		}
+		if err != nil {
+			return nil, rowError(line, err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readCSVRecord(record []string, layout string, columns beans.CSVColumns) (beans.ImportRow, error) {
+	column := func(index beans.Optional[int], name string) (string, error) {
+		i, ok := index.Value()
+		if !ok {
+			return "", nil
+		}
+		if i < 0 || i >= len(record) {
+			return "", fmt.Errorf("%s column %d does not exist", name, i)
+		}
+		return strings.TrimSpace(record[i]), nil
+	}
+
+	row := beans.ImportRow{}
+
+	// date
+	value, err := column(columns.Date, "date")
+	if err != nil {
+		return row, err
+	}
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		return row, fmt.Errorf("invalid date %q", value)
+	}
+	row.Date = beans.NewDate(date)
+
+	// amount, from a single column or from debit and credit columns
+	if _, ok := columns.Amount.Value(); ok {
+		value, err := column(columns.Amount, "amount")
+		if err != nil {
+			return row, err
+		}
+		if row.Amount, err = parseAmount(value); err != nil {
+			return row, fmt.Errorf("invalid amount %q", value)
+		}
+	} else {
+		debitValue, err := column(columns.Debit, "debit")
+		if err != nil {
+			return row, err
+		}
+		creditValue, err := column(columns.Credit, "credit")
+		if err != nil {
+			return row, err
+		}
+
+		debit, err := parseAmount(debitValue)
+		if err != nil {
+			return row, fmt.Errorf("invalid debit %q", debitValue)
+		}
+		credit, err := parseAmount(creditValue)
+		if err != nil {
+			return row, fmt.Errorf("invalid credit %q", creditValue)
+		}
+
+		// banks disagree on the sign of debits, so only the column matters
+		if !debit.Empty() || !credit.Empty() {
+			row.Amount, err = beans.Arithmetic.Add(abs(credit.OrZero()), beans.Arithmetic.Negate(abs(debit.OrZero())))
+			if err != nil {
+				return row, err
+			}
+		}
+	}
+
+	// payee
+	payee, err := column(columns.Payee, "payee")
+	if err != nil {
+		return row, err
+	}
+	row.PayeeName = beans.Name(payee)
+
+	// notes
+	notes, err := column(columns.Notes, "notes")
+	if err != nil {
+		return row, err
+	}
+	row.Notes = beans.NewTransactionNotes(notes)
+
+	return row, nil
+}
+
+func abs(amount beans.Amount) beans.Amount {
+	if amount.Compare(beans.NewAmount(0, 0)) < 0 {
+		return beans.Arithmetic.Negate(amount)
+	}
+	return amount
+}
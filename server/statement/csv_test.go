@@ -0,0 +1,135 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected beans.Amount
+	}{
+		{"", beans.NewEmptyAmount()},
+		{"5", beans.NewAmount(5, 0)},
+		{"-5.25", beans.NewAmount(-525, -2)},
+		{"5.25-", beans.NewAmount(-525, -2)},
+		{"(5.25)", beans.NewAmount(-525, -2)},
+		{"+5", beans.NewAmount(5, 0)},
+		{"$1,234.50", beans.NewAmount(123450, -2)},
+		{"-$1,234.50", beans.NewAmount(-123450, -2)},
+		{"USD 12", beans.NewAmount(12, 0)},
+		{"1.234,50", beans.NewAmount(123450, -2)},
+		{"12,5", beans.NewAmount(125, -1)},
+		{"1,234", beans.NewAmount(1234, 0)},
+		{".5", beans.NewAmount(5, -1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			amount, err := parseAmount(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, amount)
+		})
+	}
+
+	for _, value := range []string{"abc", "5 USD", "1.2.3", "-", "."} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := parseAmount(value)
+			assert.ErrorIs(t, err, errInvalidAmount)
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	format := beans.CSVFormat{
+		HasHeader:  true,
+		DateFormat: beans.DateFormatDMYPeriod,
+		Columns: beans.CSVColumns{
+			Date:   beans.OptionalWrap(1),
+			Amount: beans.OptionalWrap(0),
+			Payee:  beans.OptionalWrap(2),
+			Notes:  beans.OptionalWrap(3),
+		},
+	}
+
+	t.Run("can read", func(t *testing.T) {
+		rows, err := ReadCSV(strings.NewReader("\ufeffAmount,Date,Payee,Memo\n"+
+			"\"-1.234,50\",31.1.2024,  Landlord ,rent\n"+
+			"12,01.02.2024,Employer,\n"), format)
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.ImportRow{
+			{
+				Date:      testutils.NewDate(t, "2024-01-31"),
+				Amount:    beans.NewAmount(-123450, -2),
+				PayeeName: "Landlord",
+				Notes:     beans.NewTransactionNotes("rent"),
+			},
+			{
+				Date:      testutils.NewDate(t, "2024-02-01"),
+				Amount:    beans.NewAmount(12, 0),
+				PayeeName: "Employer",
+			},
+		}, rows)
+	})
+
+	t.Run("can read empty file", func(t *testing.T) {
+		rows, err := ReadCSV(strings.NewReader(""), format)
+		require.NoError(t, err)
+		assert.Len(t, rows, 0)
+	})
+
+	t.Run("reads first row without header", func(t *testing.T) {
+		format := format
+		format.HasHeader = false
+
+		rows, err := ReadCSV(strings.NewReader("5,1.1.2024,,\n"), format)
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("can read debit and credit", func(t *testing.T) {
+		format := beans.CSVFormat{
+			DateFormat: beans.DateFormatYMD,
+			Columns: beans.CSVColumns{
+				Date:   beans.OptionalWrap(0),
+				Debit:  beans.OptionalWrap(1),
+				Credit: beans.OptionalWrap(2),
+			},
+		}
+
+		rows, err := ReadCSV(strings.NewReader("2024-01-01,-5,\n2024-01-02,5,\n2024-01-03,,7\n"), format)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Equal(t, beans.NewAmount(-5, 0), rows[0].Amount)
+		assert.Equal(t, beans.NewAmount(-5, 0), rows[1].Amount)
+		assert.Equal(t, beans.NewAmount(7, 0), rows[2].Amount)
+	})
+
+	t.Run("requires amount", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("header\n,1.1.2024,,\n"), format)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Row 2: Amount is required.")
+	})
+
+	t.Run("reports missing column", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("header\n5,1.1.2024\n"), format)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Row 2: payee column 2 does not exist.")
+	})
+
+	t.Run("reports invalid amount", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("header\nfive,1.1.2024,,\n"), format)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Row 2: invalid amount "five".`)
+	})
+
+	t.Run("reports invalid date", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("header\n5,2024-01-01,,\n"), format)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Row 2: invalid date "2024-01-01".`)
+	})
+}
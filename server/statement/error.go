@@ -0,0 +1,28 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+func rowError(line int, err error) error {
+	return recordError(fmt.Sprintf("Row %d", line), err)
+}
+
+func transactionError(n int, err error) error {
+	return recordError(fmt.Sprintf("Transaction %d", n), err)
+}
+
+// Prefixes the error with the record it came from so the user can find it in
+// their file.
+func recordError(record string, err error) error {
+	var beansError beans.Error
+	if errors.As(err, &beansError) {
+		_, msg := beansError.BeansError()
+		return beans.NewError(beans.EINVALID, fmt.Sprintf("%s: %s", record, msg))
+	}
+
+	return beans.NewError(beans.EINVALID, fmt.Sprintf("%s: %s.", record, err))
+}
@@ -0,0 +1,150 @@
+package statement
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+// Reads transactions from an OFX or QFX file. Both the SGML based version 1
+// format, where elements holding a value are not closed, and the XML based
+// version 2 format are supported.
+func ReadOFX(r io.Reader) ([]beans.ImportRow, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// skip the headers, which differ between versions
+	content := string(b)
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, beans.NewError(beans.EINVALID, "File is not a valid OFX file.")
+	}
+
+	rows := []beans.ImportRow{}
+	var record map[string]string
+
+	finish := func() error {
+		if record == nil {
+			return nil
+		}
+
+		row, err := readOFXRecord(record)
+		if err == nil {
+			err = row.ValidateAll()
+		}
+		if err != nil {
+			return transactionError(len(rows)+1, err)
+		}
+
+		rows = append(rows, row)
+		record = nil
+		return nil
+	}
+
+	for _, element := range readOFXElements(content[start:]) {
+		switch {
+		case element.tag == "STMTTRN" && !element.closing:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			record = make(map[string]string)
+
+		case element.tag == "STMTTRN" || element.tag == "BANKTRANLIST":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+		case record != nil && !element.closing && element.value != "":
+			// the first value wins, so NAME is taken from the transaction
+			// rather than a nested PAYEE aggregate when both are present
+			if _, ok := record[element.tag]; !ok {
+				record[element.tag] = element.value
+			}
+		}
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func readOFXRecord(record map[string]string) (beans.ImportRow, error) {
+	row := beans.ImportRow{}
+
+	// dates look like YYYYMMDDHHMMSS.XXX[-5:EST], only the day matters
+	value := record["DTPOSTED"]
+	if len(value) < 8 {
+		return row, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return row, fmt.Errorf("invalid date %q", value)
+	}
+	row.Date = beans.NewDate(date)
+
+	value = record["TRNAMT"]
+	if row.Amount, err = parseAmount(value); err != nil {
+		return row, fmt.Errorf("invalid amount %q", value)
+	}
+
+	row.PayeeName = beans.Name(record["NAME"])
+	row.Notes = beans.NewTransactionNotes(record["MEMO"])
+	row.ImportID = beans.NewNullString(record["FITID"])
+
+	return row, nil
+}
+
+type ofxElement struct {
+	tag     string
+	closing bool
+	value   string
+}
+
+// Splits OFX content into its tags and the text following each tag.
+func readOFXElements(content string) []ofxElement {
+	elements := []ofxElement{}
+
+	for {
+		open := strings.IndexByte(content, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(content[open:], '>')
+		if end < 0 {
+			break
+		}
+
+		tag := strings.TrimSpace(content[open+1 : open+end])
+		content = content[open+end+1:]
+
+		// skip processing instructions and comments
+		if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		element := ofxElement{}
+		if strings.HasPrefix(tag, "/") {
+			element.closing = true
+			tag = tag[1:]
+		} else {
+			next := strings.IndexByte(content, '<')
+			if next < 0 {
+				next = len(content)
+			}
+			element.value = html.UnescapeString(strings.TrimSpace(content[:next]))
+		}
+		element.tag = strings.ToUpper(strings.TrimSpace(tag))
+
+		elements = append(elements, element)
+	}
+
+	return elements
+}
@@ -0,0 +1,123 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOFX(t *testing.T) {
+
+	t.Run("can read sgml", func(t *testing.T) {
+		rows, err := ReadOFX(strings.NewReader(`OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240110</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<DTSTART>20240101
+<DTEND>20240110
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240102120000.000[-5:EST]
+<TRNAMT>-12.50
+<FITID>1001
+<NAME>Bread &amp; Butter
+<MEMO>lunch
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240103
+<TRNAMT>100
+<FITID>1002
+<PAYEE><NAME>Employer<ADDR1>1 Main St</PAYEE>
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>87.50<DTASOF>20240110</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.ImportRow{
+			{
+				Date:      testutils.NewDate(t, "2024-01-02"),
+				Amount:    beans.NewAmount(-1250, -2),
+				PayeeName: "Bread & Butter",
+				Notes:     beans.NewTransactionNotes("lunch"),
+				ImportID:  beans.NewNullString("1001"),
+			},
+			{
+				Date:      testutils.NewDate(t, "2024-01-03"),
+				Amount:    beans.NewAmount(100, 0),
+				PayeeName: "Employer",
+				ImportID:  beans.NewNullString("1002"),
+			},
+		}, rows)
+	})
+
+	t.Run("can read xml", func(t *testing.T) {
+		rows, err := ReadOFX(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+    <BANKTRANLIST>
+      <STMTTRN>
+        <TRNTYPE>DEBIT</TRNTYPE>
+        <DTPOSTED>20240102</DTPOSTED>
+        <TRNAMT>-4.99</TRNAMT>
+        <FITID>abc-1</FITID>
+        <NAME>Coffee</NAME>
+        <!-- a comment -->
+      </STMTTRN>
+    </BANKTRANLIST>
+  </CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.ImportRow{
+			{
+				Date:      testutils.NewDate(t, "2024-01-02"),
+				Amount:    beans.NewAmount(-499, -2),
+				PayeeName: "Coffee",
+				ImportID:  beans.NewNullString("abc-1"),
+			},
+		}, rows)
+	})
+
+	t.Run("can read lowercase tags", func(t *testing.T) {
+		rows, err := ReadOFX(strings.NewReader(`<ofx><stmttrn><dtposted>20240102<trnamt>1<fitid>1</stmttrn></ofx>`))
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("can read statement without transactions", func(t *testing.T) {
+		rows, err := ReadOFX(strings.NewReader(`<OFX><BANKTRANLIST></BANKTRANLIST></OFX>`))
+		require.NoError(t, err)
+		assert.Len(t, rows, 0)
+	})
+
+	t.Run("rejects non ofx file", func(t *testing.T) {
+		_, err := ReadOFX(strings.NewReader("date,amount\n"))
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "File is not a valid OFX file.")
+	})
+
+	t.Run("reports invalid date", func(t *testing.T) {
+		_, err := ReadOFX(strings.NewReader(`<OFX><STMTTRN><DTPOSTED>2024<TRNAMT>1</STMTTRN></OFX>`))
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Transaction 1: invalid date "2024".`)
+	})
+
+	t.Run("requires amount", func(t *testing.T) {
+		_, err := ReadOFX(strings.NewReader(`<OFX><STMTTRN><DTPOSTED>20240102</STMTTRN></OFX>`))
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 1: Amount is required.")
+	})
+}
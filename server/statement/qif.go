@@ -0,0 +1,272 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+)
+
+// Sections of a QIF file that hold transactions. Others, such as category
+// lists and memorized transactions, are skipped.
+var qifTransactionTypes = map[string]bool{
+	"BANK":  true,
+	"CASH":  true,
+	"CCARD": true,
+	"OTH A": true,
+	"OTH L": true,
+}
+
+// Reads transactions from a QIF file. Dates in QIF files depend on the
+// program that wrote them, so the day, month, and year order comes from
+// dateFormat. Two digit years and an apostrophe before the year are accepted.
+func ReadQIF(r io.Reader, dateFormat beans.ImportDateFormat) ([]beans.ImportRow, error) {
+	layout, ok := dateFormat.Layout()
+	if !ok {
+		return nil, beans.NewError(beans.EINVALID, fmt.Sprintf("Date format %s is not supported.", dateFormat))
+	}
+	layout = normalizeQIFDate(layout)
+
+	rows := []beans.ImportRow{}
+	record := []string{}
+	inTransactions := false
+
+	scanner := bufio.NewScanner(r)
+	for first := true; scanner.Scan(); first = false {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "!"):
+			header := strings.ToUpper(strings.TrimSpace(line[1:]))
+			if strings.HasPrefix(header, "TYPE:") {
+				inTransactions = qifTransactionTypes[strings.TrimSpace(header[len("TYPE:"):])]
+			} else if header != "CLEAR:AUTOSWITCH" && header != "OPTION:AUTOSWITCH" {
+				inTransactions = false
+			}
+			record = record[:0]
+
+		case line[0] == '^':
+			if inTransactions && len(record) > 0 {
+				row, err := readQIFRecord(record, layout)
+				if err == nil {
+					err = row.ValidateAll()
+				}
+				if err != nil {
+					return nil, transactionError(len(rows)+1, err)
+				}
+
+				rows = append(rows, row)
+			}
+			record = record[:0]
+
+		default:
+			record = append(record, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func readQIFRecord(record []string, layout string) (beans.ImportRow, error) {
+	row := beans.ImportRow{}
+
+	for _, line := range record {
+		code, value := line[0], strings.TrimSpace(line[1:])
+
+		switch code {
+		case 'D':
+			date, err := parseQIFDate(value, layout)
+			if err != nil {
+				return row, err
+			}
+			row.Date = date
+
+		case 'T', 'U':
+			// U is a copy of T with more precision in some files
+			if code == 'U' && !row.Amount.Empty() {
+				continue
+			}
+			amount, err := parseAmount(value)
+			if err != nil {
+				return row, fmt.Errorf("invalid amount %q", value)
+			}
+			row.Amount = amount
+
+		case 'P':
+			row.PayeeName = beans.Name(value)
+
+		case 'M':
+			row.Notes = beans.NewTransactionNotes(value)
+
+		case 'L':
+			category, transfer := parseQIFCategory(value)
+			row.CategoryName = category
+			row.TransferAccountName = transfer
+
+		case 'S':
+			category, transfer := parseQIFCategory(value)
+			if transfer != "" {
+				return row, fmt.Errorf("transfers to %s on split lines are not supported", transfer)
+			}
+			row.Splits = append(row.Splits, beans.ImportSplit{CategoryName: category})
+
+		case '$':
+			if len(row.Splits) == 0 {
+				return row, fmt.Errorf("split amount %q has no category", value)
+			}
+			amount, err := parseAmount(value)
+			if err != nil {
+				return row, fmt.Errorf("invalid split amount %q", value)
+			}
+			row.Splits[len(row.Splits)-1].Amount = amount
+
+		case 'E':
+			if len(row.Splits) == 0 {
+				return row, fmt.Errorf("split memo %q has no category", value)
+			}
+			row.Splits[len(row.Splits)-1].Notes = beans.NewTransactionNotes(value)
+		}
+	}
+
+	if row.Date.Empty() {
+		return row, fmt.Errorf("missing date")
+	}
+
+	// the category of a split transaction is only a marker
+	if len(row.Splits) > 0 {
+		row.CategoryName = ""
+		row.TransferAccountName = ""
+	}
+
+	return row, nil
+}
+
+// Splits an L or S value into a category name or a transfer account name.
+// Classes, written after a slash, are dropped.
+func parseQIFCategory(value string) (category beans.Name, transfer beans.Name) {
+	if strings.HasPrefix(value, "[") {
+		if end := strings.Index(value, "]"); end > 0 {
+			return "", beans.Name(strings.TrimSpace(value[1:end]))
+		}
+	}
+
+	if i := strings.Index(value, "/"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.TrimSpace(value)
+	if value == "--Split--" {
+		return "", ""
+	}
+
+	return beans.Name(value), ""
+}
+
+func parseQIFDate(value string, layout string) (beans.Date, error) {
+	normalized := normalizeQIFDate(value)
+
+	date, err := time.Parse(layout, normalized)
+	if err != nil {
+		date, err = time.Parse(strings.Replace(layout, "2006", "06", 1), normalized)
+	}
+	if err != nil {
+		return beans.Date{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return beans.NewDate(date), nil
+}
+
+// Uses a slash for every separator so dates like 1/ 2'24 and 1-2-2024
+// parse with the same layout.
+func normalizeQIFDate(value string) string {
+	return strings.NewReplacer(" ", "", "'", "/", "-", "/", ".", "/").Replace(value)
+}
+
+// A transaction to export, with its split lines if it is split.
+type QIFTransaction struct {
+	beans.TransactionWithRelations
+	Splits []beans.Split
+}
+
+// Writes the transactions of a single account as a QIF file of the account's
+// type. Dates are written as MM/DD/YYYY.
+func WriteQIF(w io.Writer, accountType beans.AccountType, transactions []QIFTransaction) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, "!Type:%s\n", qifType(accountType))
+	for _, t := range transactions {
+		fmt.Fprintf(b, "D%s\n", t.Date.Format("01/02/2006"))
+		fmt.Fprintf(b, "T%s\n", formatQIFAmount(t.Amount))
+		if payee, ok := t.Payee.Value(); ok {
+			fmt.Fprintf(b, "P%s\n", qifValue(string(payee.Name)))
+		}
+		if !t.Notes.Empty() {
+			fmt.Fprintf(b, "M%s\n", qifValue(t.Notes.String()))
+		}
+		if account, ok := t.TransferAccount.Value(); ok {
+			fmt.Fprintf(b, "L[%s]\n", qifValue(string(account.Name)))
+		} else if category, ok := t.Category.Value(); ok {
+			fmt.Fprintf(b, "L%s\n", qifValue(string(category.Name)))
+		}
+		for _, split := range t.Splits {
+			fmt.Fprintf(b, "S%s\n", qifValue(string(split.Category.Name)))
+			if !split.Notes.Empty() {
+				fmt.Fprintf(b, "E%s\n", qifValue(split.Notes.String()))
+			}
+			fmt.Fprintf(b, "$%s\n", formatQIFAmount(split.Amount))
+		}
+		fmt.Fprintln(b, "^")
+	}
+
+	return b.Flush()
+}
+
+// Gets the QIF account type header for the account type.
+func qifType(accountType beans.AccountType) string {
+	switch accountType {
+	case beans.AccountCash:
+		return "Cash"
+	case beans.AccountCreditCard, beans.AccountLineOfCredit:
+		return "CCard"
+	case beans.AccountInvestment:
+		return "Invst"
+	case beans.AccountLoan:
+		return "Oth L"
+	default:
+		return "Bank"
+	}
+}
+
+// Formats the amount with two decimal places.
+func formatQIFAmount(amount beans.Amount) string {
+	cents := beans.NewAmountWithBigInt(amount.Coefficient(), amount.Exponent()+2)
+	value, err := cents.AsInt64()
+	if err != nil {
+		// more precision than cents, which validation does not allow
+		return amount.String()
+	}
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/100, value%100)
+}
+
+// Values must stay on one line.
+func qifValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,178 @@
+package statement
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bradenrayhorn/beans/server/beans"
+	"github.com/bradenrayhorn/beans/server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadQIF(t *testing.T) {
+
+	t.Run("can read", func(t *testing.T) {
+		rows, err := ReadQIF(strings.NewReader(`!Type:Cat
+NFood
+^
+!Type:Bank
+D1/ 2'24
+T-1,234.50
+PLandlord
+Mrent
+LHousing:Rent/Home
+^
+D01/03/2024
+U100.00
+T100.00
+PChecking
+L[Savings]
+^
+D1/4/24
+T-30
+L--Split--
+SFood
+Elunch
+$-20
+SHousehold
+$-10
+^
+`), beans.DateFormatMDYSlash)
+		require.NoError(t, err)
+
+		assert.Equal(t, []beans.ImportRow{
+			{
+				Date:         testutils.NewDate(t, "2024-01-02"),
+				Amount:       beans.NewAmount(-123450, -2),
+				PayeeName:    "Landlord",
+				Notes:        beans.NewTransactionNotes("rent"),
+				CategoryName: "Housing:Rent",
+			},
+			{
+				Date:                testutils.NewDate(t, "2024-01-03"),
+				Amount:              beans.NewAmount(10000, -2),
+				PayeeName:           "Checking",
+				TransferAccountName: "Savings",
+			},
+			{
+				Date:   testutils.NewDate(t, "2024-01-04"),
+				Amount: beans.NewAmount(-30, 0),
+				Splits: []beans.ImportSplit{
+					{Amount: beans.NewAmount(-20, 0), CategoryName: "Food", Notes: beans.NewTransactionNotes("lunch")},
+					{Amount: beans.NewAmount(-10, 0), CategoryName: "Household"},
+				},
+			},
+		}, rows)
+	})
+
+	t.Run("uses date format", func(t *testing.T) {
+		rows, err := ReadQIF(strings.NewReader("!Type:CCard\nD2.1'24\nT1\n^\n"), beans.DateFormatDMYPeriod)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, testutils.NewDate(t, "2024-01-02"), rows[0].Date)
+	})
+
+	t.Run("skips other sections", func(t *testing.T) {
+		rows, err := ReadQIF(strings.NewReader(`!Option:AutoSwitch
+!Account
+NChecking
+TBank
+^
+!Clear:AutoSwitch
+!Type:Bank
+D1/2/2024
+T1
+^
+!Type:Memorized
+D1/2/2024
+T1
+^
+`), beans.DateFormatMDYSlash)
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("reports invalid date", func(t *testing.T) {
+		_, err := ReadQIF(strings.NewReader("!Type:Bank\nD1/2/2024\nT1\n^\nD2024\nT1\n^\n"), beans.DateFormatMDYSlash)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, `Transaction 2: invalid date "2024".`)
+	})
+
+	t.Run("reports missing date", func(t *testing.T) {
+		_, err := ReadQIF(strings.NewReader("!Type:Bank\nT1\n^\n"), beans.DateFormatMDYSlash)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 1: missing date.")
+	})
+
+	t.Run("reports transfer on split", func(t *testing.T) {
+		_, err := ReadQIF(strings.NewReader("!Type:Bank\nD1/2/2024\nT1\nS[Savings]\n$1\n^\n"), beans.DateFormatMDYSlash)
+		testutils.AssertErrorAndCode(t, err, beans.EINVALID, "Transaction 1: transfers to Savings on split lines are not supported.")
+	})
+}
+
+func TestWriteQIF(t *testing.T) {
+	var b strings.Builder
+	err := WriteQIF(&b, beans.AccountChecking, []QIFTransaction{
+		{
+			TransactionWithRelations: beans.TransactionWithRelations{
+				Date:     testutils.NewDate(t, "2024-01-02"),
+				Amount:   beans.NewAmount(-125, -1),
+				Notes:    beans.NewTransactionNotes("two\nlines"),
+				Payee:    beans.OptionalWrap(beans.RelatedPayee{Name: "Store"}),
+				Category: beans.OptionalWrap(beans.RelatedCategory{Name: "Food"}),
+			},
+		},
+		{
+			TransactionWithRelations: beans.TransactionWithRelations{
+				Date:            testutils.NewDate(t, "2024-01-03"),
+				Amount:          beans.NewAmount(1, 3),
+				TransferAccount: beans.OptionalWrap(beans.RelatedAccount{Name: "Savings"}),
+			},
+		},
+		{
+			TransactionWithRelations: beans.TransactionWithRelations{
+				Date:   testutils.NewDate(t, "2024-01-04"),
+				Amount: beans.NewAmount(-5, -2),
+			},
+			Splits: []beans.Split{
+				{Amount: beans.NewAmount(-5, -2), Category: beans.RelatedCategory{Name: "Food"}, Notes: beans.NewTransactionNotes("snack")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `!Type:Bank
+D01/02/2024
+T-12.50
+PStore
+Mtwo lines
+LFood
+^
+D01/03/2024
+T1000.00
+L[Savings]
+^
+D01/04/2024
+T-0.05
+SFood
+Esnack
+$-0.05
+^
+`, b.String())
+}
+
+func TestWriteQIFType(t *testing.T) {
+	for accountType, header := range map[beans.AccountType]string{
+		"":                        "!Type:Bank\n",
+		beans.AccountChecking:     "!Type:Bank\n",
+		beans.AccountSavings:      "!Type:Bank\n",
+		beans.AccountCash:         "!Type:Cash\n",
+		beans.AccountCreditCard:   "!Type:CCard\n",
+		beans.AccountLineOfCredit: "!Type:CCard\n",
+		beans.AccountLoan:         "!Type:Oth L\n",
+		beans.AccountInvestment:   "!Type:Invst\n",
+	} {
+		var b strings.Builder
+		require.NoError(t, WriteQIF(&b, accountType, nil))
+		assert.Equal(t, header, b.String(), accountType)
+	}
+}